@@ -13,7 +13,11 @@ import (
 type BuildkitV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	BuildkitsGetter
+	BuildkitGroupsGetter
+	BuildkitPoolsGetter
+	BuildkitQuotasGetter
 	BuildkitTemplatesGetter
+	ClusterBuildkitTemplatesGetter
 }
 
 // BuildkitV1alpha1Client is used to interact with features provided by the buildkit.seatgeek.io group.
@@ -25,10 +29,26 @@ func (c *BuildkitV1alpha1Client) Buildkits(namespace string) BuildkitInterface {
 	return newBuildkits(c, namespace)
 }
 
+func (c *BuildkitV1alpha1Client) BuildkitGroups(namespace string) BuildkitGroupInterface {
+	return newBuildkitGroups(c, namespace)
+}
+
+func (c *BuildkitV1alpha1Client) BuildkitPools(namespace string) BuildkitPoolInterface {
+	return newBuildkitPools(c, namespace)
+}
+
+func (c *BuildkitV1alpha1Client) BuildkitQuotas(namespace string) BuildkitQuotaInterface {
+	return newBuildkitQuotas(c, namespace)
+}
+
 func (c *BuildkitV1alpha1Client) BuildkitTemplates(namespace string) BuildkitTemplateInterface {
 	return newBuildkitTemplates(c, namespace)
 }
 
+func (c *BuildkitV1alpha1Client) ClusterBuildkitTemplates() ClusterBuildkitTemplateInterface {
+	return newClusterBuildkitTemplates(c)
+}
+
 // NewForConfig creates a new BuildkitV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
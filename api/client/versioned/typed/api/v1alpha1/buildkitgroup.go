@@ -0,0 +1,54 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	scheme "github.com/seatgeek/buildkit-operator/api/client/versioned/scheme"
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// BuildkitGroupsGetter has a method to return a BuildkitGroupInterface.
+// A group's client should implement this interface.
+type BuildkitGroupsGetter interface {
+	BuildkitGroups(namespace string) BuildkitGroupInterface
+}
+
+// BuildkitGroupInterface has methods to work with BuildkitGroup resources.
+type BuildkitGroupInterface interface {
+	Create(ctx context.Context, buildkitGroup *apiv1alpha1.BuildkitGroup, opts v1.CreateOptions) (*apiv1alpha1.BuildkitGroup, error)
+	Update(ctx context.Context, buildkitGroup *apiv1alpha1.BuildkitGroup, opts v1.UpdateOptions) (*apiv1alpha1.BuildkitGroup, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, buildkitGroup *apiv1alpha1.BuildkitGroup, opts v1.UpdateOptions) (*apiv1alpha1.BuildkitGroup, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.BuildkitGroup, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.BuildkitGroupList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.BuildkitGroup, err error)
+	BuildkitGroupExpansion
+}
+
+// buildkitGroups implements BuildkitGroupInterface
+type buildkitGroups struct {
+	*gentype.ClientWithList[*apiv1alpha1.BuildkitGroup, *apiv1alpha1.BuildkitGroupList]
+}
+
+// newBuildkitGroups returns a BuildkitGroups
+func newBuildkitGroups(c *BuildkitV1alpha1Client, namespace string) *buildkitGroups {
+	return &buildkitGroups{
+		gentype.NewClientWithList[*apiv1alpha1.BuildkitGroup, *apiv1alpha1.BuildkitGroupList](
+			"buildkitgroups",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.BuildkitGroup { return &apiv1alpha1.BuildkitGroup{} },
+			func() *apiv1alpha1.BuildkitGroupList { return &apiv1alpha1.BuildkitGroupList{} },
+		),
+	}
+}
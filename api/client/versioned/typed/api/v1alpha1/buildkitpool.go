@@ -0,0 +1,54 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	scheme "github.com/seatgeek/buildkit-operator/api/client/versioned/scheme"
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// BuildkitPoolsGetter has a method to return a BuildkitPoolInterface.
+// A group's client should implement this interface.
+type BuildkitPoolsGetter interface {
+	BuildkitPools(namespace string) BuildkitPoolInterface
+}
+
+// BuildkitPoolInterface has methods to work with BuildkitPool resources.
+type BuildkitPoolInterface interface {
+	Create(ctx context.Context, buildkitPool *apiv1alpha1.BuildkitPool, opts v1.CreateOptions) (*apiv1alpha1.BuildkitPool, error)
+	Update(ctx context.Context, buildkitPool *apiv1alpha1.BuildkitPool, opts v1.UpdateOptions) (*apiv1alpha1.BuildkitPool, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, buildkitPool *apiv1alpha1.BuildkitPool, opts v1.UpdateOptions) (*apiv1alpha1.BuildkitPool, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.BuildkitPool, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.BuildkitPoolList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.BuildkitPool, err error)
+	BuildkitPoolExpansion
+}
+
+// buildkitPools implements BuildkitPoolInterface
+type buildkitPools struct {
+	*gentype.ClientWithList[*apiv1alpha1.BuildkitPool, *apiv1alpha1.BuildkitPoolList]
+}
+
+// newBuildkitPools returns a BuildkitPools
+func newBuildkitPools(c *BuildkitV1alpha1Client, namespace string) *buildkitPools {
+	return &buildkitPools{
+		gentype.NewClientWithList[*apiv1alpha1.BuildkitPool, *apiv1alpha1.BuildkitPoolList](
+			"buildkitpools",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.BuildkitPool { return &apiv1alpha1.BuildkitPool{} },
+			func() *apiv1alpha1.BuildkitPoolList { return &apiv1alpha1.BuildkitPoolList{} },
+		),
+	}
+}
@@ -0,0 +1,54 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	scheme "github.com/seatgeek/buildkit-operator/api/client/versioned/scheme"
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// BuildkitQuotasGetter has a method to return a BuildkitQuotaInterface.
+// A group's client should implement this interface.
+type BuildkitQuotasGetter interface {
+	BuildkitQuotas(namespace string) BuildkitQuotaInterface
+}
+
+// BuildkitQuotaInterface has methods to work with BuildkitQuota resources.
+type BuildkitQuotaInterface interface {
+	Create(ctx context.Context, buildkitQuota *apiv1alpha1.BuildkitQuota, opts v1.CreateOptions) (*apiv1alpha1.BuildkitQuota, error)
+	Update(ctx context.Context, buildkitQuota *apiv1alpha1.BuildkitQuota, opts v1.UpdateOptions) (*apiv1alpha1.BuildkitQuota, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, buildkitQuota *apiv1alpha1.BuildkitQuota, opts v1.UpdateOptions) (*apiv1alpha1.BuildkitQuota, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.BuildkitQuota, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.BuildkitQuotaList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.BuildkitQuota, err error)
+	BuildkitQuotaExpansion
+}
+
+// buildkitQuotas implements BuildkitQuotaInterface
+type buildkitQuotas struct {
+	*gentype.ClientWithList[*apiv1alpha1.BuildkitQuota, *apiv1alpha1.BuildkitQuotaList]
+}
+
+// newBuildkitQuotas returns a BuildkitQuotas
+func newBuildkitQuotas(c *BuildkitV1alpha1Client, namespace string) *buildkitQuotas {
+	return &buildkitQuotas{
+		gentype.NewClientWithList[*apiv1alpha1.BuildkitQuota, *apiv1alpha1.BuildkitQuotaList](
+			"buildkitquotas",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.BuildkitQuota { return &apiv1alpha1.BuildkitQuota{} },
+			func() *apiv1alpha1.BuildkitQuotaList { return &apiv1alpha1.BuildkitQuotaList{} },
+		),
+	}
+}
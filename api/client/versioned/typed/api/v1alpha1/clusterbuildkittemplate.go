@@ -0,0 +1,54 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	scheme "github.com/seatgeek/buildkit-operator/api/client/versioned/scheme"
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterBuildkitTemplatesGetter has a method to return a ClusterBuildkitTemplateInterface.
+// A group's client should implement this interface.
+type ClusterBuildkitTemplatesGetter interface {
+	ClusterBuildkitTemplates() ClusterBuildkitTemplateInterface
+}
+
+// ClusterBuildkitTemplateInterface has methods to work with ClusterBuildkitTemplate resources.
+type ClusterBuildkitTemplateInterface interface {
+	Create(ctx context.Context, clusterBuildkitTemplate *apiv1alpha1.ClusterBuildkitTemplate, opts v1.CreateOptions) (*apiv1alpha1.ClusterBuildkitTemplate, error)
+	Update(ctx context.Context, clusterBuildkitTemplate *apiv1alpha1.ClusterBuildkitTemplate, opts v1.UpdateOptions) (*apiv1alpha1.ClusterBuildkitTemplate, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterBuildkitTemplate *apiv1alpha1.ClusterBuildkitTemplate, opts v1.UpdateOptions) (*apiv1alpha1.ClusterBuildkitTemplate, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.ClusterBuildkitTemplate, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.ClusterBuildkitTemplateList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.ClusterBuildkitTemplate, err error)
+	ClusterBuildkitTemplateExpansion
+}
+
+// clusterBuildkitTemplates implements ClusterBuildkitTemplateInterface
+type clusterBuildkitTemplates struct {
+	*gentype.ClientWithList[*apiv1alpha1.ClusterBuildkitTemplate, *apiv1alpha1.ClusterBuildkitTemplateList]
+}
+
+// newClusterBuildkitTemplates returns a ClusterBuildkitTemplates
+func newClusterBuildkitTemplates(c *BuildkitV1alpha1Client) *clusterBuildkitTemplates {
+	return &clusterBuildkitTemplates{
+		gentype.NewClientWithList[*apiv1alpha1.ClusterBuildkitTemplate, *apiv1alpha1.ClusterBuildkitTemplateList](
+			"clusterbuildkittemplates",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *apiv1alpha1.ClusterBuildkitTemplate { return &apiv1alpha1.ClusterBuildkitTemplate{} },
+			func() *apiv1alpha1.ClusterBuildkitTemplateList { return &apiv1alpha1.ClusterBuildkitTemplateList{} },
+		),
+	}
+}
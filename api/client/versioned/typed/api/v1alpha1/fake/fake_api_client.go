@@ -16,10 +16,26 @@ func (c *FakeBuildkitV1alpha1) Buildkits(namespace string) v1alpha1.BuildkitInte
 	return newFakeBuildkits(c, namespace)
 }
 
+func (c *FakeBuildkitV1alpha1) BuildkitGroups(namespace string) v1alpha1.BuildkitGroupInterface {
+	return newFakeBuildkitGroups(c, namespace)
+}
+
+func (c *FakeBuildkitV1alpha1) BuildkitPools(namespace string) v1alpha1.BuildkitPoolInterface {
+	return newFakeBuildkitPools(c, namespace)
+}
+
+func (c *FakeBuildkitV1alpha1) BuildkitQuotas(namespace string) v1alpha1.BuildkitQuotaInterface {
+	return newFakeBuildkitQuotas(c, namespace)
+}
+
 func (c *FakeBuildkitV1alpha1) BuildkitTemplates(namespace string) v1alpha1.BuildkitTemplateInterface {
 	return newFakeBuildkitTemplates(c, namespace)
 }
 
+func (c *FakeBuildkitV1alpha1) ClusterBuildkitTemplates() v1alpha1.ClusterBuildkitTemplateInterface {
+	return newFakeClusterBuildkitTemplates(c)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeBuildkitV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,36 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package fake
+
+import (
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/client/versioned/typed/api/v1alpha1"
+	v1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeBuildkitGroups implements BuildkitGroupInterface
+type fakeBuildkitGroups struct {
+	*gentype.FakeClientWithList[*v1alpha1.BuildkitGroup, *v1alpha1.BuildkitGroupList]
+	Fake *FakeBuildkitV1alpha1
+}
+
+func newFakeBuildkitGroups(fake *FakeBuildkitV1alpha1, namespace string) apiv1alpha1.BuildkitGroupInterface {
+	return &fakeBuildkitGroups{
+		gentype.NewFakeClientWithList[*v1alpha1.BuildkitGroup, *v1alpha1.BuildkitGroupList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("buildkitgroups"),
+			v1alpha1.SchemeGroupVersion.WithKind("BuildkitGroup"),
+			func() *v1alpha1.BuildkitGroup { return &v1alpha1.BuildkitGroup{} },
+			func() *v1alpha1.BuildkitGroupList { return &v1alpha1.BuildkitGroupList{} },
+			func(dst, src *v1alpha1.BuildkitGroupList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.BuildkitGroupList) []*v1alpha1.BuildkitGroup {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.BuildkitGroupList, items []*v1alpha1.BuildkitGroup) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
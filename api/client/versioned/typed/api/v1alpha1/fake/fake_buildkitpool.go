@@ -0,0 +1,36 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package fake
+
+import (
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/client/versioned/typed/api/v1alpha1"
+	v1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeBuildkitPools implements BuildkitPoolInterface
+type fakeBuildkitPools struct {
+	*gentype.FakeClientWithList[*v1alpha1.BuildkitPool, *v1alpha1.BuildkitPoolList]
+	Fake *FakeBuildkitV1alpha1
+}
+
+func newFakeBuildkitPools(fake *FakeBuildkitV1alpha1, namespace string) apiv1alpha1.BuildkitPoolInterface {
+	return &fakeBuildkitPools{
+		gentype.NewFakeClientWithList[*v1alpha1.BuildkitPool, *v1alpha1.BuildkitPoolList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("buildkitpools"),
+			v1alpha1.SchemeGroupVersion.WithKind("BuildkitPool"),
+			func() *v1alpha1.BuildkitPool { return &v1alpha1.BuildkitPool{} },
+			func() *v1alpha1.BuildkitPoolList { return &v1alpha1.BuildkitPoolList{} },
+			func(dst, src *v1alpha1.BuildkitPoolList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.BuildkitPoolList) []*v1alpha1.BuildkitPool {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.BuildkitPoolList, items []*v1alpha1.BuildkitPool) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,36 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package fake
+
+import (
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/client/versioned/typed/api/v1alpha1"
+	v1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeBuildkitQuotas implements BuildkitQuotaInterface
+type fakeBuildkitQuotas struct {
+	*gentype.FakeClientWithList[*v1alpha1.BuildkitQuota, *v1alpha1.BuildkitQuotaList]
+	Fake *FakeBuildkitV1alpha1
+}
+
+func newFakeBuildkitQuotas(fake *FakeBuildkitV1alpha1, namespace string) apiv1alpha1.BuildkitQuotaInterface {
+	return &fakeBuildkitQuotas{
+		gentype.NewFakeClientWithList[*v1alpha1.BuildkitQuota, *v1alpha1.BuildkitQuotaList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("buildkitquotas"),
+			v1alpha1.SchemeGroupVersion.WithKind("BuildkitQuota"),
+			func() *v1alpha1.BuildkitQuota { return &v1alpha1.BuildkitQuota{} },
+			func() *v1alpha1.BuildkitQuotaList { return &v1alpha1.BuildkitQuotaList{} },
+			func(dst, src *v1alpha1.BuildkitQuotaList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.BuildkitQuotaList) []*v1alpha1.BuildkitQuota {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.BuildkitQuotaList, items []*v1alpha1.BuildkitQuota) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,36 @@
+// Code generated by client-gen-v0.32. DO NOT EDIT.
+
+package fake
+
+import (
+	apiv1alpha1 "github.com/seatgeek/buildkit-operator/api/client/versioned/typed/api/v1alpha1"
+	v1alpha1 "github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterBuildkitTemplates implements ClusterBuildkitTemplateInterface
+type fakeClusterBuildkitTemplates struct {
+	*gentype.FakeClientWithList[*v1alpha1.ClusterBuildkitTemplate, *v1alpha1.ClusterBuildkitTemplateList]
+	Fake *FakeBuildkitV1alpha1
+}
+
+func newFakeClusterBuildkitTemplates(fake *FakeBuildkitV1alpha1) apiv1alpha1.ClusterBuildkitTemplateInterface {
+	return &fakeClusterBuildkitTemplates{
+		gentype.NewFakeClientWithList[*v1alpha1.ClusterBuildkitTemplate, *v1alpha1.ClusterBuildkitTemplateList](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("clusterbuildkittemplates"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterBuildkitTemplate"),
+			func() *v1alpha1.ClusterBuildkitTemplate { return &v1alpha1.ClusterBuildkitTemplate{} },
+			func() *v1alpha1.ClusterBuildkitTemplateList { return &v1alpha1.ClusterBuildkitTemplateList{} },
+			func(dst, src *v1alpha1.ClusterBuildkitTemplateList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterBuildkitTemplateList) []*v1alpha1.ClusterBuildkitTemplate {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterBuildkitTemplateList, items []*v1alpha1.ClusterBuildkitTemplate) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -4,4 +4,12 @@ package v1alpha1
 
 type BuildkitExpansion interface{}
 
+type BuildkitGroupExpansion interface{}
+
+type BuildkitPoolExpansion interface{}
+
+type BuildkitQuotaExpansion interface{}
+
 type BuildkitTemplateExpansion interface{}
+
+type ClusterBuildkitTemplateExpansion interface{}
@@ -0,0 +1,133 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GroupLabel is set on every Buildkit created by a BuildkitGroup, with the group name as its value.
+const GroupLabel = "buildkit.seatgeek.io/group"
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=buildkitgroup
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Platforms",type=string,JSONPath=`.spec.platforms[*].platform`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyNodes`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:validation:XValidation:rule="self.metadata.name.matches('^[a-z]([-a-z0-9]*[a-z0-9])?$')",message="name must be a DNS-1035 label, since the group's Buildkits are named after it"
+// +kubebuilder:validation:XValidation:rule="!has(self.spec) || self.spec.platforms.all(p, size(self.metadata.name) + 1 + size(p.platform) <= 63)",message="name is too long; the group's Buildkits are named <name>-<platform>, which must be no more than 63 characters"
+type BuildkitGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BuildkitGroupSpec   `json:"spec,omitempty"`
+	Status BuildkitGroupStatus `json:"status,omitempty"`
+}
+
+// BuildkitGroupSpec describes a multi-platform builder made of one Buildkit instance per platform, each started from
+// a template that schedules it onto nodes of that platform.
+type BuildkitGroupSpec struct {
+	// Platforms lists the platforms the group builds for, and the template each one's Buildkit is started from
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=platform
+	Platforms []BuildkitGroupPlatform `json:"platforms"`
+}
+
+// BuildkitGroupPlatform maps a platform onto the template of the Buildkit instance that builds for it.
+// +kubebuilder:validation:XValidation:rule="has(self.template) != has(self.templateRef)",message="exactly one of template or templateRef must be set"
+type BuildkitGroupPlatform struct {
+	// Platform is the OS and architecture the node builds for, like linux/amd64 or linux/arm64/v8
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+/[a-z0-9]+(/[a-z0-9]+)?$`
+	// +kubebuilder:validation:MaxLength=61
+	Platform string `json:"platform"`
+
+	// Template is the name of the BuildkitTemplate the platform's Buildkit is started from.
+	// Exactly one of template or templateRef must be set.
+	// +kubebuilder:validation:Optional
+	Template string `json:"template,omitempty"`
+
+	// TemplateRef references the template the platform's Buildkit is started from by kind and name.
+	// Exactly one of template or templateRef must be set.
+	// +kubebuilder:validation:Optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+}
+
+// NodeName returns the name of the Buildkit that builds for the platform in the given group, like mygroup-linux-arm64.
+func (p *BuildkitGroupPlatform) NodeName(group string) string {
+	return group + "-" + strings.ReplaceAll(p.Platform, "/", "-")
+}
+
+// BuildkitGroupNode reports on the Buildkit that builds for one of the group's platforms.
+type BuildkitGroupNode struct {
+	// Platform is the platform the node builds for
+	Platform string `json:"platform"`
+
+	// Buildkit is the name of the node's Buildkit instance
+	Buildkit string `json:"buildkit"`
+
+	// Phase is the phase of the node's Buildkit instance
+	Phase BuildkitPhase `json:"phase,omitempty"`
+
+	// Endpoint is the tcp URI of the node's Buildkit instance, once it's ready
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ClientTLSSecretName is the name of the Secret holding the client certificate of the node's Buildkit instance,
+	// when its template enables TLS
+	ClientTLSSecretName string `json:"clientTLSSecretName,omitempty"`
+}
+
+type BuildkitGroupStatus struct {
+	api.ConditionedStatus `json:",inline"`
+
+	// ResourceRefs is a list of all resources managed by this object.
+	ResourceRefs []api.TypedObjectRef `json:"resourceRefs,omitempty"`
+
+	// Nodes lists the group's Buildkit instances, in the order of its platforms
+	Nodes []BuildkitGroupNode `json:"nodes,omitempty"`
+
+	// ReadyNodes is how many of the group's Buildkit instances are ready; the group is only ready once all of them are
+	ReadyNodes int32 `json:"readyNodes"`
+}
+
+func (b *BuildkitGroup) GetConditions() []api.Condition {
+	return b.Status.Conditions
+}
+
+func (b *BuildkitGroup) SetConditions(cond ...api.Condition) {
+	b.Status.SetConditions(cond...)
+}
+
+func (b *BuildkitGroup) GetCondition(t api.ConditionType) api.Condition {
+	return b.Status.GetCondition(t)
+}
+
+func (b *BuildkitGroup) SetManagedResources(refs []api.TypedObjectRef) {
+	b.Status.ResourceRefs = refs
+}
+
+func (b *BuildkitGroup) GetManagedResources() []api.TypedObjectRef {
+	return b.Status.ResourceRefs
+}
+
+// +kubebuilder:object:root=true
+type BuildkitGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BuildkitGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BuildkitGroup{}, &BuildkitGroupList{})
+}
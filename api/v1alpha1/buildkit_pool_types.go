@@ -0,0 +1,91 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package v1alpha1
+
+import (
+	"github.com/reddit/achilles-sdk-api/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PoolLabel is set on every pod started by a BuildkitPool, with the pool name as its value.
+// It is kept once a Buildkit instance claims the pod, at which point the pod also gains the InstanceLabel.
+const PoolLabel = "buildkit.seatgeek.io/pool"
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=buildkitpool
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.template`
+// +kubebuilder:printcolumn:name="Size",type=integer,JSONPath=`.spec.size`
+// +kubebuilder:printcolumn:name="Warm",type=integer,JSONPath=`.status.warm`
+// +kubebuilder:printcolumn:name="Starting",type=integer,JSONPath=`.status.starting`
+// +kubebuilder:printcolumn:name="Bound",type=integer,JSONPath=`.status.bound`
+type BuildkitPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BuildkitPoolSpec   `json:"spec,omitempty"`
+	Status BuildkitPoolStatus `json:"status,omitempty"`
+}
+
+type BuildkitPoolSpec struct {
+	// Template is the name of the BuildkitTemplate used to start warm pods.
+	// Templates that enable TLS or use PersistentVolumeClaim storage are not supported, since those are set up per Buildkit instance.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template"`
+
+	// Size is the number of idle, ready pods to keep warm; default is 1
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	Size int32 `json:"size"`
+}
+
+type BuildkitPoolStatus struct {
+	api.ConditionedStatus `json:",inline"`
+
+	// ResourceRefs is a list of all resources managed by this object.
+	ResourceRefs []api.TypedObjectRef `json:"resourceRefs,omitempty"`
+
+	// Warm is the number of ready pods waiting to be claimed by a Buildkit instance.
+	Warm int32 `json:"warm"`
+	// Starting is the number of unclaimed pods that are not ready yet.
+	Starting int32 `json:"starting"`
+	// Bound is the number of pods started by this pool that have since been claimed by a Buildkit instance.
+	Bound int32 `json:"bound"`
+}
+
+func (b *BuildkitPool) GetConditions() []api.Condition {
+	return b.Status.Conditions
+}
+
+func (b *BuildkitPool) SetConditions(cond ...api.Condition) {
+	b.Status.SetConditions(cond...)
+}
+
+func (b *BuildkitPool) GetCondition(t api.ConditionType) api.Condition {
+	return b.Status.GetCondition(t)
+}
+
+func (b *BuildkitPool) SetManagedResources(refs []api.TypedObjectRef) {
+	b.Status.ResourceRefs = refs
+}
+
+func (b *BuildkitPool) GetManagedResources() []api.TypedObjectRef {
+	return b.Status.ResourceRefs
+}
+
+// +kubebuilder:object:root=true
+type BuildkitPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BuildkitPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BuildkitPool{}, &BuildkitPoolList{})
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package v1alpha1
+
+import (
+	"github.com/reddit/achilles-sdk-api/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=buildkitquota
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Instances",type=integer,JSONPath=`.status.used.instances`
+// +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.instances`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type BuildkitQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BuildkitQuotaSpec   `json:"spec,omitempty"`
+	Status BuildkitQuotaStatus `json:"status,omitempty"`
+}
+
+// BuildkitQuotaSpec caps what the Buildkits in the quota's namespace may use between them. New Buildkits that would
+// take the namespace over any of the caps are rejected; existing ones are left alone when a cap is lowered.
+type BuildkitQuotaSpec struct {
+	// Instances caps how many Buildkits may exist in the namespace, suspended ones included
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Instances *int32 `json:"instances,omitempty"`
+
+	// Requests caps the total resource requests of the Buildkits in the namespace, after their template's defaults and
+	// maximums are applied. Once a resource is capped, Buildkits must request it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['cpu', 'memory', 'ephemeral-storage'])",message="only cpu, memory and ephemeral-storage may be capped"
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// Limits caps the total resource limits of the Buildkits in the namespace, after their template's defaults and
+	// maximums are applied. Once a resource is capped, Buildkits must have a limit for it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['cpu', 'memory', 'ephemeral-storage'])",message="only cpu, memory and ephemeral-storage may be capped"
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+}
+
+// BuildkitQuotaUsage sums up what the Buildkits in a namespace use.
+type BuildkitQuotaUsage struct {
+	// Instances is how many Buildkits there are in the namespace
+	Instances int32 `json:"instances"`
+
+	// Requests is the total cpu, memory and ephemeral-storage requests of the Buildkits in the namespace
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// Limits is the total cpu, memory and ephemeral-storage limits of the Buildkits in the namespace
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+}
+
+type BuildkitQuotaStatus struct {
+	api.ConditionedStatus `json:",inline"`
+
+	// ResourceRefs is a list of all resources managed by this object.
+	ResourceRefs []api.TypedObjectRef `json:"resourceRefs,omitempty"`
+
+	// Used is what the Buildkits in the namespace currently use
+	Used BuildkitQuotaUsage `json:"used"`
+}
+
+func (b *BuildkitQuota) GetConditions() []api.Condition {
+	return b.Status.Conditions
+}
+
+func (b *BuildkitQuota) SetConditions(cond ...api.Condition) {
+	b.Status.SetConditions(cond...)
+}
+
+func (b *BuildkitQuota) GetCondition(t api.ConditionType) api.Condition {
+	return b.Status.GetCondition(t)
+}
+
+func (b *BuildkitQuota) SetManagedResources(refs []api.TypedObjectRef) {
+	b.Status.ResourceRefs = refs
+}
+
+func (b *BuildkitQuota) GetManagedResources() []api.TypedObjectRef {
+	return b.Status.ResourceRefs
+}
+
+// +kubebuilder:object:root=true
+type BuildkitQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BuildkitQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BuildkitQuota{}, &BuildkitQuotaList{})
+}
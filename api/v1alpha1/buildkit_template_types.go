@@ -5,6 +5,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	"github.com/reddit/achilles-sdk-api/api"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,11 +14,41 @@ import (
 
 const BuildkitTemplateNameMaxLength = 57
 
+// DefaultTemplateAnnotation marks a BuildkitTemplate as the default for Buildkits in its namespace when set to "true".
+// At most one BuildkitTemplate per namespace may be marked as the default.
+const DefaultTemplateAnnotation = "buildkit.seatgeek.io/default-template"
+
+// DefaultTemplateLabel names the BuildkitTemplate that Buildkits in a namespace use when they don't set one.
+// It is set on the namespace and takes precedence over DefaultTemplateAnnotation.
+const DefaultTemplateLabel = "buildkit.seatgeek.io/default-template"
+
+const (
+	// DefaultTLSCertificateDuration is the default validity of server and client certificates issued for Buildkit instances.
+	DefaultTLSCertificateDuration = 90 * 24 * time.Hour
+	// DefaultTLSRenewBefore is the default amount of time before expiry that issued certificates are rotated.
+	DefaultTLSRenewBefore = 30 * 24 * time.Hour
+	// DefaultRecoveryMaxRestarts is the default number of times a failed Buildkit pod is replaced under the OnFailure recovery policy.
+	DefaultRecoveryMaxRestarts = 3
+	// DefaultRecoveryInitialBackoff is the default delay before the first failed Buildkit pod is replaced.
+	DefaultRecoveryInitialBackoff = 10 * time.Second
+	// DefaultRecoveryMaxBackoff is the default upper bound on the delay before a failed Buildkit pod is replaced.
+	DefaultRecoveryMaxBackoff = 5 * time.Minute
+)
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=buildkittemplate
 // +kubebuilder:subresource:status
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalInstances`
+// +kubebuilder:printcolumn:name="Running",type=integer,JSONPath=`.status.instances`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyInstances`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedInstances`
+// +kubebuilder:printcolumn:name="Outdated",type=integer,JSONPath=`.status.outOfDateInstances`
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queuedInstances`
+// +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxInstances`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type BuildkitTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -39,10 +71,24 @@ type BuildkitTemplateSpec struct {
 	// +kubebuilder:default=1234
 	Port int32 `json:"port"`
 
+	// EndpointType selects what address is published in a Buildkit's status.endpoint: the pod IP,
+	// or the DNS name of the stable per-instance Service; default is PodIP.
+	// Templates with TLS enabled always publish the Service, which their server certificates are valid for.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=PodIP;Service
+	// +kubebuilder:default=PodIP
+	EndpointType EndpointType `json:"endpointType,omitempty"`
+
 	// BuildkitdToml is the configuration for Buildkit in TOML format
 	// +kubebuilder:validation:Optional
 	BuildkitdToml string `json:"buildkitdToml,omitempty"`
 
+	// Config holds typed buildkitd settings that are rendered to TOML and merged on top of buildkitdToml.
+	// A setting made here wins over the same setting made in buildkitdToml; tables are merged key by key,
+	// while any other value, arrays included, is replaced as a whole.
+	// +kubebuilder:validation:Optional
+	Config *BuildkitdConfig `json:"config,omitempty"`
+
 	// Image is the container image to use for the Buildkit instance
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="moby/buildkit:latest"
@@ -53,6 +99,16 @@ type BuildkitTemplateSpec struct {
 	// +kubebuilder:default=IfNotPresent
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 
+	// ImagePullSecrets are Secrets in the namespace of each Buildkit used to pull the Buildkit image itself
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// RegistryAuth lists Secrets of type kubernetes.io/dockerconfigjson, in the namespace of each Buildkit, whose
+	// credentials are merged into the Docker config that buildkitd uses to pull from and push to registries.
+	// When several Secrets hold credentials for the same registry, the one listed last wins.
+	// +kubebuilder:validation:Optional
+	RegistryAuth []corev1.LocalObjectReference `json:"registryAuth,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Resources BuildkitTemplateResources `json:"resources,omitempty"`
 
@@ -66,6 +122,11 @@ type BuildkitTemplateSpec struct {
 	// +kubebuilder:validation:Optional
 	Scheduling BuildkitTemplatePodScheduling `json:"scheduling,omitempty"`
 
+	// AllowedOverrides lists the parts of the scheduling that Buildkit instances may override with their own.
+	// Nothing may be overridden unless it's listed here.
+	// +kubebuilder:validation:Optional
+	AllowedOverrides *BuildkitTemplateAllowedOverrides `json:"allowedOverrides,omitempty"`
+
 	// Lifecycle defines the lifecycle settings for the Buildkit pods
 	// +kubebuilder:validation:Optional
 	Lifecycle BuildkitTemplatePodLifecycle `json:"lifecycle,omitempty"`
@@ -84,8 +145,62 @@ type BuildkitTemplateSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=true
 	HostUsers *bool `json:"hostUsers,omitempty"`
+
+	// TLS enables mutual TLS on the Buildkit TCP listener using certificates issued by an operator-managed CA
+	// +kubebuilder:validation:Optional
+	TLS *BuildkitTemplateTLS `json:"tls,omitempty"`
+
+	// Storage defines the volume mounted at buildkitd's state directory, which holds the build cache
+	// +kubebuilder:validation:Optional
+	Storage BuildkitTemplateStorage `json:"storage,omitempty"`
+
+	// MaxInstances caps how many Buildkits started from the template may run at once. Buildkits beyond the cap are
+	// queued without a pod, and start in the order they were created as running ones are deleted or suspended.
+	// Lowering the cap doesn't stop instances that are already running. Unlimited when unset.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxInstances *int32 `json:"maxInstances,omitempty"`
+
+	// UpdateStrategy controls how changes to the template are rolled out to existing Buildkit pods; default is OnDelete
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=OnDelete;Recreate;RecreateWhenIdle
+	// +kubebuilder:default=OnDelete
+	UpdateStrategy UpdateStrategyType `json:"updateStrategy,omitempty"`
+
+	// DeletionPolicy controls what happens to the Buildkits and BuildkitPools using the template when it's deleted; default is Block
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Block;Cascade
+	// +kubebuilder:default=Block
+	DeletionPolicy DeletionPolicyType `json:"deletionPolicy,omitempty"`
 }
 
+type DeletionPolicyType string
+
+const (
+	// DeletionPolicyBlock keeps a deleted template around until no Buildkit or BuildkitPool uses it anymore
+	DeletionPolicyBlock DeletionPolicyType = "Block"
+	// DeletionPolicyCascade deletes the Buildkits and BuildkitPools using a deleted template, which goes away once they're gone
+	DeletionPolicyCascade DeletionPolicyType = "Cascade"
+)
+
+type UpdateStrategyType string
+
+const (
+	// UpdateStrategyOnDelete leaves out-of-date pods running until something else deletes them
+	UpdateStrategyOnDelete UpdateStrategyType = "OnDelete"
+	// UpdateStrategyRecreate replaces out-of-date pods straight away, interrupting any builds in progress
+	UpdateStrategyRecreate UpdateStrategyType = "Recreate"
+	// UpdateStrategyRecreateWhenIdle replaces out-of-date pods once they have no builds in progress
+	UpdateStrategyRecreateWhenIdle UpdateStrategyType = "RecreateWhenIdle"
+)
+
+type EndpointType string
+
+const (
+	EndpointTypePodIP   EndpointType = "PodIP"
+	EndpointTypeService EndpointType = "Service"
+)
+
 type BuildkitTemplatePodScheduling struct {
 	// +kubebuilder:validation:Optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
@@ -103,6 +218,23 @@ type BuildkitTemplatePodScheduling struct {
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
+type BuildkitTemplateAllowedOverrides struct {
+	// NodeSelectorKeys are the nodeSelector keys that instances may set, replacing the template's value for the key
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	NodeSelectorKeys []string `json:"nodeSelectorKeys,omitempty"`
+
+	// TolerationKeys are the taint keys that instances may add tolerations for
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	TolerationKeys []string `json:"tolerationKeys,omitempty"`
+
+	// PriorityClassNames are the priority classes that instances may use instead of the template's
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	PriorityClassNames []string `json:"priorityClassNames,omitempty"`
+}
+
 type BuildkitTemplatePodLifecycle struct {
 	// RequireOwner indicates whether the Buildkit instance must be created with an owner reference
 	// +kubebuilder:validation:Optional
@@ -121,8 +253,65 @@ type BuildkitTemplatePodLifecycle struct {
 
 	// +kubebuilder:validation:Optional
 	PreStopScript bool `json:"preStopScript,omitempty"`
+
+	// IdleTimeout is how long a Buildkit instance may go without running any builds before idleAction is taken.
+	// Instances are kept running indefinitely when unset.
+	// +kubebuilder:validation:Optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// IdleAction is what happens to a Buildkit instance once it has been idle for idleTimeout; default is Delete
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Suspend
+	// +kubebuilder:default=Delete
+	IdleAction IdleAction `json:"idleAction,omitempty"`
+
+	// RecoveryPolicy controls whether Buildkit pods that have failed are replaced; they are not when unset
+	// +kubebuilder:validation:Optional
+	RecoveryPolicy *BuildkitTemplateRecoveryPolicy `json:"recoveryPolicy,omitempty"`
+}
+
+type BuildkitTemplateRecoveryPolicy struct {
+	// Type is either Never, which leaves a Buildkit with a failed pod as it is, or OnFailure, which replaces failed pods
+	// up to maxRestarts times; default is Never
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Never;OnFailure
+	// +kubebuilder:default=Never
+	Type RecoveryPolicyType `json:"type,omitempty"`
+
+	// MaxRestarts is how many times a failed pod is replaced before the Buildkit is marked as Failed; default is 3
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+
+	// InitialBackoff is how long to wait before replacing a failed pod the first time; default is 10s.
+	// The wait doubles with every restart, up to maxBackoff.
+	// +kubebuilder:validation:Optional
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff is the longest to wait before replacing a failed pod; default is 5m
+	// +kubebuilder:validation:Optional
+	MaxBackoff metav1.Duration `json:"maxBackoff,omitempty"`
 }
 
+type RecoveryPolicyType string
+
+const (
+	// RecoveryPolicyNever leaves failed pods in place
+	RecoveryPolicyNever RecoveryPolicyType = "Never"
+	// RecoveryPolicyOnFailure replaces failed pods with exponential backoff, up to a maximum number of restarts
+	RecoveryPolicyOnFailure RecoveryPolicyType = "OnFailure"
+)
+
+type IdleAction string
+
+const (
+	// IdleActionDelete deletes the idle Buildkit instance
+	IdleActionDelete IdleAction = "Delete"
+	// IdleActionSuspend stops the pod of the idle Buildkit instance and marks it as suspended, keeping the instance,
+	// its Service and its build cache around until it is resumed
+	IdleActionSuspend IdleAction = "Suspend"
+)
+
 type BuildkitTemplateObservability struct {
 	// +kubebuilder:validation:Optional
 	DebugLogging bool `json:"debugLogging,omitempty"`
@@ -139,12 +328,225 @@ type BuildkitTemplateOTLPSettings struct {
 	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
 }
 
+type BuildkitTemplateTLS struct {
+	// CertificateDuration is how long issued server and client certificates are valid for; default is 2160h (90 days)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="2160h"
+	CertificateDuration metav1.Duration `json:"certificateDuration,omitempty"`
+
+	// RenewBefore is how long before expiry issued certificates are rotated; default is 720h (30 days)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="720h"
+	RenewBefore metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+type StorageType string
+
+const (
+	// StorageTypeEmptyDir keeps the cache in an emptyDir, so it's lost whenever the pod goes away
+	StorageTypeEmptyDir StorageType = "EmptyDir"
+	// StorageTypePersistentVolumeClaim keeps the cache in a PVC created for each Buildkit instance
+	StorageTypePersistentVolumeClaim StorageType = "PersistentVolumeClaim"
+	// StorageTypeEphemeral keeps the cache in a generic ephemeral volume that lives as long as the pod
+	StorageTypeEphemeral StorageType = "Ephemeral"
+)
+
+type StorageRetentionPolicy string
+
+const (
+	// StorageRetentionPolicyDelete deletes a Buildkit instance's PVC along with the instance
+	StorageRetentionPolicyDelete StorageRetentionPolicy = "Delete"
+	// StorageRetentionPolicyRetain keeps the PVC after the instance is deleted, so that the next instance with the
+	// same cache key can reuse it
+	StorageRetentionPolicyRetain StorageRetentionPolicy = "Retain"
+)
+
+type BuildkitTemplateStorage struct {
+	// Type selects the kind of volume used for buildkitd's state; default is EmptyDir
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=EmptyDir;PersistentVolumeClaim;Ephemeral
+	// +kubebuilder:default=EmptyDir
+	Type StorageType `json:"type,omitempty"`
+
+	// EmptyDir configures the sizeLimit and medium of the volume when type is EmptyDir
+	// +kubebuilder:validation:Optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// VolumeClaimTemplate describes the PVC to create when type is PersistentVolumeClaim or Ephemeral
+	// +kubebuilder:validation:Optional
+	VolumeClaimTemplate *corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+
+	// RetentionPolicy controls what happens to a PVC when its Buildkit instance is deleted; default is Delete.
+	// Only applies when type is PersistentVolumeClaim.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	RetentionPolicy StorageRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+type BuildkitdWorker string
+
+const (
+	// BuildkitdWorkerOCI runs builds with buildkitd's own OCI (runc) worker
+	BuildkitdWorkerOCI BuildkitdWorker = "OCI"
+	// BuildkitdWorkerContainerd runs builds through a containerd daemon reachable from the pod
+	BuildkitdWorkerContainerd BuildkitdWorker = "Containerd"
+)
+
+type BuildkitdLogFormat string
+
+const (
+	BuildkitdLogFormatJSON BuildkitdLogFormat = "json"
+	BuildkitdLogFormatText BuildkitdLogFormat = "text"
+)
+
+type BuildkitdConfig struct {
+	// Worker enables the named worker and disables the other one. The worker settings below (maxParallelism and gc)
+	// apply to this worker, or to the OCI worker when unset, in which case buildkitd picks the worker itself.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=OCI;Containerd
+	Worker BuildkitdWorker `json:"worker,omitempty"`
+
+	// MaxParallelism limits how many build steps the worker runs at once; unlimited when unset
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelism *int32 `json:"maxParallelism,omitempty"`
+
+	// GC configures how the worker garbage collects its build cache
+	// +kubebuilder:validation:Optional
+	GC *BuildkitdGCConfig `json:"gc,omitempty"`
+
+	// Registries configures mirrors and insecure access for the registries buildkitd pulls from and pushes to
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=host
+	Registries []BuildkitdRegistry `json:"registries,omitempty"`
+
+	// History configures how long buildkitd keeps the records of finished builds
+	// +kubebuilder:validation:Optional
+	History *BuildkitdHistory `json:"history,omitempty"`
+
+	// LogFormat is the format of buildkitd's logs, either json or text
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=json;text
+	LogFormat BuildkitdLogFormat `json:"logFormat,omitempty"`
+}
+
+type BuildkitdGCConfig struct {
+	// Enabled turns garbage collection of the build cache on or off; buildkitd enables it by default
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ReservedSpace is the least cache buildkitd keeps around, as a size like "10GB" or a percentage of the disk like "10%"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(\d+%|\d+(\.\d+)? ?[kKmMgGtTpP]?[iI]?[bB]?)$`
+	ReservedSpace string `json:"reservedSpace,omitempty"`
+
+	// MaxUsedSpace is the most cache buildkitd keeps around, as a size like "50GB" or a percentage of the disk like "60%"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(\d+%|\d+(\.\d+)? ?[kKmMgGtTpP]?[iI]?[bB]?)$`
+	MaxUsedSpace string `json:"maxUsedSpace,omitempty"`
+
+	// MinFreeSpace is how much of the disk buildkitd tries to keep free, as a size like "20GB" or a percentage like "20%"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(\d+%|\d+(\.\d+)? ?[kKmMgGtTpP]?[iI]?[bB]?)$`
+	MinFreeSpace string `json:"minFreeSpace,omitempty"`
+
+	// Policies replaces buildkitd's default garbage collection policies; they're applied in order
+	// +kubebuilder:validation:Optional
+	Policies []BuildkitdGCPolicy `json:"policies,omitempty"`
+}
+
+type BuildkitdGCPolicy struct {
+	// All makes the policy also prune internal and frontend cache records, not just the build cache
+	// +kubebuilder:validation:Optional
+	All bool `json:"all,omitempty"`
+
+	// Filters restricts the policy to matching cache records, like "type==source.local"
+	// +kubebuilder:validation:Optional
+	Filters []string `json:"filters,omitempty"`
+
+	// KeepDuration keeps cache records that have been used more recently than this
+	// +kubebuilder:validation:Optional
+	KeepDuration *metav1.Duration `json:"keepDuration,omitempty"`
+
+	// ReservedSpace is the least cache the policy keeps around, as a size like "10GB" or a percentage like "10%"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(\d+%|\d+(\.\d+)? ?[kKmMgGtTpP]?[iI]?[bB]?)$`
+	ReservedSpace string `json:"reservedSpace,omitempty"`
+
+	// MaxUsedSpace is the most cache the policy keeps around, as a size like "50GB" or a percentage like "60%"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(\d+%|\d+(\.\d+)? ?[kKmMgGtTpP]?[iI]?[bB]?)$`
+	MaxUsedSpace string `json:"maxUsedSpace,omitempty"`
+
+	// MinFreeSpace is how much of the disk the policy tries to keep free, as a size like "20GB" or a percentage like "20%"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(\d+%|\d+(\.\d+)? ?[kKmMgGtTpP]?[iI]?[bB]?)$`
+	MinFreeSpace string `json:"minFreeSpace,omitempty"`
+}
+
+type BuildkitdRegistry struct {
+	// Host is the registry host, optionally with a port, like "docker.io" or "registry.example.com:5000"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)*(:[0-9]+)?$`
+	Host string `json:"host"`
+
+	// Mirrors are hosts tried before this registry when pulling images from it
+	// +kubebuilder:validation:Optional
+	Mirrors []string `json:"mirrors,omitempty"`
+
+	// Insecure skips verification of the registry's TLS certificate
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// HTTP talks to the registry over plain HTTP instead of HTTPS
+	// +kubebuilder:validation:Optional
+	HTTP bool `json:"http,omitempty"`
+
+	// CA is the PEM bundle of the certificate authorities that sign the registry's certificate, for registries using
+	// a private CA. It's mounted at certs/<host>/ca.crt under buildkitd's config directory.
+	// +kubebuilder:validation:Optional
+	CA *BuildkitdRegistryCA `json:"ca,omitempty"`
+
+	// ClientCertificate names a kubernetes.io/tls Secret holding the client certificate and key presented to the
+	// registry. They're mounted at certs/<host>/client.crt and client.key under buildkitd's config directory.
+	// +kubebuilder:validation:Optional
+	ClientCertificate *corev1.LocalObjectReference `json:"clientCertificate,omitempty"`
+}
+
+// BuildkitdRegistryCA references the key of a ConfigMap or Secret holding a PEM bundle of CA certificates.
+// Exactly one of configMapKeyRef and secretKeyRef must be set.
+type BuildkitdRegistryCA struct {
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+type BuildkitdHistory struct {
+	// MaxAge is how long records of finished builds are kept
+	// +kubebuilder:validation:Optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// MaxEntries is how many records of finished builds are kept
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxEntries *int64 `json:"maxEntries,omitempty"`
+}
+
 type BuildkitTemplateResources struct {
 	// +kubebuilder:validation:Optional
 	Default corev1.ResourceRequirements `json:"default,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	Maximum corev1.ResourceList `json:"maximum,omitempty"`
+
+	// Strict rejects Buildkits that request more than Maximum, instead of warning that their resources will be reduced
+	// to it
+	// +kubebuilder:validation:Optional
+	Strict bool `json:"strict,omitempty"`
 }
 
 type BuildkitTemplateStatus struct {
@@ -152,6 +554,45 @@ type BuildkitTemplateStatus struct {
 
 	// ResourceRefs is a list of all resources managed by this object.
 	ResourceRefs []api.TypedObjectRef `json:"resourceRefs,omitempty"`
+
+	// ObservedGeneration is the generation of the template the status was last updated for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ConfigHash is a hash of the buildkitd.toml rendered from the template, or empty if it renders none
+	ConfigHash string `json:"configHash,omitempty"`
+
+	TemplateInstanceCounts `json:",inline"`
+}
+
+// TemplateInstanceCounts sums up the Buildkits started from a template, directly or through a pool.
+type TemplateInstanceCounts struct {
+	// TotalInstances is how many Buildkits use the template, including suspended and queued ones
+	TotalInstances int32 `json:"totalInstances"`
+
+	// Instances is how many Buildkits started from the template have a pod, which is what counts towards maxInstances
+	Instances int32 `json:"instances"`
+
+	// ReadyInstances is how many Buildkits started from the template are ready
+	ReadyInstances int32 `json:"readyInstances"`
+
+	// FailedInstances is how many Buildkits started from the template have failed
+	FailedInstances int32 `json:"failedInstances"`
+
+	// OutOfDateInstances is how many Buildkits run a pod started from an older version of the template
+	OutOfDateInstances int32 `json:"outOfDateInstances"`
+
+	// QueuedInstances is how many Buildkits started from the template are queued, waiting for a running one to go away
+	QueuedInstances int32 `json:"queuedInstances"`
+}
+
+// ResourcePrefix returns the prefix of the names of the resources derived from the template, such as its ConfigMaps:
+// buildkit-<name> for a BuildkitTemplate, and buildkit-cluster-<name> for a ClusterBuildkitTemplate in a namespace.
+func (b *BuildkitTemplate) ResourcePrefix() string {
+	if b.Annotations[ClusterTemplateAnnotation] == "true" {
+		return "buildkit-cluster-" + b.Name
+	}
+
+	return "buildkit-" + b.Name
 }
 
 func (b *BuildkitTemplate) GetConditions() []api.Condition {
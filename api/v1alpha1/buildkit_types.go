@@ -5,6 +5,8 @@
 package v1alpha1
 
 import (
+	"cmp"
+
 	"github.com/reddit/achilles-sdk-api/api"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,6 +14,50 @@ import (
 
 const (
 	TypeDeployed api.ConditionType = "Deployed"
+	// TypeTemplateOutOfDate is True while the Buildkit pod runs an older version of its template than the current one
+	TypeTemplateOutOfDate api.ConditionType = "TemplateOutOfDate"
+	// TypeFailed is True once the Buildkit pod has failed and won't be replaced under the template's recovery policy
+	TypeFailed api.ConditionType = "Failed"
+	// TypeQueued is True while the Buildkit waits without a pod because its template's maxInstances has been reached
+	TypeQueued api.ConditionType = "Queued"
+	// TypeTemplateMissing is True while the Buildkit's template, or the pool it belongs to, can't be found
+	TypeTemplateMissing api.ConditionType = "TemplateMissing"
+	// TypeResourcesClamped is True while some of the Buildkit's requests or limits are over its template's maximums,
+	// and have been reduced to them
+	TypeResourcesClamped api.ConditionType = "ResourcesClamped"
+	// TypeServiceConflict is True while a Service not created for the Buildkit has its name, so it gets no Service of
+	// its own
+	TypeServiceConflict api.ConditionType = "ServiceConflict"
+)
+
+// InstanceLabel is set on the pod and Service of a Buildkit instance, with the instance name as its value.
+const InstanceLabel = "buildkit.seatgeek.io/instance"
+
+// TemplateHashAnnotation is set on Buildkit pods to a hash of the template spec they were started from.
+const TemplateHashAnnotation = "buildkit.seatgeek.io/template-hash"
+
+// TemplateGenerationAnnotation is set on Buildkit pods to the generation of the template they were started from.
+const TemplateGenerationAnnotation = "buildkit.seatgeek.io/template-generation"
+
+// BuildkitPhase sums up where a Buildkit instance is in its lifecycle.
+// +kubebuilder:validation:Enum=Pending;Queued;Starting;Ready;Failed;Terminating;Suspended
+type BuildkitPhase string
+
+const (
+	// BuildkitPhasePending means the instance has no pod yet, such as while it waits for its certificate authority or build cache
+	BuildkitPhasePending BuildkitPhase = "Pending"
+	// BuildkitPhaseQueued means the instance has no pod because its template's maxInstances has been reached
+	BuildkitPhaseQueued BuildkitPhase = "Queued"
+	// BuildkitPhaseStarting means the instance's pod exists but is not ready yet
+	BuildkitPhaseStarting BuildkitPhase = "Starting"
+	// BuildkitPhaseReady means the instance's pod is ready and its endpoint is set
+	BuildkitPhaseReady BuildkitPhase = "Ready"
+	// BuildkitPhaseFailed means the instance's pod has failed and won't be replaced
+	BuildkitPhaseFailed BuildkitPhase = "Failed"
+	// BuildkitPhaseTerminating means the instance or its pod is being deleted
+	BuildkitPhaseTerminating BuildkitPhase = "Terminating"
+	// BuildkitPhaseSuspended means the instance is suspended and runs no pod
+	BuildkitPhaseSuspended BuildkitPhase = "Suspended"
 )
 
 // +genclient
@@ -19,7 +65,19 @@ const (
 // +kubebuilder:resource:shortName=buildkit
 // +kubebuilder:subresource:status
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.template`
+// +kubebuilder:printcolumn:name="Pool",type=string,JSONPath=`.spec.pool`
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.status.podName`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.endpoint`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="IP",type=string,JSONPath=`.status.podIP`,priority=1
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.status.nodeName`,priority=1
+// +kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`,priority=1
+// +kubebuilder:printcolumn:name="Ready",type=date,JSONPath=`.status.readyTime`,priority=1
+// +kubebuilder:printcolumn:name="Template Generation",type=integer,JSONPath=`.status.templateGeneration`,priority=1
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspended`,priority=1
+// +kubebuilder:printcolumn:name="Restarts",type=integer,JSONPath=`.status.restartCount`,priority=1
 type Buildkit struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -30,30 +88,174 @@ type Buildkit struct {
 
 type BuildkitSpec struct {
 	// Template is the name of the BuildkitTemplate to use for creating the Buildkit instance.
-	// +kubebuilder:validation:Required
-	Template string `json:"template"`
+	// Exactly one of template, templateRef or pool must be set.
+	// +kubebuilder:validation:Optional
+	Template string `json:"template,omitempty"`
+
+	// TemplateRef references the template to use for creating the Buildkit instance by kind and name,
+	// which allows using a ClusterBuildkitTemplate whose namespaceSelector matches the Buildkit's namespace.
+	// Exactly one of template, templateRef or pool must be set.
+	// +kubebuilder:validation:Optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+
+	// Pool is the name of a BuildkitPool to claim a warm pod from, instead of starting a new one from a template.
+	// If the pool has no warm pods available, a new pod is started from the pool's template.
+	// Exactly one of template, templateRef or pool must be set.
+	// +kubebuilder:validation:Optional
+	Pool string `json:"pool,omitempty"`
+
+	// CacheKey identifies the build cache of the Buildkit instance. When the template retains its PVCs, an instance
+	// reuses the PVC left behind by an earlier instance with the same cache key, waiting for it to be released if needed.
+	// Defaults to the instance name.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	CacheKey string `json:"cacheKey,omitempty"`
+
+	// Suspended stops the Buildkit pod while keeping the instance, its Service and its build cache.
+	// It is set by the operator when the template's idleAction is Suspend; clear it to start the instance again.
+	// +kubebuilder:validation:Optional
+	Suspended bool `json:"suspended,omitempty"`
 
 	// Resources defines the resource requirements for the Buildkit instance.
 	// It is optional and can be omitted if the default resource limits are sufficient.
+	// Changes are applied to the running pod by resizing it in place where the cluster supports it,
+	// or by replacing the pod otherwise.
 	// +kubebuilder:validation:Optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// Scheduling overrides the scheduling of the Buildkit pod, within what the template's allowedOverrides permit.
+	// It can't be changed once the instance is created, nor set when using a pool.
+	// +kubebuilder:validation:Optional
+	Scheduling BuildkitSchedulingOverrides `json:"scheduling,omitempty"`
+
 	// Annotations can be used to attach arbitrary metadata to the Buildkit instance.
+	// Changes are patched onto the running pod.
 	// +kubebuilder:validation:Optional
 	Annotations map[string]string `json:"annotations,omitempty"`
 	// Labels can be used to attach arbitrary metadata to the Buildkit instance.
+	// Changes are patched onto the running pod.
 	// +kubebuilder:validation:Optional
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// BuildkitSchedulingOverrides are merged on top of the template's scheduling when the Buildkit pod is built.
+type BuildkitSchedulingOverrides struct {
+	// NodeSelector entries are added to the template's nodeSelector, replacing its value for the same key
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the template's tolerations
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName replaces the template's priority class
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=BuildkitTemplate;ClusterBuildkitTemplate
+type TemplateKind string
+
+const (
+	TemplateKindBuildkitTemplate        TemplateKind = "BuildkitTemplate"
+	TemplateKindClusterBuildkitTemplate TemplateKind = "ClusterBuildkitTemplate"
+)
+
+type TemplateReference struct {
+	// Kind is the kind of the referenced template.
+	// A BuildkitTemplate is looked up in the Buildkit's own namespace.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=BuildkitTemplate
+	Kind TemplateKind `json:"kind,omitempty"`
+
+	// Name is the name of the referenced template.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// IsCluster reports whether the reference is to a ClusterBuildkitTemplate.
+func (r *TemplateReference) IsCluster() bool {
+	return r != nil && r.Kind == TemplateKindClusterBuildkitTemplate
+}
+
+// DirectTemplateRef returns the template the Buildkit references directly through either template or templateRef,
+// or nil when it uses a pool instead. An unset kind is treated as BuildkitTemplate.
+func (s *BuildkitSpec) DirectTemplateRef() *TemplateReference {
+	switch {
+	case s.TemplateRef != nil:
+		return &TemplateReference{Kind: cmp.Or(s.TemplateRef.Kind, TemplateKindBuildkitTemplate), Name: s.TemplateRef.Name}
+	case s.Template != "":
+		return &TemplateReference{Kind: TemplateKindBuildkitTemplate, Name: s.Template}
+	default:
+		return nil
+	}
+}
+
 type BuildkitStatus struct {
 	api.ConditionedStatus `json:",inline"`
 
 	// ResourceRefs is a list of all resources managed by this object.
 	ResourceRefs []api.TypedObjectRef `json:"resourceRefs,omitempty"`
 
+	// ObservedGeneration is the generation of the Buildkit that was last reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase sums up where the Buildkit instance is in its lifecycle
+	Phase BuildkitPhase `json:"phase,omitempty"`
+
+	// PodName is the name of the Buildkit pod
+	PodName string `json:"podName,omitempty"`
+
+	// PodIP is the primary IP address of the Buildkit pod
+	PodIP string `json:"podIP,omitempty"`
+
+	// PodIPs lists the IP addresses of the Buildkit pod, one per IP family
+	PodIPs []string `json:"podIPs,omitempty"`
+
+	// NodeName is the node the Buildkit pod is scheduled on
+	NodeName string `json:"nodeName,omitempty"`
+
+	// StartTime is when the Buildkit pod was started on its node
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// ReadyTime is when the Buildkit pod last became ready
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+
+	// EffectiveResources are the resources the Buildkit pod gets, once its template's defaults and maximums are applied
+	EffectiveResources *corev1.ResourceRequirements `json:"effectiveResources,omitempty"`
+
+	// TemplateGeneration is the generation of the template the Buildkit pod was started from
+	TemplateGeneration int64 `json:"templateGeneration,omitempty"`
+
+	// QueuePosition is where the Buildkit stands in the queue for its template's maxInstances, starting at 1 for the
+	// next one to start; zero when it isn't queued
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
 	// Endpoint is the tcp URI of the Buildkit instance, like tcp://some-buildkit-instance-amd64:1234
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// ServiceName is the name of the Service that provides a stable address for the Buildkit instance; empty while
+	// another Service has the name the instance's would get
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// ClientTLSSecretName is the name of the Secret holding the client certificate, key and CA bundle
+	// needed to connect to the Buildkit instance when its template enables TLS
+	ClientTLSSecretName string `json:"clientTLSSecretName,omitempty"`
+
+	// LastActiveTime is the last time the Buildkit instance was seen running a build.
+	// Only tracked when the template sets an idleTimeout.
+	LastActiveTime *metav1.Time `json:"lastActiveTime,omitempty"`
+
+	// RestartCount is how many times a failed Buildkit pod has been replaced under the template's recovery policy
+	RestartCount int32 `json:"restartCount,omitempty"`
+
+	// LastFailureReason describes why the Buildkit pod last failed
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+
+	// LastFailureTime is when the Buildkit pod was last seen to have failed
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
 }
 
 func (b *Buildkit) GetConditions() []api.Condition {
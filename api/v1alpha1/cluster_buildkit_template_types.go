@@ -0,0 +1,127 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package v1alpha1
+
+import (
+	"maps"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterTemplateAnnotation marks the BuildkitTemplates returned by ClusterBuildkitTemplate.InNamespace, whose derived
+// resources are named apart from those of a BuildkitTemplate of the same name in that namespace.
+const ClusterTemplateAnnotation = "buildkit.seatgeek.io/cluster-template"
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=clusterbuildkittemplate
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalInstances`
+// +kubebuilder:printcolumn:name="Running",type=integer,JSONPath=`.status.instances`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyInstances`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedInstances`
+// +kubebuilder:printcolumn:name="Outdated",type=integer,JSONPath=`.status.outOfDateInstances`
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queuedInstances`
+// +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxInstances`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Namespaces",type=string,JSONPath=`.status.namespaces`,priority=1
+type ClusterBuildkitTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterBuildkitTemplateSpec   `json:"spec,omitempty"`
+	Status ClusterBuildkitTemplateStatus `json:"status,omitempty"`
+}
+
+type ClusterBuildkitTemplateSpec struct {
+	BuildkitTemplateSpec `json:",inline"`
+
+	// NamespaceSelector selects the namespaces whose Buildkits may use this template.
+	// An empty selector allows every namespace, while leaving it unset allows none.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+type ClusterBuildkitTemplateStatus struct {
+	api.ConditionedStatus `json:",inline"`
+
+	// ResourceRefs is a list of all resources managed by this object.
+	ResourceRefs []api.TypedObjectRef `json:"resourceRefs,omitempty"`
+
+	// Namespaces lists the namespaces with Buildkits that use this template,
+	// where the template's ConfigMaps and certificate authority are created.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ObservedGeneration is the generation of the template the status was last updated for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ConfigHash is a hash of the buildkitd.toml rendered from the template, or empty if it renders none
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// TemplateInstanceCounts sums up the Buildkits started from the template across all namespaces
+	TemplateInstanceCounts `json:",inline"`
+}
+
+// InNamespace returns a BuildkitTemplate with the same name and spec as the ClusterBuildkitTemplate, as seen by the
+// Buildkits in the given namespace. The resources derived from it, such as its ConfigMaps, live in that namespace, and
+// it's marked with ClusterTemplateAnnotation so that they don't collide with those of a BuildkitTemplate.
+func (t *ClusterBuildkitTemplate) InNamespace(namespace string) *BuildkitTemplate {
+	annotations := maps.Clone(t.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ClusterTemplateAnnotation] = "true"
+
+	return &BuildkitTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       "BuildkitTemplate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        t.Name,
+			Namespace:   namespace,
+			UID:         t.UID,
+			Generation:  t.Generation,
+			Labels:      t.Labels,
+			Annotations: annotations,
+		},
+		Spec: *t.Spec.BuildkitTemplateSpec.DeepCopy(),
+	}
+}
+
+func (t *ClusterBuildkitTemplate) GetConditions() []api.Condition {
+	return t.Status.Conditions
+}
+
+func (t *ClusterBuildkitTemplate) SetConditions(cond ...api.Condition) {
+	t.Status.SetConditions(cond...)
+}
+
+func (t *ClusterBuildkitTemplate) GetCondition(ct api.ConditionType) api.Condition {
+	return t.Status.GetCondition(ct)
+}
+
+func (t *ClusterBuildkitTemplate) SetManagedResources(refs []api.TypedObjectRef) {
+	t.Status.ResourceRefs = refs
+}
+
+func (t *ClusterBuildkitTemplate) GetManagedResources() []api.TypedObjectRef {
+	return t.Status.ResourceRefs
+}
+
+// +kubebuilder:object:root=true
+type ClusterBuildkitTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBuildkitTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBuildkitTemplate{}, &ClusterBuildkitTemplateList{})
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package v1alpha1
+
+// Reasons of the Events recorded on Buildkits and their templates. They're part of the API, so alerts can match on them.
+const (
+	// EventReasonPodCreated is recorded on a Buildkit when a pod is started for it
+	EventReasonPodCreated = "PodCreated"
+	// EventReasonExtraPodsDeleted is recorded on a Buildkit when more than one pod was found for it and the extras are deleted
+	EventReasonExtraPodsDeleted = "ExtraPodsDeleted"
+	// EventReasonPodFailed is recorded on a Buildkit when its pod has failed
+	EventReasonPodFailed = "PodFailed"
+	// EventReasonEndpointReady is recorded on a Buildkit when its endpoint becomes available
+	EventReasonEndpointReady = "EndpointReady"
+	// EventReasonEndpointLost is recorded on a Buildkit when its endpoint stops being available
+	EventReasonEndpointLost = "EndpointLost"
+	// EventReasonQueued is recorded on a Buildkit when it's queued because its template's maxInstances has been reached
+	EventReasonQueued = "Queued"
+	// EventReasonTemplateMissing is recorded on a Buildkit when its template, or the pool it belongs to, can't be found
+	EventReasonTemplateMissing = "TemplateMissing"
+	// EventReasonServiceConflict is recorded on a Buildkit when a Service not created for it already has its name
+	EventReasonServiceConflict = "ServiceConflict"
+	// EventReasonResourcesClamped is recorded on a Buildkit when some of its requests or limits are reduced to its
+	// template's maximums
+	EventReasonResourcesClamped = "ResourcesClamped"
+	// EventReasonConfigMapApplied is recorded on a template when one of its ConfigMaps is created or updated
+	EventReasonConfigMapApplied = "ConfigMapApplied"
+	// EventReasonConfigMapRemoved is recorded on a template when one of its ConfigMaps is no longer needed and is removed
+	EventReasonConfigMapRemoved = "ConfigMapRemoved"
+	// EventReasonDeletionBlocked is recorded on a template when it's deleted while Buildkits still use it, and its
+	// deletionPolicy keeps it around until they're gone
+	EventReasonDeletionBlocked = "DeletionBlocked"
+	// EventReasonConsumerDeleted is recorded on a template when one of the Buildkits or BuildkitPools using it is deleted
+	// along with it
+	EventReasonConsumerDeleted = "ConsumerDeleted"
+	// EventReasonRegistryAuthSkipped is recorded on a template when one of its registryAuth Secrets is missing or doesn't
+	// hold a Docker config, so its credentials are left out of the merged config
+	EventReasonRegistryAuthSkipped = "RegistryAuthSkipped"
+)
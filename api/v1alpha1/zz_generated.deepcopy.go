@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	"github.com/reddit/achilles-sdk-api/api"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -38,31 +39,58 @@ func (in *Buildkit) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitList) DeepCopyInto(out *BuildkitList) {
+func (in *BuildkitGroup) DeepCopyInto(out *BuildkitGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitGroup.
+func (in *BuildkitGroup) DeepCopy() *BuildkitGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildkitGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitGroupList) DeepCopyInto(out *BuildkitGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Buildkit, len(*in))
+		*out = make([]BuildkitGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitList.
-func (in *BuildkitList) DeepCopy() *BuildkitList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitGroupList.
+func (in *BuildkitGroupList) DeepCopy() *BuildkitGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitList)
+	out := new(BuildkitGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BuildkitList) DeepCopyObject() runtime.Object {
+func (in *BuildkitGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -70,37 +98,64 @@ func (in *BuildkitList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitSpec) DeepCopyInto(out *BuildkitSpec) {
+func (in *BuildkitGroupNode) DeepCopyInto(out *BuildkitGroupNode) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitGroupNode.
+func (in *BuildkitGroupNode) DeepCopy() *BuildkitGroupNode {
+	if in == nil {
+		return nil
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out := new(BuildkitGroupNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitGroupPlatform) DeepCopyInto(out *BuildkitGroupPlatform) {
+	*out = *in
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitGroupPlatform.
+func (in *BuildkitGroupPlatform) DeepCopy() *BuildkitGroupPlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitGroupPlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitGroupSpec) DeepCopyInto(out *BuildkitGroupSpec) {
+	*out = *in
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]BuildkitGroupPlatform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitSpec.
-func (in *BuildkitSpec) DeepCopy() *BuildkitSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitGroupSpec.
+func (in *BuildkitGroupSpec) DeepCopy() *BuildkitGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitSpec)
+	out := new(BuildkitGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitStatus) DeepCopyInto(out *BuildkitStatus) {
+func (in *BuildkitGroupStatus) DeepCopyInto(out *BuildkitGroupStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	if in.ResourceRefs != nil {
@@ -108,39 +163,76 @@ func (in *BuildkitStatus) DeepCopyInto(out *BuildkitStatus) {
 		*out = make([]api.TypedObjectRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]BuildkitGroupNode, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitStatus.
-func (in *BuildkitStatus) DeepCopy() *BuildkitStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitGroupStatus.
+func (in *BuildkitGroupStatus) DeepCopy() *BuildkitGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitStatus)
+	out := new(BuildkitGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplate) DeepCopyInto(out *BuildkitTemplate) {
+func (in *BuildkitList) DeepCopyInto(out *BuildkitList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Buildkit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitList.
+func (in *BuildkitList) DeepCopy() *BuildkitList {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildkitList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitPool) DeepCopyInto(out *BuildkitPool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplate.
-func (in *BuildkitTemplate) DeepCopy() *BuildkitTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitPool.
+func (in *BuildkitPool) DeepCopy() *BuildkitPool {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplate)
+	out := new(BuildkitPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BuildkitTemplate) DeepCopyObject() runtime.Object {
+func (in *BuildkitPool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -148,31 +240,31 @@ func (in *BuildkitTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplateList) DeepCopyInto(out *BuildkitTemplateList) {
+func (in *BuildkitPoolList) DeepCopyInto(out *BuildkitPoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]BuildkitTemplate, len(*in))
+		*out = make([]BuildkitPool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateList.
-func (in *BuildkitTemplateList) DeepCopy() *BuildkitTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitPoolList.
+func (in *BuildkitPoolList) DeepCopy() *BuildkitPoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplateList)
+	out := new(BuildkitPoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BuildkitTemplateList) DeepCopyObject() runtime.Object {
+func (in *BuildkitPoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -180,119 +272,117 @@ func (in *BuildkitTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplateOTLPSettings) DeepCopyInto(out *BuildkitTemplateOTLPSettings) {
+func (in *BuildkitPoolSpec) DeepCopyInto(out *BuildkitPoolSpec) {
 	*out = *in
-	if in.ResourceAttributes != nil {
-		in, out := &in.ResourceAttributes, &out.ResourceAttributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateOTLPSettings.
-func (in *BuildkitTemplateOTLPSettings) DeepCopy() *BuildkitTemplateOTLPSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitPoolSpec.
+func (in *BuildkitPoolSpec) DeepCopy() *BuildkitPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplateOTLPSettings)
+	out := new(BuildkitPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplateObservability) DeepCopyInto(out *BuildkitTemplateObservability) {
+func (in *BuildkitPoolStatus) DeepCopyInto(out *BuildkitPoolStatus) {
 	*out = *in
-	if in.OTLP != nil {
-		in, out := &in.OTLP, &out.OTLP
-		*out = new(BuildkitTemplateOTLPSettings)
-		(*in).DeepCopyInto(*out)
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]api.TypedObjectRef, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateObservability.
-func (in *BuildkitTemplateObservability) DeepCopy() *BuildkitTemplateObservability {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitPoolStatus.
+func (in *BuildkitPoolStatus) DeepCopy() *BuildkitPoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplateObservability)
+	out := new(BuildkitPoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplatePodLifecycle) DeepCopyInto(out *BuildkitTemplatePodLifecycle) {
+func (in *BuildkitQuota) DeepCopyInto(out *BuildkitQuota) {
 	*out = *in
-	if in.TerminationGracePeriodSeconds != nil {
-		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
-		*out = new(int64)
-		**out = **in
-	}
-	if in.ActiveDeadlineSeconds != nil {
-		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
-		*out = new(int64)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplatePodLifecycle.
-func (in *BuildkitTemplatePodLifecycle) DeepCopy() *BuildkitTemplatePodLifecycle {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitQuota.
+func (in *BuildkitQuota) DeepCopy() *BuildkitQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplatePodLifecycle)
+	out := new(BuildkitQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildkitQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplatePodScheduling) DeepCopyInto(out *BuildkitTemplatePodScheduling) {
+func (in *BuildkitQuotaList) DeepCopyInto(out *BuildkitQuotaList) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BuildkitQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplatePodScheduling.
-func (in *BuildkitTemplatePodScheduling) DeepCopy() *BuildkitTemplatePodScheduling {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitQuotaList.
+func (in *BuildkitQuotaList) DeepCopy() *BuildkitQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplatePodScheduling)
+	out := new(BuildkitQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildkitQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplateResources) DeepCopyInto(out *BuildkitTemplateResources) {
+func (in *BuildkitQuotaSpec) DeepCopyInto(out *BuildkitQuotaSpec) {
 	*out = *in
-	in.Default.DeepCopyInto(&out.Default)
-	if in.Maximum != nil {
-		in, out := &in.Maximum, &out.Maximum
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
 		*out = make(v1.ResourceList, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val.DeepCopy()
@@ -300,68 +390,510 @@ func (in *BuildkitTemplateResources) DeepCopyInto(out *BuildkitTemplateResources
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateResources.
-func (in *BuildkitTemplateResources) DeepCopy() *BuildkitTemplateResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitQuotaSpec.
+func (in *BuildkitQuotaSpec) DeepCopy() *BuildkitQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplateResources)
+	out := new(BuildkitQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplateSpec) DeepCopyInto(out *BuildkitTemplateSpec) {
+func (in *BuildkitQuotaStatus) DeepCopyInto(out *BuildkitQuotaStatus) {
 	*out = *in
-	if in.PodLabels != nil {
-		in, out := &in.PodLabels, &out.PodLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.PodAnnotations != nil {
-		in, out := &in.PodAnnotations, &out.PodAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Command != nil {
-		in, out := &in.Command, &out.Command
-		*out = make([]string, len(*in))
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]api.TypedObjectRef, len(*in))
 		copy(*out, *in)
 	}
-	in.Scheduling.DeepCopyInto(&out.Scheduling)
-	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
-	in.Observability.DeepCopyInto(&out.Observability)
-	if in.HostUsers != nil {
-		in, out := &in.HostUsers, &out.HostUsers
-		*out = new(bool)
-		**out = **in
-	}
+	in.Used.DeepCopyInto(&out.Used)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateSpec.
-func (in *BuildkitTemplateSpec) DeepCopy() *BuildkitTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitQuotaStatus.
+func (in *BuildkitQuotaStatus) DeepCopy() *BuildkitQuotaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildkitTemplateSpec)
+	out := new(BuildkitQuotaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildkitTemplateStatus) DeepCopyInto(out *BuildkitTemplateStatus) {
+func (in *BuildkitQuotaUsage) DeepCopyInto(out *BuildkitQuotaUsage) {
 	*out = *in
-	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
-	if in.ResourceRefs != nil {
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitQuotaUsage.
+func (in *BuildkitQuotaUsage) DeepCopy() *BuildkitQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitSchedulingOverrides) DeepCopyInto(out *BuildkitSchedulingOverrides) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitSchedulingOverrides.
+func (in *BuildkitSchedulingOverrides) DeepCopy() *BuildkitSchedulingOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitSchedulingOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitSpec) DeepCopyInto(out *BuildkitSpec) {
+	*out = *in
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateReference)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitSpec.
+func (in *BuildkitSpec) DeepCopy() *BuildkitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitStatus) DeepCopyInto(out *BuildkitStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]api.TypedObjectRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodIPs != nil {
+		in, out := &in.PodIPs, &out.PodIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EffectiveResources != nil {
+		in, out := &in.EffectiveResources, &out.EffectiveResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastActiveTime != nil {
+		in, out := &in.LastActiveTime, &out.LastActiveTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitStatus.
+func (in *BuildkitStatus) DeepCopy() *BuildkitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplate) DeepCopyInto(out *BuildkitTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplate.
+func (in *BuildkitTemplate) DeepCopy() *BuildkitTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildkitTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateAllowedOverrides) DeepCopyInto(out *BuildkitTemplateAllowedOverrides) {
+	*out = *in
+	if in.NodeSelectorKeys != nil {
+		in, out := &in.NodeSelectorKeys, &out.NodeSelectorKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TolerationKeys != nil {
+		in, out := &in.TolerationKeys, &out.TolerationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PriorityClassNames != nil {
+		in, out := &in.PriorityClassNames, &out.PriorityClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateAllowedOverrides.
+func (in *BuildkitTemplateAllowedOverrides) DeepCopy() *BuildkitTemplateAllowedOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateAllowedOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateList) DeepCopyInto(out *BuildkitTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BuildkitTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateList.
+func (in *BuildkitTemplateList) DeepCopy() *BuildkitTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildkitTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateOTLPSettings) DeepCopyInto(out *BuildkitTemplateOTLPSettings) {
+	*out = *in
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateOTLPSettings.
+func (in *BuildkitTemplateOTLPSettings) DeepCopy() *BuildkitTemplateOTLPSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateOTLPSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateObservability) DeepCopyInto(out *BuildkitTemplateObservability) {
+	*out = *in
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(BuildkitTemplateOTLPSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateObservability.
+func (in *BuildkitTemplateObservability) DeepCopy() *BuildkitTemplateObservability {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateObservability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplatePodLifecycle) DeepCopyInto(out *BuildkitTemplatePodLifecycle) {
+	*out = *in
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RecoveryPolicy != nil {
+		in, out := &in.RecoveryPolicy, &out.RecoveryPolicy
+		*out = new(BuildkitTemplateRecoveryPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplatePodLifecycle.
+func (in *BuildkitTemplatePodLifecycle) DeepCopy() *BuildkitTemplatePodLifecycle {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplatePodLifecycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplatePodScheduling) DeepCopyInto(out *BuildkitTemplatePodScheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplatePodScheduling.
+func (in *BuildkitTemplatePodScheduling) DeepCopy() *BuildkitTemplatePodScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplatePodScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateRecoveryPolicy) DeepCopyInto(out *BuildkitTemplateRecoveryPolicy) {
+	*out = *in
+	out.InitialBackoff = in.InitialBackoff
+	out.MaxBackoff = in.MaxBackoff
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateRecoveryPolicy.
+func (in *BuildkitTemplateRecoveryPolicy) DeepCopy() *BuildkitTemplateRecoveryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateRecoveryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateResources) DeepCopyInto(out *BuildkitTemplateResources) {
+	*out = *in
+	in.Default.DeepCopyInto(&out.Default)
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateResources.
+func (in *BuildkitTemplateResources) DeepCopy() *BuildkitTemplateResources {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateSpec) DeepCopyInto(out *BuildkitTemplateSpec) {
+	*out = *in
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(BuildkitdConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegistryAuth != nil {
+		in, out := &in.RegistryAuth, &out.RegistryAuth
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	if in.AllowedOverrides != nil {
+		in, out := &in.AllowedOverrides, &out.AllowedOverrides
+		*out = new(BuildkitTemplateAllowedOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
+	in.Observability.DeepCopyInto(&out.Observability)
+	if in.HostUsers != nil {
+		in, out := &in.HostUsers, &out.HostUsers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(BuildkitTemplateTLS)
+		**out = **in
+	}
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.MaxInstances != nil {
+		in, out := &in.MaxInstances, &out.MaxInstances
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateSpec.
+func (in *BuildkitTemplateSpec) DeepCopy() *BuildkitTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateStatus) DeepCopyInto(out *BuildkitTemplateStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.ResourceRefs != nil {
 		in, out := &in.ResourceRefs, &out.ResourceRefs
 		*out = make([]api.TypedObjectRef, len(*in))
 		copy(*out, *in)
 	}
+	out.TemplateInstanceCounts = in.TemplateInstanceCounts
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateStatus.
@@ -373,3 +905,351 @@ func (in *BuildkitTemplateStatus) DeepCopy() *BuildkitTemplateStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateStorage) DeepCopyInto(out *BuildkitTemplateStorage) {
+	*out = *in
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(v1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(v1.PersistentVolumeClaimTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateStorage.
+func (in *BuildkitTemplateStorage) DeepCopy() *BuildkitTemplateStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitTemplateTLS) DeepCopyInto(out *BuildkitTemplateTLS) {
+	*out = *in
+	out.CertificateDuration = in.CertificateDuration
+	out.RenewBefore = in.RenewBefore
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitTemplateTLS.
+func (in *BuildkitTemplateTLS) DeepCopy() *BuildkitTemplateTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitTemplateTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitdConfig) DeepCopyInto(out *BuildkitdConfig) {
+	*out = *in
+	if in.MaxParallelism != nil {
+		in, out := &in.MaxParallelism, &out.MaxParallelism
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GC != nil {
+		in, out := &in.GC, &out.GC
+		*out = new(BuildkitdGCConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]BuildkitdRegistry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(BuildkitdHistory)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitdConfig.
+func (in *BuildkitdConfig) DeepCopy() *BuildkitdConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitdConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitdGCConfig) DeepCopyInto(out *BuildkitdGCConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]BuildkitdGCPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitdGCConfig.
+func (in *BuildkitdGCConfig) DeepCopy() *BuildkitdGCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitdGCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitdGCPolicy) DeepCopyInto(out *BuildkitdGCPolicy) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeepDuration != nil {
+		in, out := &in.KeepDuration, &out.KeepDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitdGCPolicy.
+func (in *BuildkitdGCPolicy) DeepCopy() *BuildkitdGCPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitdGCPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitdHistory) DeepCopyInto(out *BuildkitdHistory) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitdHistory.
+func (in *BuildkitdHistory) DeepCopy() *BuildkitdHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitdHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitdRegistry) DeepCopyInto(out *BuildkitdRegistry) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(BuildkitdRegistryCA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitdRegistry.
+func (in *BuildkitdRegistry) DeepCopy() *BuildkitdRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitdRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildkitdRegistryCA) DeepCopyInto(out *BuildkitdRegistryCA) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildkitdRegistryCA.
+func (in *BuildkitdRegistryCA) DeepCopy() *BuildkitdRegistryCA {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildkitdRegistryCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBuildkitTemplate) DeepCopyInto(out *ClusterBuildkitTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBuildkitTemplate.
+func (in *ClusterBuildkitTemplate) DeepCopy() *ClusterBuildkitTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBuildkitTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBuildkitTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBuildkitTemplateList) DeepCopyInto(out *ClusterBuildkitTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBuildkitTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBuildkitTemplateList.
+func (in *ClusterBuildkitTemplateList) DeepCopy() *ClusterBuildkitTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBuildkitTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBuildkitTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBuildkitTemplateSpec) DeepCopyInto(out *ClusterBuildkitTemplateSpec) {
+	*out = *in
+	in.BuildkitTemplateSpec.DeepCopyInto(&out.BuildkitTemplateSpec)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBuildkitTemplateSpec.
+func (in *ClusterBuildkitTemplateSpec) DeepCopy() *ClusterBuildkitTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBuildkitTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBuildkitTemplateStatus) DeepCopyInto(out *ClusterBuildkitTemplateStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]api.TypedObjectRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.TemplateInstanceCounts = in.TemplateInstanceCounts
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBuildkitTemplateStatus.
+func (in *ClusterBuildkitTemplateStatus) DeepCopy() *ClusterBuildkitTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBuildkitTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateInstanceCounts) DeepCopyInto(out *TemplateInstanceCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateInstanceCounts.
+func (in *TemplateInstanceCounts) DeepCopy() *TemplateInstanceCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateInstanceCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateReference) DeepCopyInto(out *TemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateReference.
+func (in *TemplateReference) DeepCopy() *TemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"github.com/seatgeek/buildkit-operator/internal/cli"
+)
+
+// Version is dynamically set at compile time
+var Version = "0.0.1"
+
+func main() {
+	cmd := cli.NewCommand()
+	cmd.Version = Version
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -20,8 +20,13 @@ import (
 	crtMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_group"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_pool"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_quota"
 	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/cluster_buildkit_template"
 	"github.com/seatgeek/buildkit-operator/internal/controlplane"
+	buildkitmetrics "github.com/seatgeek/buildkit-operator/internal/metrics"
 	intscheme "github.com/seatgeek/buildkit-operator/internal/scheme"
 	"github.com/seatgeek/buildkit-operator/internal/webhooks"
 )
@@ -85,7 +90,8 @@ func initStartFunc(o *opts) bootstrap.StartFunc {
 
 		// map flag values into controlplane's context
 		cpCtx := controlplane.Context{
-			Metrics: promMetrics,
+			Metrics:         promMetrics,
+			BuildkitMetrics: buildkitmetrics.MustMakeMetrics(crtMetrics.Registry),
 		}
 		log, err := logging.FromContext(ctx)
 		if err != nil {
@@ -101,6 +107,18 @@ func initStartFunc(o *opts) bootstrap.StartFunc {
 		if err := buildkit_template.SetupController(ctx, cpCtx, mgr, rl, client); err != nil {
 			return fmt.Errorf("failed to setup BuildkitTemplate controller: %w", err)
 		}
+		if err := buildkit_pool.SetupController(ctx, cpCtx, mgr, rl, client); err != nil {
+			return fmt.Errorf("failed to setup BuildkitPool controller: %w", err)
+		}
+		if err := buildkit_group.SetupController(ctx, cpCtx, mgr, rl, client); err != nil {
+			return fmt.Errorf("failed to setup BuildkitGroup controller: %w", err)
+		}
+		if err := buildkit_quota.SetupController(ctx, cpCtx, mgr, rl, client); err != nil {
+			return fmt.Errorf("failed to setup BuildkitQuota controller: %w", err)
+		}
+		if err := cluster_buildkit_template.SetupController(ctx, cpCtx, mgr, rl, client); err != nil {
+			return fmt.Errorf("failed to setup ClusterBuildkitTemplate controller: %w", err)
+		}
 
 		if err := webhooks.SetupWebhooks(mgr); err != nil {
 			return fmt.Errorf("failed to setup webhooks: %w", err)
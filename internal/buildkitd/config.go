@@ -0,0 +1,200 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package buildkitd renders the buildkitd.toml of a BuildkitTemplate.
+package buildkitd
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/merge"
+)
+
+const (
+	// ConfigDir is where buildkitd reads its config from, and where the operator mounts it
+	ConfigDir = "/etc/buildkit"
+	// RootlessConfigDir is where rootless buildkitd reads its config from
+	RootlessConfigDir = "/home/user/.config/buildkit"
+)
+
+// ConfigDirFor returns where the operator mounts the config of buildkitd, depending on whether it runs rootless.
+func ConfigDirFor(rootless bool) string {
+	if rootless {
+		return RootlessConfigDir
+	}
+	return ConfigDir
+}
+
+// RegistryCAPath returns where the CA bundle of a registry is mounted, relative to buildkitd's config directory.
+func RegistryCAPath(host string) string {
+	return path.Join("certs", host, "ca.crt")
+}
+
+// RegistryClientCertPath returns where the client certificate for a registry is mounted, relative to buildkitd's
+// config directory.
+func RegistryClientCertPath(host string) string {
+	return path.Join("certs", host, "client.crt")
+}
+
+// RegistryClientKeyPath returns where the client key for a registry is mounted, relative to buildkitd's config
+// directory.
+func RegistryClientKeyPath(host string) string {
+	return path.Join("certs", host, "client.key")
+}
+
+// Toml returns the buildkitd.toml for a BuildkitTemplate, or an empty string if it doesn't configure buildkitd at all.
+//
+// The typed spec.config is merged on top of the raw spec.buildkitdToml, so that a setting made in both places takes
+// its value from spec.config. Without spec.config, spec.buildkitdToml is used verbatim, comments and all.
+func Toml(spec *v1alpha1.BuildkitTemplateSpec) (string, error) {
+	if spec.Config == nil {
+		return spec.BuildkitdToml, nil
+	}
+
+	var raw map[string]any
+	if _, err := toml.Decode(spec.BuildkitdToml, &raw); err != nil {
+		return "", fmt.Errorf("failed to decode buildkitdToml: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(merge.Deep(raw, Document(spec))); err != nil {
+		return "", fmt.Errorf("failed to encode buildkitd.toml: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// Document returns the buildkitd.toml settings of a template's typed config, keyed the way buildkitd expects them.
+func Document(spec *v1alpha1.BuildkitTemplateSpec) map[string]any {
+	doc := make(map[string]any)
+	config := spec.Config
+	if config == nil {
+		return doc
+	}
+
+	if config.LogFormat != "" {
+		doc["log"] = map[string]any{"format": string(config.LogFormat)}
+	}
+
+	if workers := workerDocument(config); len(workers) > 0 {
+		doc["worker"] = workers
+	}
+
+	if len(config.Registries) > 0 {
+		registries := make(map[string]any, len(config.Registries))
+		for _, registry := range config.Registries {
+			registries[registry.Host] = registryDocument(registry, ConfigDirFor(spec.Rootless))
+		}
+		doc["registry"] = registries
+	}
+
+	if config.History != nil {
+		history := make(map[string]any)
+		if config.History.MaxAge != nil {
+			history["maxAge"] = config.History.MaxAge.Duration.String()
+		}
+		if config.History.MaxEntries != nil {
+			history["maxEntries"] = *config.History.MaxEntries
+		}
+		doc["history"] = history
+	}
+
+	return doc
+}
+
+// workerDocument returns the worker table, with the worker settings applied to the selected worker, or to the OCI
+// worker when none is selected.
+func workerDocument(config *v1alpha1.BuildkitdConfig) map[string]any {
+	selected := map[string]any{}
+	if config.MaxParallelism != nil {
+		selected["max-parallelism"] = *config.MaxParallelism
+	}
+	if config.GC != nil {
+		gcDocument(selected, config.GC)
+	}
+
+	switch config.Worker {
+	case v1alpha1.BuildkitdWorkerOCI:
+		selected["enabled"] = true
+		return map[string]any{"oci": selected, "containerd": map[string]any{"enabled": false}}
+	case v1alpha1.BuildkitdWorkerContainerd:
+		selected["enabled"] = true
+		return map[string]any{"containerd": selected, "oci": map[string]any{"enabled": false}}
+	}
+
+	if len(selected) == 0 {
+		return nil
+	}
+	return map[string]any{"oci": selected}
+}
+
+func gcDocument(worker map[string]any, gc *v1alpha1.BuildkitdGCConfig) {
+	if gc.Enabled != nil {
+		worker["gc"] = *gc.Enabled
+	}
+	diskSpaceDocument(worker, gc.ReservedSpace, gc.MaxUsedSpace, gc.MinFreeSpace)
+
+	if len(gc.Policies) == 0 {
+		return
+	}
+
+	policies := make([]map[string]any, 0, len(gc.Policies))
+	for _, policy := range gc.Policies {
+		doc := map[string]any{}
+		if policy.All {
+			doc["all"] = true
+		}
+		if len(policy.Filters) > 0 {
+			doc["filters"] = policy.Filters
+		}
+		if policy.KeepDuration != nil {
+			doc["keepDuration"] = policy.KeepDuration.Duration.String()
+		}
+		diskSpaceDocument(doc, policy.ReservedSpace, policy.MaxUsedSpace, policy.MinFreeSpace)
+		policies = append(policies, doc)
+	}
+	worker["gcpolicy"] = policies
+}
+
+func diskSpaceDocument(doc map[string]any, reservedSpace, maxUsedSpace, minFreeSpace string) {
+	if reservedSpace != "" {
+		doc["reservedSpace"] = reservedSpace
+	}
+	if maxUsedSpace != "" {
+		doc["maxUsedSpace"] = maxUsedSpace
+	}
+	if minFreeSpace != "" {
+		doc["minFreeSpace"] = minFreeSpace
+	}
+}
+
+// registryDocument returns the settings of a registry, pointing buildkitd at its certificates where they're mounted
+// under configDir.
+func registryDocument(registry v1alpha1.BuildkitdRegistry, configDir string) map[string]any {
+	doc := map[string]any{}
+	if len(registry.Mirrors) > 0 {
+		doc["mirrors"] = registry.Mirrors
+	}
+	if registry.Insecure {
+		doc["insecure"] = true
+	}
+	if registry.HTTP {
+		doc["http"] = true
+	}
+	if registry.CA != nil {
+		doc["ca"] = []string{path.Join(configDir, RegistryCAPath(registry.Host))}
+	}
+	if registry.ClientCertificate != nil {
+		doc["keypair"] = []map[string]any{{
+			"cert": path.Join(configDir, RegistryClientCertPath(registry.Host)),
+			"key":  path.Join(configDir, RegistryClientKeyPath(registry.Host)),
+		}}
+	}
+	return doc
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkitd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/buildkitd"
+)
+
+func TestToml(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    v1alpha1.BuildkitTemplateSpec
+		want    string
+		wantErr string
+	}{
+		{
+			name: "nothing configured",
+			spec: v1alpha1.BuildkitTemplateSpec{},
+			want: "",
+		},
+		{
+			name: "raw toml is kept verbatim without a typed config",
+			spec: v1alpha1.BuildkitTemplateSpec{
+				BuildkitdToml: "# comment\ndebug = true\n",
+			},
+			want: "# comment\ndebug = true\n",
+		},
+		{
+			name: "empty typed config",
+			spec: v1alpha1.BuildkitTemplateSpec{
+				Config: &v1alpha1.BuildkitdConfig{},
+			},
+			want: "",
+		},
+		{
+			name: "typed config wins over raw toml",
+			spec: v1alpha1.BuildkitTemplateSpec{
+				BuildkitdToml: `debug = true
+
+[worker.oci]
+gc = false
+max-parallelism = 2
+
+[registry."docker.io"]
+mirrors = ["mirror.example.com"]
+`,
+				Config: &v1alpha1.BuildkitdConfig{
+					MaxParallelism: new(int32(8)),
+					Registries: []v1alpha1.BuildkitdRegistry{
+						{Host: "docker.io", Mirrors: []string{"mirror.gcr.io"}},
+					},
+				},
+			},
+			want: `debug = true
+
+[registry]
+  [registry."docker.io"]
+    mirrors = ["mirror.gcr.io"]
+
+[worker]
+  [worker.oci]
+    gc = false
+    max-parallelism = 8
+`,
+		},
+		{
+			name: "invalid raw toml",
+			spec: v1alpha1.BuildkitTemplateSpec{
+				BuildkitdToml: "debug = ",
+				Config:        &v1alpha1.BuildkitdConfig{LogFormat: v1alpha1.BuildkitdLogFormatJSON},
+			},
+			wantErr: "failed to decode buildkitdToml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := buildkitd.Toml(&tt.spec)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDocument(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   *v1alpha1.BuildkitdConfig
+		rootless bool
+		want     map[string]any
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			want:   map[string]any{},
+		},
+		{
+			name: "worker settings go to the oci worker when none is selected",
+			config: &v1alpha1.BuildkitdConfig{
+				MaxParallelism: new(int32(4)),
+				GC: &v1alpha1.BuildkitdGCConfig{
+					Enabled:      new(true),
+					MaxUsedSpace: "50GB",
+				},
+			},
+			want: map[string]any{
+				"worker": map[string]any{
+					"oci": map[string]any{"max-parallelism": int32(4), "gc": true, "maxUsedSpace": "50GB"},
+				},
+			},
+		},
+		{
+			name: "selecting the containerd worker disables the oci worker",
+			config: &v1alpha1.BuildkitdConfig{
+				Worker:         v1alpha1.BuildkitdWorkerContainerd,
+				MaxParallelism: new(int32(2)),
+			},
+			want: map[string]any{
+				"worker": map[string]any{
+					"containerd": map[string]any{"enabled": true, "max-parallelism": int32(2)},
+					"oci":        map[string]any{"enabled": false},
+				},
+			},
+		},
+		{
+			name: "selecting the oci worker disables the containerd worker",
+			config: &v1alpha1.BuildkitdConfig{
+				Worker: v1alpha1.BuildkitdWorkerOCI,
+			},
+			want: map[string]any{
+				"worker": map[string]any{
+					"oci":        map[string]any{"enabled": true},
+					"containerd": map[string]any{"enabled": false},
+				},
+			},
+		},
+		{
+			name: "gc policies",
+			config: &v1alpha1.BuildkitdConfig{
+				GC: &v1alpha1.BuildkitdGCConfig{
+					Policies: []v1alpha1.BuildkitdGCPolicy{
+						{
+							Filters:      []string{"type==source.local"},
+							KeepDuration: &metav1.Duration{Duration: 48 * time.Hour},
+							MaxUsedSpace: "512MB",
+						},
+						{
+							All:           true,
+							ReservedSpace: "10%",
+							MinFreeSpace:  "20GB",
+						},
+					},
+				},
+			},
+			want: map[string]any{
+				"worker": map[string]any{
+					"oci": map[string]any{
+						"gcpolicy": []map[string]any{
+							{"filters": []string{"type==source.local"}, "keepDuration": "48h0m0s", "maxUsedSpace": "512MB"},
+							{"all": true, "reservedSpace": "10%", "minFreeSpace": "20GB"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "registries, history and logging",
+			config: &v1alpha1.BuildkitdConfig{
+				Registries: []v1alpha1.BuildkitdRegistry{
+					{Host: "docker.io", Mirrors: []string{"mirror.gcr.io"}},
+					{Host: "registry.internal:5000", Insecure: true, HTTP: true},
+				},
+				History: &v1alpha1.BuildkitdHistory{
+					MaxAge:     &metav1.Duration{Duration: 24 * time.Hour},
+					MaxEntries: new(int64(100)),
+				},
+				LogFormat: v1alpha1.BuildkitdLogFormatJSON,
+			},
+			want: map[string]any{
+				"registry": map[string]any{
+					"docker.io":              map[string]any{"mirrors": []string{"mirror.gcr.io"}},
+					"registry.internal:5000": map[string]any{"insecure": true, "http": true},
+				},
+				"history": map[string]any{"maxAge": "24h0m0s", "maxEntries": int64(100)},
+				"log":     map[string]any{"format": "json"},
+			},
+		},
+		{
+			name: "registry certificates",
+			config: &v1alpha1.BuildkitdConfig{
+				Registries: []v1alpha1.BuildkitdRegistry{
+					{
+						Host: "registry.internal:5000",
+						CA: &v1alpha1.BuildkitdRegistryCA{
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "internal-ca"},
+								Key:                  "ca.pem",
+							},
+						},
+						ClientCertificate: &corev1.LocalObjectReference{Name: "registry-client"},
+					},
+				},
+			},
+			want: map[string]any{
+				"registry": map[string]any{
+					"registry.internal:5000": map[string]any{
+						"ca": []string{"/etc/buildkit/certs/registry.internal:5000/ca.crt"},
+						"keypair": []map[string]any{{
+							"cert": "/etc/buildkit/certs/registry.internal:5000/client.crt",
+							"key":  "/etc/buildkit/certs/registry.internal:5000/client.key",
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "registry certificates when rootless",
+			config: &v1alpha1.BuildkitdConfig{
+				Registries: []v1alpha1.BuildkitdRegistry{
+					{
+						Host: "registry.internal",
+						CA: &v1alpha1.BuildkitdRegistryCA{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "internal-ca"},
+								Key:                  "ca.crt",
+							},
+						},
+					},
+				},
+			},
+			rootless: true,
+			want: map[string]any{
+				"registry": map[string]any{
+					"registry.internal": map[string]any{
+						"ca": []string{"/home/user/.config/buildkit/certs/registry.internal/ca.crt"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := &v1alpha1.BuildkitTemplateSpec{Config: tt.config, Rootless: tt.rootless}
+			assert.Equal(t, tt.want, buildkitd.Document(spec))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// clientCertificateFiles maps the driver options of the buildx remote driver onto the keys of the client TLS Secret.
+var clientCertificateFiles = []struct{ option, key string }{
+	{"cacert", "ca.crt"},
+	{"cert", "tls.crt"},
+	{"key", "tls.key"},
+}
+
+func newBuildxConfigCommand(e *env) *cobra.Command {
+	var (
+		builder  string
+		certsDir string
+		group    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "buildx-config NAME",
+		Short: "Print the docker buildx command that connects to a Buildkit instance",
+		Long: "Print the docker buildx create command that adds a Buildkit instance as a builder using the remote driver.\n" +
+			"When the instance uses TLS, it's preceded by the commands that save its client certificate to --certs-dir.\n" +
+			"With --group, NAME is a BuildkitGroup, and one command is printed per platform, appending each node to the same builder.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, namespace, err := e.connect()
+			if err != nil {
+				return err
+			}
+
+			if group {
+				bg, err := client.BuildkitV1alpha1().BuildkitGroups(namespace).Get(cmd.Context(), args[0], metav1.GetOptions{})
+				if err != nil {
+					return fmt.Errorf("failed to get BuildkitGroup %s: %w", args[0], err)
+				}
+
+				return writeGroupBuildxConfig(cmd.OutOrStdout(), bg, cmp.Or(builder, bg.Name), cmp.Or(certsDir, path.Join("$HOME", ".buildkit", bg.Namespace)))
+			}
+
+			bk, err := client.BuildkitV1alpha1().Buildkits(namespace).Get(cmd.Context(), args[0], metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get Buildkit %s: %w", args[0], err)
+			}
+
+			if bk.Status.Endpoint == "" {
+				return fmt.Errorf("buildkit %s has no endpoint yet; wait for it to become ready", bk.Name)
+			}
+
+			return writeBuildxConfig(cmd.OutOrStdout(), bk, cmp.Or(certsDir, path.Join("$HOME", ".buildkit", bk.Namespace, bk.Name)), "--name", cmp.Or(builder, bk.Name))
+		},
+	}
+
+	cmd.Flags().StringVar(&builder, "builder", "", "Name of the buildx builder (defaults to the instance or group name)")
+	cmd.Flags().StringVar(&certsDir, "certs-dir", "", "Directory to save the client certificate of instances using TLS to (defaults to $HOME/.buildkit/NAMESPACE/NAME); with --group, each node's goes to a subdirectory named after its instance")
+	cmd.Flags().BoolVar(&group, "group", false, "Treat NAME as a BuildkitGroup and add each of its nodes to a multi-platform builder")
+
+	return cmd
+}
+
+// writeGroupBuildxConfig writes the commands that add every node of the BuildkitGroup to one buildx builder, each
+// building for its own platform. The first command creates the builder and the others append to it.
+func writeGroupBuildxConfig(w io.Writer, bg *v1alpha1.BuildkitGroup, builder, certsDir string) error {
+	if len(bg.Status.Nodes) == 0 {
+		return fmt.Errorf("buildkit group %s has no nodes yet; wait for it to become ready", bg.Name)
+	}
+
+	for _, node := range bg.Status.Nodes {
+		if node.Endpoint == "" {
+			return fmt.Errorf("buildkit group %s has no endpoint for %s yet; wait for it to become ready", bg.Name, node.Platform)
+		}
+	}
+
+	for i, node := range bg.Status.Nodes {
+		bk := &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: node.Buildkit, Namespace: bg.Namespace},
+			Status:     v1alpha1.BuildkitStatus{Endpoint: node.Endpoint, ClientTLSSecretName: node.ClientTLSSecretName},
+		}
+
+		createArgs := []string{"--name", builder}
+		if i > 0 {
+			createArgs = append(createArgs, "--append")
+		}
+		createArgs = append(createArgs, "--node", node.Buildkit, "--platform", node.Platform)
+
+		if err := writeBuildxConfig(w, bk, path.Join(certsDir, node.Buildkit), createArgs...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeBuildxConfig writes the commands that add the Buildkit as a buildx builder using the remote driver.
+// The createArgs name the builder, and the node and platform when it's part of a group.
+func writeBuildxConfig(w io.Writer, bk *v1alpha1.Buildkit, certsDir string, createArgs ...string) error {
+	args := slices.Concat([]string{"docker", "buildx", "create"}, createArgs, []string{"--driver", "remote"})
+
+	if secret := bk.Status.ClientTLSSecretName; secret != "" {
+		fmt.Fprintf(w, "mkdir -p %s\n", certsDir)
+
+		opts := make([]string, 0, len(clientCertificateFiles)+1)
+		for _, file := range clientCertificateFiles {
+			filePath := path.Join(certsDir, file.key)
+			jsonPath := strings.ReplaceAll(file.key, ".", `\.`)
+			fmt.Fprintf(w, "kubectl get secret %s -n %s -o jsonpath='{.data.%s}' | base64 -d > %s\n", secret, bk.Namespace, jsonPath, filePath)
+			opts = append(opts, fmt.Sprintf("%s=%s", file.option, filePath))
+		}
+
+		// The server certificate is issued for the Service's DNS names, which instances using TLS publish unless another
+		// Service has taken their name; the pod IP they fall back to then isn't one of them
+		endpoint, err := url.Parse(bk.Status.Endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to parse endpoint %q: %w", bk.Status.Endpoint, err)
+		}
+		if net.ParseIP(endpoint.Hostname()) != nil {
+			opts = append(opts, fmt.Sprintf("servername=%s.%s.svc", bk.Name, bk.Namespace))
+		}
+
+		args = append(args, "--driver-opt", strings.Join(opts, ","))
+	}
+
+	args = append(args, bk.Status.Endpoint)
+	_, err := fmt.Fprintln(w, strings.Join(args, " "))
+
+	return err
+}
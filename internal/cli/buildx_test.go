@@ -0,0 +1,150 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned/fake"
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestBuildxConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status v1alpha1.BuildkitStatus
+		args   []string
+		want   string
+	}{
+		{
+			name:   "plain TCP",
+			status: v1alpha1.BuildkitStatus{Endpoint: "tcp://10.0.0.1:1234"},
+			want:   "docker buildx create --name test --driver remote tcp://10.0.0.1:1234\n",
+		},
+		{
+			name:   "custom builder name",
+			status: v1alpha1.BuildkitStatus{Endpoint: "tcp://10.0.0.1:1234"},
+			args:   []string{"--builder", "ci-amd64"},
+			want:   "docker buildx create --name ci-amd64 --driver remote tcp://10.0.0.1:1234\n",
+		},
+		{
+			name:   "TLS through the Service",
+			status: v1alpha1.BuildkitStatus{Endpoint: "tcp://test.ci.svc:1234", ClientTLSSecretName: "test-client-tls"},
+			args:   []string{"--certs-dir", "/tmp/certs"},
+			want: "" +
+				"mkdir -p /tmp/certs\n" +
+				`kubectl get secret test-client-tls -n ci -o jsonpath='{.data.ca\.crt}' | base64 -d > /tmp/certs/ca.crt` + "\n" +
+				`kubectl get secret test-client-tls -n ci -o jsonpath='{.data.tls\.crt}' | base64 -d > /tmp/certs/tls.crt` + "\n" +
+				`kubectl get secret test-client-tls -n ci -o jsonpath='{.data.tls\.key}' | base64 -d > /tmp/certs/tls.key` + "\n" +
+				"docker buildx create --name test --driver remote --driver-opt cacert=/tmp/certs/ca.crt,cert=/tmp/certs/tls.crt,key=/tmp/certs/tls.key tcp://test.ci.svc:1234\n",
+		},
+		{
+			name:   "TLS through the pod IP",
+			status: v1alpha1.BuildkitStatus{Endpoint: "tcp://10.0.0.1:1234", ClientTLSSecretName: "test-client-tls"},
+			want: "" +
+				"mkdir -p $HOME/.buildkit/ci/test\n" +
+				`kubectl get secret test-client-tls -n ci -o jsonpath='{.data.ca\.crt}' | base64 -d > $HOME/.buildkit/ci/test/ca.crt` + "\n" +
+				`kubectl get secret test-client-tls -n ci -o jsonpath='{.data.tls\.crt}' | base64 -d > $HOME/.buildkit/ci/test/tls.crt` + "\n" +
+				`kubectl get secret test-client-tls -n ci -o jsonpath='{.data.tls\.key}' | base64 -d > $HOME/.buildkit/ci/test/tls.key` + "\n" +
+				"docker buildx create --name test --driver remote --driver-opt cacert=$HOME/.buildkit/ci/test/ca.crt,cert=$HOME/.buildkit/ci/test/tls.crt,key=$HOME/.buildkit/ci/test/tls.key,servername=test.ci.svc tcp://10.0.0.1:1234\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := fake.NewSimpleClientset(&v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ci"},
+				Status:     tt.status,
+			})
+
+			out, err := run(t, client, append([]string{"buildx-config", "test"}, tt.args...)...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestBuildxConfig_NotReady(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(&v1alpha1.Buildkit{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ci"}})
+
+	_, err := run(t, client, "buildx-config", "test")
+	require.EqualError(t, err, "buildkit test has no endpoint yet; wait for it to become ready")
+}
+
+func TestBuildxConfig_Group(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		nodes []v1alpha1.BuildkitGroupNode
+		args  []string
+		want  string
+	}{
+		{
+			name: "plain TCP",
+			nodes: []v1alpha1.BuildkitGroupNode{
+				{Platform: "linux/amd64", Buildkit: "multi-linux-amd64", Endpoint: "tcp://10.0.0.1:1234"},
+				{Platform: "linux/arm64", Buildkit: "multi-linux-arm64", Endpoint: "tcp://10.0.0.2:1234"},
+			},
+			want: "" +
+				"docker buildx create --name multi --node multi-linux-amd64 --platform linux/amd64 --driver remote tcp://10.0.0.1:1234\n" +
+				"docker buildx create --name multi --append --node multi-linux-arm64 --platform linux/arm64 --driver remote tcp://10.0.0.2:1234\n",
+		},
+		{
+			name: "TLS",
+			nodes: []v1alpha1.BuildkitGroupNode{
+				{Platform: "linux/arm64", Buildkit: "multi-linux-arm64", Endpoint: "tcp://multi-linux-arm64.ci.svc:1234", ClientTLSSecretName: "multi-linux-arm64-client-tls"},
+			},
+			args: []string{"--builder", "ci", "--certs-dir", "/tmp/certs"},
+			want: "" +
+				"mkdir -p /tmp/certs/multi-linux-arm64\n" +
+				`kubectl get secret multi-linux-arm64-client-tls -n ci -o jsonpath='{.data.ca\.crt}' | base64 -d > /tmp/certs/multi-linux-arm64/ca.crt` + "\n" +
+				`kubectl get secret multi-linux-arm64-client-tls -n ci -o jsonpath='{.data.tls\.crt}' | base64 -d > /tmp/certs/multi-linux-arm64/tls.crt` + "\n" +
+				`kubectl get secret multi-linux-arm64-client-tls -n ci -o jsonpath='{.data.tls\.key}' | base64 -d > /tmp/certs/multi-linux-arm64/tls.key` + "\n" +
+				"docker buildx create --name ci --node multi-linux-arm64 --platform linux/arm64 --driver remote --driver-opt cacert=/tmp/certs/multi-linux-arm64/ca.crt,cert=/tmp/certs/multi-linux-arm64/tls.crt,key=/tmp/certs/multi-linux-arm64/tls.key tcp://multi-linux-arm64.ci.svc:1234\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := fake.NewSimpleClientset(&v1alpha1.BuildkitGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "ci"},
+				Status:     v1alpha1.BuildkitGroupStatus{Nodes: tt.nodes},
+			})
+
+			out, err := run(t, client, append([]string{"buildx-config", "--group", "multi"}, tt.args...)...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestBuildxConfig_GroupNotReady(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(&v1alpha1.BuildkitGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "ci"},
+		Status: v1alpha1.BuildkitGroupStatus{Nodes: []v1alpha1.BuildkitGroupNode{
+			{Platform: "linux/amd64", Buildkit: "multi-linux-amd64", Endpoint: "tcp://10.0.0.1:1234"},
+			{Platform: "linux/arm64", Buildkit: "multi-linux-arm64"},
+		}},
+	})
+
+	out, err := run(t, client, "buildx-config", "--group", "multi")
+	require.EqualError(t, err, "buildkit group multi has no endpoint for linux/arm64 yet; wait for it to become ready")
+	assert.Empty(t, out)
+}
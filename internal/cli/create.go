@@ -0,0 +1,99 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned"
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func newCreateCommand(e *env) *cobra.Command {
+	var (
+		template        string
+		clusterTemplate string
+		pool            string
+		waitReady       bool
+		timeout         time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create NAME [--template TEMPLATE | --cluster-template TEMPLATE | --pool POOL]",
+		Short: "Create a Buildkit instance",
+		Long: "Create a Buildkit instance from a BuildkitTemplate, a ClusterBuildkitTemplate or a BuildkitPool.\n" +
+			"Without any of them, the namespace's default BuildkitTemplate is used.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, namespace, err := e.connect()
+			if err != nil {
+				return err
+			}
+
+			bk := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{Name: args[0], Namespace: namespace},
+				Spec:       v1alpha1.BuildkitSpec{Template: template, Pool: pool},
+			}
+			if clusterTemplate != "" {
+				bk.Spec.TemplateRef = &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: clusterTemplate}
+			}
+
+			if _, err := client.BuildkitV1alpha1().Buildkits(namespace).Create(cmd.Context(), bk, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create Buildkit %s: %w", bk.Name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "buildkit/%s created\n", bk.Name)
+
+			if !waitReady {
+				return nil
+			}
+
+			endpoint, err := waitForEndpoint(cmd.Context(), client, namespace, bk.Name, e.pollInterval, timeout)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "buildkit/%s ready at %s\n", bk.Name, endpoint)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&template, "template", "", "Name of the BuildkitTemplate to create the instance from")
+	cmd.Flags().StringVar(&clusterTemplate, "cluster-template", "", "Name of the ClusterBuildkitTemplate to create the instance from")
+	cmd.Flags().StringVar(&pool, "pool", "", "Name of the BuildkitPool to claim a warm pod from")
+	cmd.Flags().BoolVar(&waitReady, "wait", false, "Wait until the instance's endpoint is set")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for the instance with --wait")
+	cmd.MarkFlagsMutuallyExclusive("template", "cluster-template", "pool")
+
+	return cmd
+}
+
+// waitForEndpoint polls a Buildkit until its endpoint is set, giving up if it fails or the timeout passes.
+func waitForEndpoint(ctx context.Context, client versioned.Interface, namespace, name string, interval, timeout time.Duration) (string, error) {
+	var endpoint string
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		bk, err := client.BuildkitV1alpha1().Buildkits(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get Buildkit %s: %w", name, err)
+		}
+
+		if bk.Status.Phase == v1alpha1.BuildkitPhaseFailed {
+			return false, fmt.Errorf("buildkit %s has failed: %s", name, bk.Status.LastFailureReason)
+		}
+
+		endpoint = bk.Status.Endpoint
+		return endpoint != "", nil
+	})
+	if wait.Interrupted(err) {
+		return "", fmt.Errorf("timed out waiting for Buildkit %s to become ready", name)
+	}
+
+	return endpoint, err
+}
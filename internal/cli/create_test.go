@@ -0,0 +1,125 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned/fake"
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantSpec v1alpha1.BuildkitSpec
+	}{
+		{
+			name:     "from a template",
+			args:     []string{"--template", "amd64"},
+			wantSpec: v1alpha1.BuildkitSpec{Template: "amd64"},
+		},
+		{
+			name: "from a cluster template",
+			args: []string{"--cluster-template", "shared"},
+			wantSpec: v1alpha1.BuildkitSpec{
+				TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "shared"},
+			},
+		},
+		{
+			name:     "from a pool",
+			args:     []string{"--pool", "warm"},
+			wantSpec: v1alpha1.BuildkitSpec{Pool: "warm"},
+		},
+		{
+			name: "from the namespace's default template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := fake.NewSimpleClientset()
+			out, err := run(t, client, append([]string{"create", "test"}, tt.args...)...)
+			require.NoError(t, err)
+			assert.Equal(t, "buildkit/test created\n", out)
+
+			bk, err := client.BuildkitV1alpha1().Buildkits("ci").Get(context.Background(), "test", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSpec, bk.Spec)
+		})
+	}
+}
+
+func TestCreate_TemplateFlagsAreExclusive(t *testing.T) {
+	t.Parallel()
+
+	_, err := run(t, fake.NewSimpleClientset(), "create", "test", "--template", "amd64", "--pool", "warm")
+	require.Error(t, err)
+}
+
+func TestCreate_Wait(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset()
+	becomeReadyOnSecondGet(t, client, v1alpha1.BuildkitStatus{Endpoint: "tcp://10.0.0.1:1234"})
+
+	out, err := run(t, client, "create", "test", "--template", "amd64", "--wait")
+	require.NoError(t, err)
+	assert.Equal(t, "buildkit/test created\nbuildkit/test ready at tcp://10.0.0.1:1234\n", out)
+}
+
+func TestCreate_WaitFailed(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset()
+	becomeReadyOnSecondGet(t, client, v1alpha1.BuildkitStatus{Phase: v1alpha1.BuildkitPhaseFailed, LastFailureReason: "Evicted"})
+
+	_, err := run(t, client, "create", "test", "--template", "amd64", "--wait")
+	require.EqualError(t, err, "buildkit test has failed: Evicted")
+}
+
+func TestCreate_WaitTimeout(t *testing.T) {
+	t.Parallel()
+
+	_, err := run(t, fake.NewSimpleClientset(), "create", "test", "--template", "amd64", "--wait", "--timeout", "10ms")
+	require.EqualError(t, err, "timed out waiting for Buildkit test to become ready")
+}
+
+// becomeReadyOnSecondGet has the operator's part played by the fake clientset: the Buildkit gets the given status
+// once it has been fetched once.
+func becomeReadyOnSecondGet(t *testing.T, client *fake.Clientset, status v1alpha1.BuildkitStatus) {
+	t.Helper()
+
+	gets := 0
+	client.PrependReactor("get", "buildkits", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		if gets < 2 {
+			return false, nil, nil
+		}
+
+		get := action.(k8stesting.GetAction)
+		obj, err := client.Tracker().Get(get.GetResource(), get.GetNamespace(), get.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+
+		bk := obj.(*v1alpha1.Buildkit).DeepCopy()
+		bk.Status = status
+
+		return true, bk, nil
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDeleteCommand(e *env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete NAME...",
+		Short: "Delete Buildkit instances",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, namespace, err := e.connect()
+			if err != nil {
+				return err
+			}
+
+			for _, name := range args {
+				if err := client.BuildkitV1alpha1().Buildkits(namespace).Delete(cmd.Context(), name, metav1.DeleteOptions{}); err != nil {
+					return fmt.Errorf("failed to delete Buildkit %s: %w", name, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "buildkit/%s deleted\n", name)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned/fake"
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(
+		&v1alpha1.Buildkit{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ci"}},
+		&v1alpha1.Buildkit{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ci"}},
+		&v1alpha1.Buildkit{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ci"}},
+	)
+
+	out, err := run(t, client, "delete", "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "buildkit/a deleted\nbuildkit/b deleted\n", out)
+
+	list, err := client.BuildkitV1alpha1().Buildkits("ci").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "c", list.Items[0].Name)
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := run(t, fake.NewSimpleClientset(), "delete", "missing")
+	require.Error(t, err)
+	assert.True(t, apierrors.IsNotFound(err))
+}
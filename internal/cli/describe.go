@@ -0,0 +1,81 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func newDescribeCommand(e *env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe NAME",
+		Short: "Show the details of a Buildkit instance",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, namespace, err := e.connect()
+			if err != nil {
+				return err
+			}
+
+			bk, err := client.BuildkitV1alpha1().Buildkits(namespace).Get(cmd.Context(), args[0], metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get Buildkit %s: %w", args[0], err)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+			e.describe(w, bk)
+
+			return w.Flush()
+		},
+	}
+}
+
+func (e *env) describe(w *tabwriter.Writer, bk *v1alpha1.Buildkit) {
+	timestamp := func(t *metav1.Time) string {
+		if t == nil {
+			return "<none>"
+		}
+		return fmt.Sprintf("%s (%s ago)", t.UTC().Format(time.RFC3339), duration.HumanDuration(e.now().Sub(t.Time)))
+	}
+
+	fmt.Fprintf(w, "Name:\t%s\n", bk.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", bk.Namespace)
+	fmt.Fprintf(w, "Template:\t%s\n", templateName(bk))
+	fmt.Fprintf(w, "Created:\t%s\n", timestamp(&bk.CreationTimestamp))
+	fmt.Fprintf(w, "Suspended:\t%t\n", bk.Spec.Suspended)
+	fmt.Fprintf(w, "Phase:\t%s\n", orNone(string(bk.Status.Phase)))
+	fmt.Fprintf(w, "Endpoint:\t%s\n", orNone(bk.Status.Endpoint))
+	fmt.Fprintf(w, "Client TLS Secret:\t%s\n", orNone(bk.Status.ClientTLSSecretName))
+	fmt.Fprintf(w, "Pod:\t%s\n", orNone(bk.Status.PodName))
+	fmt.Fprintf(w, "Node:\t%s\n", orNone(bk.Status.NodeName))
+	fmt.Fprintf(w, "IPs:\t%s\n", orNone(strings.Join(bk.Status.PodIPs, ", ")))
+	fmt.Fprintf(w, "Started:\t%s\n", timestamp(bk.Status.StartTime))
+	fmt.Fprintf(w, "Ready:\t%s\n", timestamp(bk.Status.ReadyTime))
+	fmt.Fprintf(w, "Template Generation:\t%d\n", bk.Status.TemplateGeneration)
+	fmt.Fprintf(w, "Restarts:\t%d\n", bk.Status.RestartCount)
+	if bk.Status.LastFailureTime != nil {
+		fmt.Fprintf(w, "Last Failure:\t%s at %s\n", bk.Status.LastFailureReason, timestamp(bk.Status.LastFailureTime))
+	}
+
+	if len(bk.Status.Conditions) == 0 {
+		fmt.Fprintln(w, "Conditions:\t<none>")
+		return
+	}
+
+	fmt.Fprintln(w, "Conditions:")
+	fmt.Fprintln(w, "  Type\tStatus\tReason\tMessage")
+	for _, condition := range bk.Status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned/fake"
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	started := metav1.NewTime(testNow.Add(-5 * time.Minute))
+	ready := metav1.NewTime(testNow.Add(-4 * time.Minute))
+
+	client := fake.NewSimpleClientset(&v1alpha1.Buildkit{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ci", CreationTimestamp: metav1.NewTime(testNow.Add(-time.Hour))},
+		Spec:       v1alpha1.BuildkitSpec{Template: "amd64"},
+		Status: v1alpha1.BuildkitStatus{
+			ConditionedStatus: api.ConditionedStatus{Conditions: []api.Condition{
+				{Type: v1alpha1.TypeDeployed, Status: corev1.ConditionTrue, Reason: "Available"},
+			}},
+			Phase:              v1alpha1.BuildkitPhaseReady,
+			Endpoint:           "tcp://10.0.0.1:1234",
+			PodName:            "test-abcde",
+			PodIPs:             []string{"10.0.0.1", "fd00::1"},
+			NodeName:           "node-1",
+			StartTime:          &started,
+			ReadyTime:          &ready,
+			TemplateGeneration: 2,
+			RestartCount:       1,
+		},
+	})
+
+	out, err := run(t, client, "describe", "test")
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"Name:                 test\n"+
+		"Namespace:            ci\n"+
+		"Template:             amd64\n"+
+		"Created:              2026-05-01T11:00:00Z (60m ago)\n"+
+		"Suspended:            false\n"+
+		"Phase:                Ready\n"+
+		"Endpoint:             tcp://10.0.0.1:1234\n"+
+		"Client TLS Secret:    <none>\n"+
+		"Pod:                  test-abcde\n"+
+		"Node:                 node-1\n"+
+		"IPs:                  10.0.0.1, fd00::1\n"+
+		"Started:              2026-05-01T11:55:00Z (5m ago)\n"+
+		"Ready:                2026-05-01T11:56:00Z (4m ago)\n"+
+		"Template Generation:  2\n"+
+		"Restarts:             1\n"+
+		"Conditions:\n"+
+		"  Type      Status  Reason     Message\n"+
+		"  Deployed  True    Available  \n", out)
+}
+
+func TestDescribe_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := run(t, fake.NewSimpleClientset(), "describe", "missing")
+	require.ErrorContains(t, err, "failed to get Buildkit missing")
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func newListCommand(e *env) *cobra.Command {
+	var allNamespaces bool
+
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List Buildkit instances",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, namespace, err := e.connect()
+			if err != nil {
+				return err
+			}
+
+			if allNamespaces {
+				namespace = metav1.NamespaceAll
+			}
+
+			list, err := client.BuildkitV1alpha1().Buildkits(namespace).List(cmd.Context(), metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list Buildkits: %w", err)
+			}
+
+			if len(list.Items) == 0 {
+				fmt.Fprintln(cmd.ErrOrStderr(), "No Buildkits found.")
+				return nil
+			}
+
+			slices.SortFunc(list.Items, func(a, b v1alpha1.Buildkit) int {
+				return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+			})
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 3, ' ', 0)
+			if allNamespaces {
+				fmt.Fprint(w, "NAMESPACE\t")
+			}
+			fmt.Fprintln(w, "NAME\tPHASE\tTEMPLATE\tENDPOINT\tAGE")
+
+			for _, bk := range list.Items {
+				if allNamespaces {
+					fmt.Fprintf(w, "%s\t", bk.Namespace)
+				}
+				age := duration.HumanDuration(e.now().Sub(bk.CreationTimestamp.Time))
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", bk.Name, orNone(string(bk.Status.Phase)), templateName(&bk), orNone(bk.Status.Endpoint), age)
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List Buildkits across all namespaces")
+
+	return cmd
+}
+
+// templateName describes what a Buildkit was created from, prefixing pools and cluster templates with their kind.
+func templateName(bk *v1alpha1.Buildkit) string {
+	if bk.Spec.Pool != "" {
+		return "pool/" + bk.Spec.Pool
+	}
+
+	ref := bk.Spec.DirectTemplateRef()
+	switch {
+	case ref == nil:
+		return "<none>"
+	case ref.IsCluster():
+		return "cluster/" + ref.Name
+	default:
+		return ref.Name
+	}
+}
+
+func orNone(s string) string {
+	return cmp.Or(s, "<none>")
+}
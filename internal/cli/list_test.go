@@ -0,0 +1,61 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned/fake"
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ci", CreationTimestamp: metav1.NewTime(testNow.Add(-3 * time.Hour))},
+			Spec:       v1alpha1.BuildkitSpec{Pool: "warm"},
+			Status:     v1alpha1.BuildkitStatus{Phase: v1alpha1.BuildkitPhaseStarting},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ci", CreationTimestamp: metav1.NewTime(testNow.Add(-90 * time.Second))},
+			Spec:       v1alpha1.BuildkitSpec{Template: "amd64"},
+			Status:     v1alpha1.BuildkitStatus{Phase: v1alpha1.BuildkitPhaseReady, Endpoint: "tcp://10.0.0.1:1234"},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "other", CreationTimestamp: metav1.NewTime(testNow.Add(-48 * time.Hour))},
+			Spec:       v1alpha1.BuildkitSpec{TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "shared"}},
+		},
+	)
+
+	out, err := run(t, client, "ls")
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"NAME   PHASE      TEMPLATE    ENDPOINT              AGE\n"+
+		"a      Ready      amd64       tcp://10.0.0.1:1234   90s\n"+
+		"b      Starting   pool/warm   <none>                3h\n", out)
+
+	out, err = run(t, client, "ls", "-A")
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"NAMESPACE   NAME   PHASE      TEMPLATE         ENDPOINT              AGE\n"+
+		"ci          a      Ready      amd64            tcp://10.0.0.1:1234   90s\n"+
+		"ci          b      Starting   pool/warm        <none>                3h\n"+
+		"other       c      <none>     cluster/shared   <none>                2d\n", out)
+}
+
+func TestList_Empty(t *testing.T) {
+	t.Parallel()
+
+	out, err := run(t, fake.NewSimpleClientset(), "ls")
+	require.NoError(t, err)
+	assert.Equal(t, "No Buildkits found.\n", out)
+}
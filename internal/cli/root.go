@@ -0,0 +1,105 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package cli implements kubectl-buildkit, a kubectl plugin for managing Buildkit instances.
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned"
+)
+
+// defaultPollInterval is how often commands that wait for a Buildkit check on it.
+const defaultPollInterval = 2 * time.Second
+
+// clientFactory connects the commands to a cluster.
+type clientFactory interface {
+	// Clientset returns a clientset for the Buildkit API.
+	Clientset() (versioned.Interface, error)
+	// Namespace returns the namespace to work in.
+	Namespace() (string, error)
+}
+
+// env holds what the commands need from their surroundings, so that tests can swap it out.
+type env struct {
+	clients      clientFactory
+	now          func() time.Time
+	pollInterval time.Duration
+}
+
+// kubeconfigFactory connects to the cluster described by the user's kubeconfig, like kubectl does.
+type kubeconfigFactory struct {
+	config clientcmd.ClientConfig
+}
+
+func (f *kubeconfigFactory) Clientset() (versioned.Interface, error) {
+	restConfig, err := f.config.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	return versioned.NewForConfig(restConfig)
+}
+
+func (f *kubeconfigFactory) Namespace() (string, error) {
+	namespace, _, err := f.config.Namespace()
+	return namespace, err
+}
+
+// NewCommand returns the kubectl-buildkit command, which takes the same kubeconfig flags as kubectl.
+func NewCommand() *cobra.Command {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+
+	cmd := newRootCommand(&env{
+		clients:      &kubeconfigFactory{config: config},
+		now:          time.Now,
+		pollInterval: defaultPollInterval,
+	})
+
+	cmd.PersistentFlags().StringVar(&loadingRules.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file to use")
+	clientcmd.BindOverrideFlags(overrides, cmd.PersistentFlags(), clientcmd.RecommendedConfigOverrideFlags(""))
+
+	return cmd
+}
+
+func newRootCommand(e *env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "kubectl-buildkit",
+		Short:         "Manage Buildkit instances",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(
+		newCreateCommand(e),
+		newListCommand(e),
+		newDescribeCommand(e),
+		newDeleteCommand(e),
+		newBuildxConfigCommand(e),
+	)
+
+	return cmd
+}
+
+// connect returns a clientset along with the namespace to work in.
+func (e *env) connect() (versioned.Interface, string, error) {
+	client, err := e.clients.Clientset()
+	if err != nil {
+		return nil, "", err
+	}
+
+	namespace, err := e.clients.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to determine namespace: %w", err)
+	}
+
+	return client, namespace, nil
+}
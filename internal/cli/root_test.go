@@ -0,0 +1,50 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/buildkit-operator/api/client/versioned"
+	"github.com/seatgeek/buildkit-operator/api/client/versioned/fake"
+)
+
+// testNow is the time the commands see in tests.
+var testNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeFactory struct {
+	client    *fake.Clientset
+	namespace string
+}
+
+func (f *fakeFactory) Clientset() (versioned.Interface, error) {
+	return f.client, nil
+}
+
+func (f *fakeFactory) Namespace() (string, error) {
+	return f.namespace, nil
+}
+
+// run runs kubectl-buildkit with the given arguments against the fake clientset, in the "ci" namespace.
+func run(t *testing.T, client *fake.Clientset, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newRootCommand(&env{
+		clients:      &fakeFactory{client: client, namespace: "ci"},
+		now:          func() time.Time { return testNow },
+		pollInterval: time.Millisecond,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// defaultProbeTimeout bounds a single activity probe when the prober doesn't set its own timeout.
+const defaultProbeTimeout = 10 * time.Second
+
+// ActivityProber reports whether a buildkitd instance is currently running any builds.
+type ActivityProber interface {
+	// Active connects to the buildkitd listening on address (host:port), using tlsConfig if it is not nil.
+	Active(ctx context.Context, address string, tlsConfig *tls.Config) (bool, error)
+}
+
+// ControlActivityProber asks buildkitd for its in-progress builds through the control gRPC API.
+// Every build a client is running shows up as an active build history record until it completes.
+type ControlActivityProber struct {
+	// Timeout bounds each probe; defaults to 10 seconds
+	Timeout time.Duration
+}
+
+var _ ActivityProber = ControlActivityProber{}
+
+// Active reports whether buildkitd at address has any builds in progress.
+func (p ControlActivityProber) Active(ctx context.Context, address string, tlsConfig *tls.Config) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(p.Timeout, defaultProbeTimeout))
+	defer cancel()
+
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to buildkitd at %s: %w", address, err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	// With EarlyExit, buildkitd closes the stream once it has sent the builds that are currently running
+	stream, err := controlapi.NewControlClient(conn).ListenBuildHistory(ctx, &controlapi.BuildHistoryRequest{
+		ActiveOnly: true,
+		EarlyExit:  true,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list active builds on %s: %w", address, err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("failed to list active builds on %s: %w", address, err)
+		}
+
+		if event.GetType() == controlapi.BuildHistoryEventType_STARTED && event.GetRecord().GetCompletedAt() == nil {
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seatgeek/buildkit-operator/internal/pki"
+	"github.com/seatgeek/buildkit-operator/internal/test/buildkitd"
+)
+
+func TestControlActivityProber_Active(t *testing.T) {
+	t.Parallel()
+
+	serverTLS, clientTLS := testTLSConfigs(t)
+
+	tests := []struct {
+		name         string
+		activeBuilds int32
+		serverTLS    *tls.Config
+		clientTLS    *tls.Config
+		want         bool
+	}{
+		{
+			name: "no builds running",
+		},
+		{
+			name:         "builds running",
+			activeBuilds: 2,
+			want:         true,
+		},
+		{
+			name:         "builds running over mutual TLS",
+			activeBuilds: 1,
+			serverTLS:    serverTLS,
+			clientTLS:    clientTLS,
+			want:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := buildkitd.Start(tt.serverTLS)
+			require.NoError(t, err)
+			t.Cleanup(server.Stop)
+
+			server.SetActiveBuilds(tt.activeBuilds)
+
+			active, err := ControlActivityProber{}.Active(t.Context(), server.Addr(), tt.clientTLS)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, active)
+		})
+	}
+}
+
+func TestControlActivityProber_ActiveUnreachable(t *testing.T) {
+	t.Parallel()
+
+	server, err := buildkitd.Start(nil)
+	require.NoError(t, err)
+	server.Stop()
+
+	_, err = ControlActivityProber{Timeout: time.Second}.Active(t.Context(), server.Addr(), nil)
+	require.ErrorContains(t, err, "failed to list active builds")
+}
+
+// testTLSConfigs returns matching server and client TLS configs with certificates issued by a throwaway CA.
+func testTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+
+	now := time.Now()
+	ca, err := pki.NewCA("test-ca", time.Hour, now)
+	require.NoError(t, err)
+
+	server, err := ca.Issue(pki.CertificateRequest{
+		CommonName:  "test-buildkit",
+		DNSNames:    []string{"test-buildkit"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+		Usage:       pki.UsageServer,
+		Validity:    time.Hour,
+	}, now)
+	require.NoError(t, err)
+
+	client, err := ca.Issue(pki.CertificateRequest{
+		CommonName: "test-client",
+		Usage:      pki.UsageClient,
+		Validity:   time.Hour,
+	}, now)
+	require.NoError(t, err)
+
+	serverCert, err := tls.X509KeyPair(server.CertPEM, server.KeyPEM)
+	require.NoError(t, err)
+
+	clientCert, err := tls.X509KeyPair(client.CertPEM, client.KeyPEM)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+
+	serverConfig := &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    roots,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	clientConfig := &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      roots,
+		ServerName:   "test-buildkit",
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	return serverConfig, clientConfig
+}
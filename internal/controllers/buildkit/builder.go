@@ -5,23 +5,48 @@
 package buildkit
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/buildkitd"
 	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit/resources"
 	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
 	"github.com/seatgeek/buildkit-operator/internal/merge"
+	"github.com/seatgeek/buildkit-operator/internal/pki"
+)
+
+// buildkitContainerName is the name of the container running buildkitd in each Buildkit pod.
+const buildkitContainerName = "buildkit"
+
+const (
+	// socketAddr is the unix socket buildkitd listens on, alongside its TCP port
+	socketAddr = "unix:///run/buildkit/buildkitd.sock"
+	// rootlessSocketAddr is the unix socket buildkitd listens on when running rootless
+	rootlessSocketAddr = "unix:///run/user/1000/buildkit/buildkitd.sock"
 )
 
 type Builder struct {
 	buildkit *v1alpha1.Buildkit
 	cl       client.Reader
+	template *v1alpha1.BuildkitTemplate
+
+	// templateOwner is the object the template was loaded from, which differs from the template for ClusterBuildkitTemplates
+	templateOwner client.Object
+
+	// pool is set when building the warm pods of a BuildkitPool rather than a pod for a Buildkit instance
+	pool string
 }
 
 func NewBuilder(buildkit *v1alpha1.Buildkit, cl client.Reader) *Builder {
@@ -31,16 +56,211 @@ func NewBuilder(buildkit *v1alpha1.Buildkit, cl client.Reader) *Builder {
 	}
 }
 
-func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
-	// Load the referenced BuildkitTemplate
+// NewPoolBuilder returns a Builder for the warm pods of a BuildkitPool.
+// These pods are labelled with the pool rather than an instance until a Buildkit claims them.
+func NewPoolBuilder(pool *v1alpha1.BuildkitPool, cl client.Reader) *Builder {
+	return &Builder{
+		buildkit: &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pool.Name,
+				Namespace: pool.Namespace,
+			},
+			Spec: v1alpha1.BuildkitSpec{
+				Template: pool.Spec.Template,
+			},
+		},
+		cl:   cl,
+		pool: pool.Name,
+	}
+}
+
+// Template loads the BuildkitTemplate referenced by the Buildkit instance, either directly or through its pool.
+// A referenced ClusterBuildkitTemplate is returned as a BuildkitTemplate in the instance's namespace.
+// The result is cached, so repeated calls on the same Builder only hit the client once.
+func (b *Builder) Template(ctx context.Context) (*v1alpha1.BuildkitTemplate, error) {
+	if b.template != nil {
+		return b.template, nil
+	}
+
+	ref := b.buildkit.Spec.DirectTemplateRef()
+	if b.buildkit.Spec.Pool != "" {
+		var pool v1alpha1.BuildkitPool
+		if err := b.cl.Get(ctx, client.ObjectKey{Name: b.buildkit.Spec.Pool, Namespace: b.buildkit.Namespace}, &pool); err != nil {
+			return nil, err
+		}
+		ref = &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: pool.Spec.Template}
+	}
+
+	if ref == nil {
+		return nil, errors.New("buildkit references neither a template nor a pool")
+	}
+
+	if ref.IsCluster() {
+		var clusterTemplate v1alpha1.ClusterBuildkitTemplate
+		if err := b.cl.Get(ctx, client.ObjectKey{Name: ref.Name}, &clusterTemplate); err != nil {
+			return nil, err
+		}
+
+		b.template, b.templateOwner = clusterTemplate.InNamespace(b.buildkit.Namespace), &clusterTemplate
+		return b.template, nil
+	}
+
 	var template v1alpha1.BuildkitTemplate
-	key := client.ObjectKey{Name: b.buildkit.Spec.Template, Namespace: b.buildkit.Namespace}
+	key := client.ObjectKey{Name: ref.Name, Namespace: b.buildkit.Namespace}
 	if err := b.cl.Get(ctx, key, &template); err != nil {
 		return nil, err
 	}
 
+	b.template, b.templateOwner = &template, &template
+	return b.template, nil
+}
+
+// serverTLSSecretName returns the name of the Secret holding the buildkitd server certificate.
+func (b *Builder) serverTLSSecretName() string {
+	return b.buildkit.Name + "-server-tls"
+}
+
+// clientTLSSecretName returns the name of the Secret holding the client certificate for connecting to buildkitd.
+func (b *Builder) clientTLSSecretName() string {
+	return b.buildkit.Name + "-client-tls"
+}
+
+// selectorLabels returns the labels that uniquely identify the pod of this Buildkit instance.
+func (b *Builder) selectorLabels() map[string]string {
+	return map[string]string{v1alpha1.InstanceLabel: b.buildkit.Name}
+}
+
+// storageType returns the template's storage type, treating an unset type as EmptyDir.
+func storageType(template *v1alpha1.BuildkitTemplate) v1alpha1.StorageType {
+	return cmp.Or(template.Spec.Storage.Type, v1alpha1.StorageTypeEmptyDir)
+}
+
+// stateVolumeSource returns the source of the volume mounted at buildkitd's state directory.
+func (b *Builder) stateVolumeSource(template *v1alpha1.BuildkitTemplate) corev1.VolumeSource {
+	storage := template.Spec.Storage
+
+	switch storageType(template) {
+	case v1alpha1.StorageTypePersistentVolumeClaim:
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: b.cacheClaimName(template),
+			},
+		}
+	case v1alpha1.StorageTypeEphemeral:
+		return corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: storage.VolumeClaimTemplate.DeepCopy(),
+			},
+		}
+	default:
+		if storage.EmptyDir != nil {
+			return corev1.VolumeSource{EmptyDir: storage.EmptyDir.DeepCopy()}
+		}
+
+		return corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	}
+}
+
+// cacheClaimName returns the name of the PVC holding the build cache when the template uses PersistentVolumeClaim storage.
+// Retained PVCs are named after the template and cache key so that later instances can find them.
+func (b *Builder) cacheClaimName(template *v1alpha1.BuildkitTemplate) string {
+	if template.Spec.Storage.RetentionPolicy == v1alpha1.StorageRetentionPolicyRetain {
+		return fmt.Sprintf("%s-cache-%s", template.ResourcePrefix(), cmp.Or(b.buildkit.Spec.CacheKey, b.buildkit.Name))
+	}
+
+	return b.buildkit.Name + "-cache"
+}
+
+// BuildCacheClaim returns the PVC holding the build cache when the template uses PersistentVolumeClaim storage.
+// PVCs that are deleted along with the instance are labelled with it; retained ones outlive it and are not.
+func (b *Builder) BuildCacheClaim(template *v1alpha1.BuildkitTemplate) *corev1.PersistentVolumeClaim {
+	claimTemplate := template.Spec.Storage.VolumeClaimTemplate
+	if claimTemplate == nil {
+		claimTemplate = &corev1.PersistentVolumeClaimTemplate{}
+	}
+
+	labels := merge.Maps(claimTemplate.Labels)
+	if template.Spec.Storage.RetentionPolicy != v1alpha1.StorageRetentionPolicyRetain {
+		labels = merge.Maps(labels, b.selectorLabels())
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.cacheClaimName(template),
+			Namespace:   b.buildkit.Namespace,
+			Labels:      labels,
+			Annotations: merge.Maps(claimTemplate.Annotations),
+		},
+		Spec: *claimTemplate.Spec.DeepCopy(),
+	}
+}
+
+// scheduling returns the template's scheduling with the instance's overrides merged on top: its nodeSelector entries
+// replace the template's for the same keys, its tolerations are added to the template's, and its priority class
+// replaces the template's. The webhook has already checked them against the template's allowedOverrides.
+func (b *Builder) scheduling(template *v1alpha1.BuildkitTemplate) v1alpha1.BuildkitTemplatePodScheduling {
+	scheduling := template.Spec.Scheduling
+	overrides := b.buildkit.Spec.Scheduling
+
+	if len(overrides.NodeSelector) > 0 {
+		scheduling.NodeSelector = merge.Maps(scheduling.NodeSelector, overrides.NodeSelector)
+	}
+	if len(overrides.Tolerations) > 0 {
+		scheduling.Tolerations = slices.Concat(scheduling.Tolerations, overrides.Tolerations)
+	}
+	scheduling.PriorityClassName = cmp.Or(overrides.PriorityClassName, scheduling.PriorityClassName)
+
+	return scheduling
+}
+
+// podIdentityLabels returns the labels that tie a new pod to its Buildkit instance, or to its pool for warm pods.
+func (b *Builder) podIdentityLabels() map[string]string {
+	if b.pool != "" {
+		return map[string]string{v1alpha1.PoolLabel: b.pool}
+	}
+
+	return b.selectorLabels()
+}
+
+// BuildService returns the ClusterIP Service that provides a stable address for the Buildkit instance's pod.
+// The port should match the one the current pod listens on, which may differ from the template if it has since changed.
+func (b *Builder) BuildService(port int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.buildkit.Name,
+			Namespace: b.buildkit.Namespace,
+			Labels:    b.selectorLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: b.selectorLabels(),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "tcp",
+					Port:       port,
+					TargetPort: intstr.FromString("tcp"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
+	// Load the referenced BuildkitTemplate
+	template, err := b.Template(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduling := b.scheduling(template)
+
+	socket := socketAddr
+	if template.Spec.Rootless {
+		socket = rootlessSocketAddr
+	}
+
 	// We define the overrideable defaults first; non-overrideable values will be set further down
-	const buildkitContainerName = "buildkit"
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: b.buildkit.Name + "-",
@@ -51,9 +271,10 @@ func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
 				template.Spec.PodAnnotations,
 			),
 			Labels: merge.Maps(
-				map[string]string{"app.kubernetes.io/name": "buildkit"},
+				defaultPodLabels(),
 				b.buildkit.Spec.Labels,
 				template.Spec.PodLabels,
+				b.podIdentityLabels(),
 			),
 		},
 		Spec: corev1.PodSpec{
@@ -71,7 +292,7 @@ func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
 					Command: template.Spec.Command,
 					Args: []string{
 						"--addr",
-						"unix:///run/buildkit/buildkitd.sock",
+						socket,
 						"--addr",
 						fmt.Sprintf("tcp://0.0.0.0:%d", template.Spec.Port),
 					},
@@ -118,25 +339,27 @@ func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
 			},
 			Volumes: []corev1.Volume{
 				{
-					Name: "buildkitd",
-					VolumeSource: corev1.VolumeSource{
-						EmptyDir: &corev1.EmptyDirVolumeSource{},
-					},
+					Name:         "buildkitd",
+					VolumeSource: b.stateVolumeSource(template),
 				},
 			},
 			HostUsers:                     template.Spec.HostUsers,
 			ServiceAccountName:            template.Spec.ServiceAccountName,
-			NodeSelector:                  template.Spec.Scheduling.NodeSelector,
-			Tolerations:                   template.Spec.Scheduling.Tolerations,
-			Affinity:                      template.Spec.Scheduling.Affinity,
-			TopologySpreadConstraints:     template.Spec.Scheduling.TopologySpreadConstraints,
-			PriorityClassName:             template.Spec.Scheduling.PriorityClassName,
+			ImagePullSecrets:              template.Spec.ImagePullSecrets,
+			NodeSelector:                  scheduling.NodeSelector,
+			Tolerations:                   scheduling.Tolerations,
+			Affinity:                      scheduling.Affinity,
+			TopologySpreadConstraints:     scheduling.TopologySpreadConstraints,
+			PriorityClassName:             scheduling.PriorityClassName,
 			RestartPolicy:                 template.Spec.Lifecycle.RestartPolicy,
 			TerminationGracePeriodSeconds: template.Spec.Lifecycle.TerminationGracePeriodSeconds,
 			ActiveDeadlineSeconds:         template.Spec.Lifecycle.ActiveDeadlineSeconds,
 		},
 	}
 
+	// Record which labels and annotations came from the Buildkit's spec, so that later changes to them can be applied
+	syncSpecMetadata(pod, b.buildkit, template)
+
 	// Create a reference to the main container to keep the following code cleaner
 	container := &pod.Spec.Containers[0]
 
@@ -145,7 +368,6 @@ func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
 			"container.apparmor.security.beta.kubernetes.io/" + buildkitContainerName: "unconfined",
 		})
 		container.VolumeMounts[0].MountPath = "/home/user/.local/share/buildkit"
-		container.Args[1] = "unix:///run/user/1000/buildkit/buildkitd.sock"
 		container.Args = append(container.Args, "--oci-worker-no-process-sandbox")
 		container.SecurityContext = &corev1.SecurityContext{
 			SeccompProfile: &corev1.SeccompProfile{
@@ -154,6 +376,13 @@ func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
 			RunAsUser:  new(int64(1000)),
 			RunAsGroup: new(int64(1000)),
 		}
+
+		// Unlike emptyDirs, claimed volumes aren't necessarily writable by the rootless user
+		if storageType(template) != v1alpha1.StorageTypeEmptyDir {
+			pod.Spec.SecurityContext = &corev1.PodSecurityContext{
+				FSGroup: new(int64(1000)),
+			}
+		}
 	}
 
 	if template.Spec.Observability.DebugLogging {
@@ -197,32 +426,105 @@ func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
 		)
 	}
 
-	// Mount buildkitd.toml config map if needed
-	if configMap := buildkit_template.NewBuilder(&template).ConfigMap(); configMap != nil {
+	// Enable mutual TLS on the TCP listener if needed
+	if template.Spec.TLS != nil {
+		const tlsMountPath = "/certs"
+
 		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
-			Name: "config",
+			Name: "tls",
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: b.serverTLSSecretName(),
+				},
+			},
+		})
+
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "tls",
+			MountPath: tlsMountPath,
+			ReadOnly:  true,
+		})
+
+		container.Args = append(container.Args,
+			"--tlscacert", path.Join(tlsMountPath, pki.CACertKey),
+			"--tlscert", path.Join(tlsMountPath, pki.TLSCertKey),
+			"--tlskey", path.Join(tlsMountPath, pki.TLSKeyKey),
+		)
+
+		// The kubelet's gRPC probes can't present a client certificate, so probe buildkitd over its unix socket instead
+		probeHandler := corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"buildctl", "--addr", socket, "debug", "workers"},
+			},
+		}
+		container.StartupProbe.ProbeHandler = probeHandler
+		container.ReadinessProbe.ProbeHandler = probeHandler
+		container.LivenessProbe.ProbeHandler = probeHandler
+	}
+
+	// Mount buildkitd.toml config map if needed, along with the registry certificates it points at
+	if configMap := buildkit_template.NewBuilder(template).ConfigMap(); configMap != nil {
+		sources := []corev1.VolumeProjection{
+			{
+				ConfigMap: &corev1.ConfigMapProjection{
 					LocalObjectReference: corev1.LocalObjectReference{
 						Name: configMap.Name,
 					},
 				},
 			},
+		}
+
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: append(sources, registryCertProjections(template.Spec.Config)...),
+				},
+			},
 		})
 
-		mountPath := "/etc/buildkit"
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "config",
+			MountPath: buildkitd.ConfigDirFor(template.Spec.Rootless),
+		})
+	}
+
+	// Mount the merged registry credentials as buildkitd's Docker config if needed
+	if len(template.Spec.RegistryAuth) > 0 {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "registry-auth",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: buildkit_template.NewBuilder(template).RegistryAuthSecretName(),
+								},
+								Items: []corev1.KeyToPath{
+									{Key: corev1.DockerConfigJsonKey, Path: "config.json"},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		mountPath := "/root/.docker"
 		if template.Spec.Rootless {
-			mountPath = "/home/user/.config/buildkit"
+			mountPath = "/home/user/.docker"
 		}
 
 		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
-			Name:      "config",
+			Name:      "registry-auth",
 			MountPath: mountPath,
+			ReadOnly:  true,
 		})
 	}
 
 	// Configure pre-stop script if needed
-	if configMap := buildkit_template.NewBuilder(&template).ScriptsConfigMap(); configMap != nil {
+	if configMap := buildkit_template.NewBuilder(template).ScriptsConfigMap(); configMap != nil {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
 			Name: "scripts",
 			VolumeSource: corev1.VolumeSource{
@@ -250,5 +552,15 @@ func (b *Builder) BuildPod(ctx context.Context) (*corev1.Pod, error) {
 		}
 	}
 
+	// Record which version of the template the pod was started from, so that later changes can be rolled out and reported
+	templateHash, err := buildkit_template.NewBuilder(template).PodSpecHash()
+	if err != nil {
+		return nil, err
+	}
+	pod.Annotations = merge.Maps(pod.Annotations, map[string]string{
+		v1alpha1.TemplateHashAnnotation:       templateHash,
+		v1alpha1.TemplateGenerationAnnotation: strconv.FormatInt(template.Generation, 10),
+	})
+
 	return pod, nil
 }
@@ -178,6 +178,206 @@ func TestBuilder_BuildPod(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with tls",
+			buildkit: &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "test-template",
+				},
+			},
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Port:  1234,
+					Image: "moby/buildkit:latest",
+					TLS:   &v1alpha1.BuildkitTemplateTLS{},
+				},
+			},
+		},
+		{
+			name: "with registry credentials",
+			buildkit: &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "test-template",
+				},
+			},
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Port:             1234,
+					Image:            "registry.example.com/moby/buildkit:rootless",
+					Rootless:         true,
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "image-pull"}},
+					RegistryAuth: []corev1.LocalObjectReference{
+						{Name: "dockerhub"},
+						{Name: "private-registry"},
+					},
+				},
+			},
+		},
+		{
+			name: "with registry certificates",
+			buildkit: &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "test-template",
+				},
+			},
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Port:  1234,
+					Image: "moby/buildkit:latest",
+					Config: &v1alpha1.BuildkitdConfig{
+						Registries: []v1alpha1.BuildkitdRegistry{
+							{
+								Host: "registry.internal:5000",
+								CA: &v1alpha1.BuildkitdRegistryCA{
+									ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "internal-ca"},
+										Key:                  "ca.pem",
+									},
+								},
+								ClientCertificate: &corev1.LocalObjectReference{Name: "registry-client"},
+							},
+							{
+								Host:    "docker.io",
+								Mirrors: []string{"mirror.internal"},
+								CA: &v1alpha1.BuildkitdRegistryCA{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "mirror-ca"},
+										Key:                  "ca.crt",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with emptydir size limit",
+			buildkit: &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "test-template",
+				},
+			},
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Port:  1234,
+					Image: "moby/buildkit:latest",
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type: v1alpha1.StorageTypeEmptyDir,
+						EmptyDir: &corev1.EmptyDirVolumeSource{
+							Medium:    corev1.StorageMediumMemory,
+							SizeLimit: new(resource.MustParse("10Gi")),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with retained persistent storage",
+			buildkit: &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "test-template",
+					CacheKey: "main",
+				},
+			},
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Port:     1234,
+					Image:    "moby/buildkit:rootless",
+					Rootless: true,
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type:            v1alpha1.StorageTypePersistentVolumeClaim,
+						RetentionPolicy: v1alpha1.StorageRetentionPolicyRetain,
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("50Gi"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with ephemeral storage",
+			buildkit: &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "test-template",
+				},
+			},
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Port:  1234,
+					Image: "moby/buildkit:latest",
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type: v1alpha1.StorageTypeEphemeral,
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+								StorageClassName: new("fast-local"),
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("50Gi"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "hostusers false",
 			buildkit: &v1alpha1.Buildkit{
@@ -370,6 +570,50 @@ func TestBuilder_BuildPod(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "scheduling overrides",
+			buildkit: &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "test-template",
+					Scheduling: v1alpha1.BuildkitSchedulingOverrides{
+						NodeSelector: map[string]string{"topology.kubernetes.io/zone": "us-east-1b"},
+						Tolerations: []corev1.Toleration{
+							{Key: "gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+						},
+						PriorityClassName: "builds-high",
+					},
+				},
+			},
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Image: "moby/buildkit:latest",
+					Port:  1234,
+					Scheduling: v1alpha1.BuildkitTemplatePodScheduling{
+						NodeSelector: map[string]string{
+							"kubernetes.io/arch":          "amd64",
+							"topology.kubernetes.io/zone": "us-east-1a",
+						},
+						Tolerations: []corev1.Toleration{
+							{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "buildkit", Effect: corev1.TaintEffectNoSchedule},
+						},
+						PriorityClassName: "builds",
+					},
+					AllowedOverrides: &v1alpha1.BuildkitTemplateAllowedOverrides{
+						NodeSelectorKeys:   []string{"topology.kubernetes.io/zone"},
+						TolerationKeys:     []string{"gpu"},
+						PriorityClassNames: []string{"builds-high"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -407,3 +651,195 @@ func TestBuilder_BuildPod(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_BuildService(t *testing.T) {
+	t.Parallel()
+
+	buildkit := &v1alpha1.Buildkit{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-buildkit",
+			Namespace: "test-ns",
+		},
+	}
+
+	svc := NewBuilder(buildkit, nil).BuildService(5678)
+
+	assert.Equal(t, "test-buildkit", svc.Name)
+	assert.Equal(t, "test-ns", svc.Namespace)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+	assert.Equal(t, map[string]string{v1alpha1.InstanceLabel: "test-buildkit"}, svc.Spec.Selector)
+	require.Len(t, svc.Spec.Ports, 1)
+	assert.Equal(t, int32(5678), svc.Spec.Ports[0].Port)
+	assert.Equal(t, "tcp", svc.Spec.Ports[0].TargetPort.String())
+}
+
+func TestNewPoolBuilder(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	template := &v1alpha1.BuildkitTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-template",
+			Namespace: "test-ns",
+		},
+		Spec: v1alpha1.BuildkitTemplateSpec{
+			Port:  1234,
+			Image: "moby/buildkit:latest",
+		},
+	}
+	pool := &v1alpha1.BuildkitPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pool",
+			Namespace: "test-ns",
+		},
+		Spec: v1alpha1.BuildkitPoolSpec{
+			Template: "test-template",
+			Size:     2,
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(template, pool).Build()
+
+	pod, err := NewPoolBuilder(pool, client).BuildPod(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-pool-", pod.GenerateName)
+	assert.Equal(t, "test-pool", pod.Labels[v1alpha1.PoolLabel])
+	assert.NotContains(t, pod.Labels, v1alpha1.InstanceLabel, "warm pods must not be selected by any instance's Service")
+
+	// A Buildkit referencing the pool resolves the same template
+	resolved, err := NewBuilder(&v1alpha1.Buildkit{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-buildkit", Namespace: "test-ns"},
+		Spec:       v1alpha1.BuildkitSpec{Pool: "test-pool"},
+	}, client).Template(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "test-template", resolved.Name)
+}
+
+func TestBuilder_ClusterTemplate(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	clusterTemplate := &v1alpha1.ClusterBuildkitTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-template"},
+		Spec: v1alpha1.ClusterBuildkitTemplateSpec{
+			BuildkitTemplateSpec: v1alpha1.BuildkitTemplateSpec{
+				Port:          1234,
+				Image:         "moby/buildkit:latest",
+				BuildkitdToml: "debug = true",
+			},
+		},
+	}
+	// A namespaced template with the same name must not be picked up instead
+	namespacedTemplate := &v1alpha1.BuildkitTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-template", Namespace: "test-ns"},
+		Spec:       v1alpha1.BuildkitTemplateSpec{Port: 5678, Image: "moby/buildkit:other"},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(clusterTemplate, namespacedTemplate).Build()
+
+	builder := NewBuilder(&v1alpha1.Buildkit{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-buildkit", Namespace: "test-ns"},
+		Spec: v1alpha1.BuildkitSpec{
+			TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "shared-template"},
+		},
+	}, client)
+
+	template, err := builder.Template(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "test-ns", template.Namespace)
+	assert.Equal(t, "moby/buildkit:latest", template.Spec.Image)
+	assert.IsType(t, &v1alpha1.ClusterBuildkitTemplate{}, builder.templateOwner)
+
+	pod, err := builder.BuildPod(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "moby/buildkit:latest", pod.Spec.Containers[0].Image)
+	assert.Contains(t, pod.Spec.Volumes, corev1.Volume{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "buildkit-cluster-shared-template-toml"},
+					},
+				}},
+			},
+		},
+	})
+}
+
+func TestBuilder_BuildCacheClaim(t *testing.T) {
+	t.Parallel()
+
+	claimTemplate := &corev1.PersistentVolumeClaimTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"team": "ci"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("50Gi"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		cacheKey        string
+		retentionPolicy v1alpha1.StorageRetentionPolicy
+		wantName        string
+		wantLabels      map[string]string
+	}{
+		{
+			name:            "deleted with the instance",
+			retentionPolicy: v1alpha1.StorageRetentionPolicyDelete,
+			wantName:        "test-buildkit-cache",
+			wantLabels:      map[string]string{"team": "ci", v1alpha1.InstanceLabel: "test-buildkit"},
+		},
+		{
+			name:            "retained with cache key",
+			cacheKey:        "main",
+			retentionPolicy: v1alpha1.StorageRetentionPolicyRetain,
+			wantName:        "buildkit-test-template-cache-main",
+			wantLabels:      map[string]string{"team": "ci"},
+		},
+		{
+			name:            "retained without cache key",
+			retentionPolicy: v1alpha1.StorageRetentionPolicyRetain,
+			wantName:        "buildkit-test-template-cache-test-buildkit",
+			wantLabels:      map[string]string{"team": "ci"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-buildkit", Namespace: "test-ns"},
+				Spec:       v1alpha1.BuildkitSpec{Template: "test-template", CacheKey: tt.cacheKey},
+			}
+			template := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "test-ns"},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type:                v1alpha1.StorageTypePersistentVolumeClaim,
+						RetentionPolicy:     tt.retentionPolicy,
+						VolumeClaimTemplate: claimTemplate,
+					},
+				},
+			}
+
+			pvc := NewBuilder(buildkit, nil).BuildCacheClaim(template)
+
+			assert.Equal(t, tt.wantName, pvc.Name)
+			assert.Equal(t, "test-ns", pvc.Namespace)
+			assert.Equal(t, tt.wantLabels, pvc.Labels)
+			assert.Equal(t, claimTemplate.Spec, pvc.Spec)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit/resources"
+)
+
+// recordResources records the resources a Buildkit's pod gets from its template, and whether any of the requests or
+// limits it ends up with were over the template's maximums and have been reduced to them.
+func (r *reconciler) recordResources(obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) {
+	maximum, desired := template.Spec.Resources.Maximum, []corev1.ResourceRequirements{template.Spec.Resources.Default, obj.Spec.Resources}
+
+	effective := resources.WithMaximums(maximum, desired...)
+	obj.Status.EffectiveResources = &effective
+
+	excesses := resources.Exceeding(maximum, desired...)
+	if len(excesses) == 0 {
+		setCondition(obj, v1alpha1.TypeResourcesClamped, corev1.ConditionFalse, "WithinMaximums", "Resources are within the template's maximums")
+		return
+	}
+
+	descriptions := make([]string, 0, len(excesses))
+	for _, excess := range excesses {
+		descriptions = append(descriptions, excess.String())
+	}
+	message := fmt.Sprintf("Resources were reduced to the maximums of template %s: %s", template.Name, strings.Join(descriptions, "; "))
+
+	if obj.GetCondition(v1alpha1.TypeResourcesClamped).Status != corev1.ConditionTrue {
+		r.recorder.Event(obj, corev1.EventTypeWarning, v1alpha1.EventReasonResourcesClamped, message)
+	}
+
+	setCondition(obj, v1alpha1.TypeResourcesClamped, corev1.ConditionTrue, "ExceedsMaximums", message)
+}
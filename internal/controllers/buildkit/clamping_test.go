@@ -0,0 +1,65 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestRecordResources(t *testing.T) {
+	t.Parallel()
+
+	template := &v1alpha1.BuildkitTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "capped"},
+		Spec: v1alpha1.BuildkitTemplateSpec{
+			Resources: v1alpha1.BuildkitTemplateResources{
+				Default: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+				Maximum: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &reconciler{recorder: recorder}
+
+	obj := &v1alpha1.Buildkit{
+		Spec: v1alpha1.BuildkitSpec{
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi")},
+			},
+		},
+	}
+
+	r.recordResources(obj, template)
+	require.NotNil(t, obj.Status.EffectiveResources)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+	}, *obj.Status.EffectiveResources)
+	clamped := obj.GetCondition(v1alpha1.TypeResourcesClamped)
+	assert.Equal(t, corev1.ConditionTrue, clamped.Status)
+	assert.Equal(t, "Resources were reduced to the maximums of template capped: limits.memory: 64Gi is over the maximum of 16Gi", clamped.Message)
+	assert.Len(t, recorder.Events, 1)
+
+	// Recording the same resources again doesn't repeat the event
+	r.recordResources(obj, template)
+	assert.Len(t, recorder.Events, 1)
+
+	obj.Spec.Resources.Limits[corev1.ResourceMemory] = resource.MustParse("8Gi")
+	r.recordResources(obj, template)
+	assert.Equal(t, resource.MustParse("8Gi"), obj.Status.EffectiveResources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, corev1.ConditionFalse, obj.GetCondition(v1alpha1.TypeResourcesClamped).Status)
+}
@@ -7,6 +7,7 @@ package buildkit
 import (
 	"github.com/reddit/achilles-sdk-api/api"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
 )
@@ -16,3 +17,20 @@ var conditionDeployed = api.Condition{
 	Status: corev1.ConditionTrue,
 	Reason: api.ReasonAvailable,
 }
+
+// setCondition sets a condition on the Buildkit, keeping its last transition time unless its status changes.
+func setCondition(obj *v1alpha1.Buildkit, conditionType api.ConditionType, status corev1.ConditionStatus, reason api.ConditionReason, message string) {
+	transitionTime := obj.GetCondition(conditionType).LastTransitionTime
+	if obj.GetCondition(conditionType).Status != status {
+		transitionTime = metav1.Now()
+	}
+
+	obj.SetConditions(api.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: obj.Generation,
+		LastTransitionTime: transitionTime,
+		Reason:             reason,
+		Message:            message,
+	})
+}
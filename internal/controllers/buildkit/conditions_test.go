@@ -0,0 +1,65 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestSetCondition(t *testing.T) {
+	t.Parallel()
+
+	earlier := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	tests := []struct {
+		name            string
+		status          corev1.ConditionStatus
+		reason          api.ConditionReason
+		keepsTransition bool
+	}{
+		{
+			name:            "reason changes",
+			status:          corev1.ConditionTrue,
+			reason:          "Recreating",
+			keepsTransition: true,
+		},
+		{
+			name:   "status flips",
+			status: corev1.ConditionFalse,
+			reason: "UpToDate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			obj := &v1alpha1.Buildkit{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+			obj.SetConditions(api.Condition{
+				Type:               v1alpha1.TypeTemplateOutOfDate,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: earlier,
+				Reason:             "WaitingForIdle",
+			})
+
+			setCondition(obj, v1alpha1.TypeTemplateOutOfDate, tt.status, tt.reason, "message")
+
+			condition := obj.GetCondition(v1alpha1.TypeTemplateOutOfDate)
+			assert.Equal(t, tt.status, condition.Status)
+			assert.Equal(t, tt.reason, condition.Reason)
+			assert.Equal(t, "message", condition.Message)
+			assert.Equal(t, int64(2), condition.ObservedGeneration)
+			assert.Equal(t, tt.keepsTransition, condition.LastTransitionTime.Equal(&earlier))
+		})
+	}
+}
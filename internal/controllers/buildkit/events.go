@@ -0,0 +1,32 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// recordEndpointChange records an Event when the endpoint of a Buildkit instance becomes available or stops being available.
+func (r *reconciler) recordEndpointChange(obj *v1alpha1.Buildkit, previous string) {
+	current := obj.Status.Endpoint
+	if current == previous {
+		return
+	}
+
+	if current != "" {
+		r.recorder.Eventf(obj, corev1.EventTypeNormal, v1alpha1.EventReasonEndpointReady, "Buildkit is ready at %s", current)
+		return
+	}
+
+	// Losing the endpoint is expected when the instance was suspended on purpose
+	eventType := corev1.EventTypeWarning
+	if obj.Spec.Suspended {
+		eventType = corev1.EventTypeNormal
+	}
+
+	r.recorder.Eventf(obj, eventType, v1alpha1.EventReasonEndpointLost, "Buildkit endpoint %s is no longer available", previous)
+}
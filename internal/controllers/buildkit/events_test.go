@@ -0,0 +1,76 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestRecordEndpointChange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		previous  string
+		current   string
+		suspended bool
+		want      []string
+	}{
+		{
+			name:     "unchanged",
+			previous: "tcp://10.0.0.1:1234",
+			current:  "tcp://10.0.0.1:1234",
+		},
+		{
+			name:    "becomes ready",
+			current: "tcp://10.0.0.1:1234",
+			want:    []string{"Normal EndpointReady Buildkit is ready at tcp://10.0.0.1:1234"},
+		},
+		{
+			name:     "moves to a new pod",
+			previous: "tcp://10.0.0.1:1234",
+			current:  "tcp://10.0.0.2:1234",
+			want:     []string{"Normal EndpointReady Buildkit is ready at tcp://10.0.0.2:1234"},
+		},
+		{
+			name:     "is lost",
+			previous: "tcp://10.0.0.1:1234",
+			want:     []string{"Warning EndpointLost Buildkit endpoint tcp://10.0.0.1:1234 is no longer available"},
+		},
+		{
+			name:      "is lost on purpose when suspended",
+			previous:  "tcp://10.0.0.1:1234",
+			suspended: true,
+			want:      []string{"Normal EndpointLost Buildkit endpoint tcp://10.0.0.1:1234 is no longer available"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := record.NewFakeRecorder(len(tt.want) + 1)
+			r := &reconciler{recorder: recorder}
+
+			obj := &v1alpha1.Buildkit{
+				Spec:   v1alpha1.BuildkitSpec{Suspended: tt.suspended},
+				Status: v1alpha1.BuildkitStatus{Endpoint: tt.current},
+			}
+			r.recordEndpointChange(obj, tt.previous)
+			close(recorder.Events)
+
+			var got []string
+			for event := range recorder.Events {
+				got = append(got, event)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
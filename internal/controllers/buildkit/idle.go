@@ -0,0 +1,104 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// idleProbeInterval is how often an instance is probed for running builds: often enough to notice idleness within
+// a fraction of the idle timeout, but no more than once a second and no less than once a minute.
+func idleProbeInterval(timeout time.Duration) time.Duration {
+	return max(min(timeout/4, time.Minute), time.Second)
+}
+
+// checkIdle probes the Buildkit pod for running builds and records when it was last seen active.
+// It reports whether the instance has been idle for the template's idleTimeout, and otherwise how long to wait
+// before probing again. Instances that can't be probed are never considered idle.
+func (r *reconciler) checkIdle(ctx context.Context, obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, pod *corev1.Pod, log *zap.SugaredLogger) (bool, time.Duration) {
+	timeout := template.Spec.Lifecycle.IdleTimeout.Duration
+	interval := idleProbeInterval(timeout)
+	now := time.Now()
+
+	active, err := r.podActive(ctx, obj, pod)
+	if err != nil {
+		log.Warnw("Failed to check Buildkit for running builds", "error", err)
+		return false, interval
+	}
+
+	// Only move lastActiveTime forward once per probe interval, since every status change triggers another reconcile
+	last := obj.Status.LastActiveTime
+	if active && (last == nil || now.Sub(last.Time) >= interval) {
+		obj.Status.LastActiveTime = &metav1.Time{Time: now}
+	}
+
+	// New and resumed pods get a full idleTimeout before they're considered idle
+	idleSince := pod.CreationTimestamp.Time
+	if last := obj.Status.LastActiveTime; last != nil && last.After(idleSince) {
+		idleSince = last.Time
+	}
+
+	if active {
+		return false, interval
+	}
+
+	if remaining := timeout - now.Sub(idleSince); remaining > 0 {
+		return false, min(remaining, interval)
+	}
+
+	return true, 0
+}
+
+// podActive probes a ready Buildkit pod for running builds.
+func (r *reconciler) podActive(ctx context.Context, obj *v1alpha1.Buildkit, pod *corev1.Pod) (bool, error) {
+	port, ok := containerPort(pod)
+	if !ok {
+		return false, fmt.Errorf("buildkit pod %s does not have containers with ports defined", pod.Name)
+	}
+
+	tlsConfig, err := r.clientTLSConfig(ctx, obj)
+	if err != nil {
+		return false, err
+	}
+
+	return r.prober.Active(ctx, net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))), tlsConfig)
+}
+
+// actOnIdle takes the template's idleAction on an instance that has been idle for too long.
+// Suspending only marks the instance as suspended; its pod is stopped on the next reconcile.
+func (r *reconciler) actOnIdle(ctx context.Context, obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, log *zap.SugaredLogger) error {
+	timeout := template.Spec.Lifecycle.IdleTimeout.Duration.String()
+
+	if template.Spec.Lifecycle.IdleAction == v1alpha1.IdleActionSuspend {
+		log.Infow("Suspending idle Buildkit instance", "idleTimeout", timeout)
+
+		// Patch a copy, since the response would otherwise overwrite the status we're about to write
+		suspended := obj.DeepCopy()
+		suspended.Spec.Suspended = true
+		if err := r.c.Patch(ctx, suspended, client.MergeFrom(obj)); err != nil {
+			return fmt.Errorf("failed to suspend idle Buildkit: %w", err)
+		}
+
+		return nil
+	}
+
+	log.Infow("Deleting idle Buildkit instance", "idleTimeout", timeout)
+	if err := r.c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete idle Buildkit: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit/resources"
+)
+
+// updatePod applies changes to the Buildkit's labels, annotations and resources to its existing pod.
+// Labels and annotations are patched onto the pod, while resources are resized in place where the cluster supports it.
+// It reports whether the pod has to be replaced to pick up the changes instead.
+func (r *reconciler) updatePod(ctx context.Context, obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, pod *corev1.Pod, log *zap.SugaredLogger) (bool, error) {
+	updated := pod.DeepCopy()
+	if syncSpecMetadata(updated, obj, template) {
+		log.Infow("Updating Buildkit pod labels and annotations", "pod", pod.Name)
+		if err := r.c.Patch(ctx, updated, client.MergeFrom(pod)); err != nil {
+			return false, fmt.Errorf("failed to update labels and annotations of pod '%s': %w", pod.Name, err)
+		}
+		*pod = *updated
+	}
+
+	// Resources also depend on the template, so pods started from an older version are left to the template's update strategy
+	if template == nil {
+		return false, nil
+	}
+
+	outOfDate, err := IsPodOutOfDate(pod, template)
+	if err != nil || outOfDate {
+		return false, err
+	}
+
+	return r.resizePod(ctx, obj, template, pod, log)
+}
+
+// resizePod brings the resources of the pod's buildkit container in line with the Buildkit's spec and template.
+// It reports whether the pod has to be replaced because the cluster can't resize it in place.
+func (r *reconciler) resizePod(ctx context.Context, obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, pod *corev1.Pod, log *zap.SugaredLogger) (bool, error) {
+	index := slices.IndexFunc(pod.Spec.Containers, func(container corev1.Container) bool {
+		return container.Name == buildkitContainerName
+	})
+	if index < 0 {
+		return false, nil
+	}
+
+	desired := resources.WithDefaultRequests(resources.WithMaximums(template.Spec.Resources.Maximum, template.Spec.Resources.Default, obj.Spec.Resources))
+	if equality.Semantic.DeepEqual(pod.Spec.Containers[index].Resources, desired) {
+		// The kubelet may still turn down a resize the API server accepted, such as when the node lacks the capacity
+		if resizeInfeasible(pod) {
+			log.Infow("Replacing Buildkit pod that can't be resized on its node", "pod", pod.Name)
+			return true, nil
+		}
+
+		return false, nil
+	}
+
+	resized := pod.DeepCopy()
+	resized.Spec.Containers[index].Resources = desired
+	err := r.c.SubResource("resize").Patch(ctx, resized, client.StrategicMergeFrom(pod))
+	switch {
+	case err == nil:
+		log.Infow("Resized Buildkit pod", "pod", pod.Name)
+		*pod = *resized
+		return false, nil
+	case apierrors.IsNotFound(err), apierrors.IsMethodNotSupported(err), apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		// Clusters without in-place pod resizing don't serve the subresource, and some changes can't be made in place
+		log.Infow("Replacing Buildkit pod that can't be resized in place", "pod", pod.Name, "reason", err.Error())
+		return true, nil
+	default:
+		return false, fmt.Errorf("failed to resize pod '%s': %w", pod.Name, err)
+	}
+}
+
+// resizeInfeasible reports whether the kubelet has rejected the pod's most recent resize.
+func resizeInfeasible(pod *corev1.Pod) bool {
+	return slices.ContainsFunc(pod.Status.Conditions, func(condition corev1.PodCondition) bool {
+		return condition.Type == corev1.PodResizePending && condition.Reason == corev1.PodReasonInfeasible
+	})
+}
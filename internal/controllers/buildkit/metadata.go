@@ -0,0 +1,96 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/merge"
+)
+
+// Pods record which of their labels and annotations were copied from the Buildkit's spec, so that keys removed
+// from the spec can also be removed from the pod without touching metadata added by anything else.
+const (
+	annotationSpecLabels      = "buildkit.seatgeek.io/spec-labels"
+	annotationSpecAnnotations = "buildkit.seatgeek.io/spec-annotations"
+)
+
+// defaultPodLabels returns the labels every Buildkit pod starts with, unless the Buildkit's spec overrides them.
+func defaultPodLabels() map[string]string {
+	return map[string]string{"app.kubernetes.io/name": "buildkit"}
+}
+
+// syncSpecMetadata brings the labels and annotations that a pod got from the Buildkit's spec in line with the spec.
+// Metadata set by the template or the operator takes precedence over the spec and is left alone.
+// It reports whether the pod was changed.
+func syncSpecMetadata(pod *corev1.Pod, obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) bool {
+	var templateLabels, templateAnnotations map[string]string
+	if template != nil {
+		templateLabels, templateAnnotations = template.Spec.PodLabels, template.Spec.PodAnnotations
+	}
+
+	labelReserved := func(key string) bool {
+		_, fromTemplate := templateLabels[key]
+		return fromTemplate || key == v1alpha1.InstanceLabel || key == v1alpha1.PoolLabel
+	}
+	annotationReserved := func(key string) bool {
+		_, fromTemplate := templateAnnotations[key]
+		return fromTemplate || strings.HasPrefix(key, v1alpha1.SchemeGroupVersion.Group+"/")
+	}
+
+	labels, labelKeys := syncSpecKeys(pod.Labels, pod.Annotations[annotationSpecLabels], obj.Spec.Labels, defaultPodLabels(), labelReserved)
+	annotations, annotationKeys := syncSpecKeys(pod.Annotations, pod.Annotations[annotationSpecAnnotations], obj.Spec.Annotations, nil, annotationReserved)
+	setOrDelete(annotations, annotationSpecLabels, labelKeys)
+	setOrDelete(annotations, annotationSpecAnnotations, annotationKeys)
+
+	changed := !maps.Equal(pod.Labels, labels) || !maps.Equal(pod.Annotations, annotations)
+	pod.Labels, pod.Annotations = labels, annotations
+
+	return changed
+}
+
+// syncSpecKeys returns a copy of current with the desired entries set, and with the previously copied keys that are no
+// longer desired reverted to their defaults or removed. Reserved keys are never touched.
+// It also returns the comma-separated list of keys that are now copied from the spec.
+func syncSpecKeys(current map[string]string, previous string, desired, defaults map[string]string, reserved func(string) bool) (map[string]string, string) {
+	result := merge.Maps(current)
+
+	for key := range strings.SplitSeq(previous, ",") {
+		if _, ok := desired[key]; ok || key == "" || reserved(key) {
+			continue
+		}
+
+		if value, ok := defaults[key]; ok {
+			result[key] = value
+		} else {
+			delete(result, key)
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key, value := range desired {
+		if !reserved(key) {
+			result[key] = value
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	return result, strings.Join(keys, ",")
+}
+
+// setOrDelete sets the key to value, or removes it if the value is empty.
+func setOrDelete(m map[string]string, key, value string) {
+	if value == "" {
+		delete(m, key)
+	} else {
+		m[key] = value
+	}
+}
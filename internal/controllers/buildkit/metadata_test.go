@@ -0,0 +1,105 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestSyncSpecMetadata(t *testing.T) {
+	t.Parallel()
+
+	template := &v1alpha1.BuildkitTemplate{
+		Spec: v1alpha1.BuildkitTemplateSpec{
+			PodLabels:      map[string]string{"team": "platform"},
+			PodAnnotations: map[string]string{"owner": "template"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		labels          map[string]string
+		annotations     map[string]string
+		spec            v1alpha1.BuildkitSpec
+		wantChanged     bool
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "already in sync",
+			labels:          map[string]string{"app": "ci", v1alpha1.InstanceLabel: "test"},
+			annotations:     map[string]string{"note": "hi", annotationSpecLabels: "app", annotationSpecAnnotations: "note"},
+			spec:            v1alpha1.BuildkitSpec{Labels: map[string]string{"app": "ci"}, Annotations: map[string]string{"note": "hi"}},
+			wantLabels:      map[string]string{"app": "ci", v1alpha1.InstanceLabel: "test"},
+			wantAnnotations: map[string]string{"note": "hi", annotationSpecLabels: "app", annotationSpecAnnotations: "note"},
+		},
+		{
+			name:            "adds and updates keys",
+			labels:          map[string]string{"app": "ci"},
+			annotations:     map[string]string{annotationSpecLabels: "app"},
+			spec:            v1alpha1.BuildkitSpec{Labels: map[string]string{"app": "cd", "env": "prod"}, Annotations: map[string]string{"note": "hi"}},
+			wantChanged:     true,
+			wantLabels:      map[string]string{"app": "cd", "env": "prod"},
+			wantAnnotations: map[string]string{"note": "hi", annotationSpecLabels: "app,env", annotationSpecAnnotations: "note"},
+		},
+		{
+			name:            "removes keys dropped from the spec but leaves others alone",
+			labels:          map[string]string{"app": "ci", "added-by-someone-else": "yes"},
+			annotations:     map[string]string{"note": "hi", "other": "kept", annotationSpecLabels: "app", annotationSpecAnnotations: "note"},
+			wantChanged:     true,
+			wantLabels:      map[string]string{"added-by-someone-else": "yes"},
+			wantAnnotations: map[string]string{"other": "kept"},
+		},
+		{
+			name:            "restores default labels the spec no longer overrides",
+			labels:          map[string]string{"app.kubernetes.io/name": "custom"},
+			annotations:     map[string]string{annotationSpecLabels: "app.kubernetes.io/name"},
+			wantChanged:     true,
+			wantLabels:      map[string]string{"app.kubernetes.io/name": "buildkit"},
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name:   "leaves template and operator metadata alone",
+			labels: map[string]string{"team": "platform", v1alpha1.InstanceLabel: "test"},
+			annotations: map[string]string{
+				"owner":                         "template",
+				v1alpha1.TemplateHashAnnotation: "abc",
+			},
+			spec: v1alpha1.BuildkitSpec{
+				Labels:      map[string]string{"team": "other", v1alpha1.InstanceLabel: "other"},
+				Annotations: map[string]string{"owner": "spec", v1alpha1.TemplateHashAnnotation: "def"},
+			},
+			wantLabels: map[string]string{"team": "platform", v1alpha1.InstanceLabel: "test"},
+			wantAnnotations: map[string]string{
+				"owner":                         "template",
+				v1alpha1.TemplateHashAnnotation: "abc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      tt.labels,
+					Annotations: tt.annotations,
+				},
+			}
+
+			changed := syncSpecMetadata(pod, &v1alpha1.Buildkit{Spec: tt.spec}, template)
+			assert.Equal(t, tt.wantChanged, changed)
+			assert.Equal(t, tt.wantLabels, pod.Labels)
+			assert.Equal(t, tt.wantAnnotations, pod.Annotations)
+		})
+	}
+}
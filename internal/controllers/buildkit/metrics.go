@@ -0,0 +1,65 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit/resources"
+)
+
+// templateLabel returns the name of the instance's template for use as a metric label.
+// It falls back to the referenced name while the template can't be loaded, which leaves it empty for pools.
+func templateLabel(obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) string {
+	if template != nil {
+		return template.Name
+	}
+
+	if ref := obj.Spec.DirectTemplateRef(); ref != nil {
+		return ref.Name
+	}
+
+	return ""
+}
+
+// recordInstance updates the instance gauges with the state the Buildkit was left in by a reconcile.
+func (r *reconciler) recordInstance(obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) {
+	r.metrics.RecordInstance(client.ObjectKeyFromObject(obj), templateLabel(obj, template), string(obj.Status.Phase), isClamped(obj, template))
+}
+
+// isClamped reports whether some of the instance's requests or limits are over its template's maximums, just like its
+// ResourcesClamped condition. Limits that are only filled in from the maximums because they're missing don't count.
+func isClamped(obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) bool {
+	return template != nil && len(resources.Exceeding(template.Spec.Resources.Maximum, template.Spec.Resources.Default, obj.Spec.Resources)) > 0
+}
+
+// observeTimeToReady records how long the instance's ready pod took to become ready, counting from when the instance
+// was created for pods claimed from a pool.
+func (r *reconciler) observeTimeToReady(obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, pod *corev1.Pod) {
+	readyAt := time.Now()
+	if readyTime := podReadyTime(pod); readyTime != nil {
+		readyAt = readyTime.Time
+	}
+
+	startedAt := pod.CreationTimestamp.Time
+	if obj.CreationTimestamp.After(startedAt) {
+		startedAt = obj.CreationTimestamp.Time
+	}
+
+	r.metrics.ObserveTimeToReady(client.ObjectKeyFromObject(obj), pod.Name, templateLabel(obj, template), readyAt.Sub(startedAt))
+}
+
+// forgetInstance drops deleted Buildkits from the instance gauges.
+func (r *reconciler) forgetInstance(_ context.Context, e event.TypedDeleteEvent[client.Object], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	r.metrics.ForgetInstance(client.ObjectKeyFromObject(e.Object))
+}
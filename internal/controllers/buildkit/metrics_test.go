@@ -0,0 +1,81 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestIsClamped(t *testing.T) {
+	t.Parallel()
+
+	template := &v1alpha1.BuildkitTemplate{
+		Spec: v1alpha1.BuildkitTemplateSpec{
+			Resources: v1alpha1.BuildkitTemplateResources{
+				Maximum: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		template  *v1alpha1.BuildkitTemplate
+		resources corev1.ResourceRequirements
+		want      bool
+	}{
+		{
+			name:     "no template",
+			template: nil,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi")},
+			},
+		},
+		{
+			name:     "no limits set, within maximums",
+			template: template,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+			},
+		},
+		{
+			name:     "limit within maximums",
+			template: template,
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+		},
+		{
+			name:     "request over maximums",
+			template: template,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Gi")},
+			},
+			want: true,
+		},
+		{
+			name:     "limit over maximums",
+			template: template,
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Gi")},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			obj := &v1alpha1.Buildkit{Spec: v1alpha1.BuildkitSpec{Resources: tt.resources}}
+			assert.Equal(t, tt.want, isClamped(obj, tt.template))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/merge"
+)
+
+// IsPodReady reports whether the pod is running, not being deleted, and all of its containers are ready.
+func IsPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PoolIncompatibility explains why pods from the template can't be started ahead of time for a pool,
+// or returns an empty string if they can.
+func PoolIncompatibility(template *v1alpha1.BuildkitTemplate) string {
+	switch {
+	case template.Spec.TLS != nil:
+		return "TLS requires certificates issued for each Buildkit instance"
+	case storageType(template) == v1alpha1.StorageTypePersistentVolumeClaim:
+		return "PersistentVolumeClaim storage requires a volume for each Buildkit instance"
+	default:
+		return ""
+	}
+}
+
+// claimWarmPod takes over a ready, unclaimed pod from the Buildkit instance's pool.
+// The pod is labelled for the instance and its controller reference moves from the pool to the Buildkit,
+// which also prompts the pool to start a replacement.
+// It returns nil if the instance doesn't use a pool or no warm pod could be claimed.
+func (r *reconciler) claimWarmPod(ctx context.Context, obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, log *zap.SugaredLogger) (*corev1.Pod, error) {
+	// Warm pods can't have been started with anything specific to this instance
+	if obj.Spec.Pool == "" || template == nil || PoolIncompatibility(template) != "" {
+		return nil, nil
+	}
+
+	inPool, err := labels.NewRequirement(v1alpha1.PoolLabel, selection.Equals, []string{obj.Spec.Pool})
+	if err != nil {
+		return nil, err
+	}
+	unclaimed, err := labels.NewRequirement(v1alpha1.InstanceLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods corev1.PodList
+	if err := r.c.List(ctx, &pods, client.InNamespace(obj.Namespace), client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*inPool, *unclaimed)}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of BuildkitPool '%s': %w", obj.Spec.Pool, err)
+	}
+
+	// Prefer the oldest pods so that the pool turns over evenly
+	slices.SortFunc(pods.Items, func(a, b corev1.Pod) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	for _, pod := range pods.Items {
+		if !IsPodReady(&pod) {
+			continue
+		}
+
+		claimed := pod.DeepCopy()
+		claimed.Labels = merge.Maps(pod.Labels, map[string]string{v1alpha1.InstanceLabel: obj.Name})
+		syncSpecMetadata(claimed, obj, template)
+		claimed.OwnerReferences = slices.DeleteFunc(claimed.OwnerReferences, func(ref metav1.OwnerReference) bool {
+			return ref.Controller != nil && *ref.Controller
+		})
+		if err := controllerutil.SetControllerReference(obj, claimed, r.scheme); err != nil {
+			return nil, fmt.Errorf("failed to set controller reference on pod '%s': %w", pod.Name, err)
+		}
+
+		// The optimistic lock ensures that two instances racing for the same pod can't both claim it
+		if err := r.c.Patch(ctx, claimed, client.MergeFromWithOptions(&pod, client.MergeFromWithOptimisticLock{})); err != nil {
+			if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+				log.Debugw("Warm pod was claimed or removed concurrently, trying the next one", "pod", pod.Name)
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to claim pod '%s' from BuildkitPool '%s': %w", pod.Name, obj.Spec.Pool, err)
+		}
+
+		log.Infow("Claimed warm pod from pool", "pod", claimed.Name, "pool", obj.Spec.Pool)
+		return claimed, nil
+	}
+
+	log.Infow("No warm pods available in pool, starting a new pod", "pool", obj.Spec.Pool)
+	return nil, nil
+}
+
+// getClaimedPods returns the pods this Buildkit instance claimed from its pool.
+// Claimed pods were never applied by this controller, so they're found by label and controller reference instead.
+func (r *reconciler) getClaimedPods(ctx context.Context, obj *v1alpha1.Buildkit) ([]corev1.Pod, error) {
+	if obj.Spec.Pool == "" {
+		return nil, nil
+	}
+
+	var pods corev1.PodList
+	if err := r.c.List(ctx, &pods, client.InNamespace(obj.Namespace), client.MatchingLabels{v1alpha1.InstanceLabel: obj.Name, v1alpha1.PoolLabel: obj.Spec.Pool}); err != nil {
+		return nil, fmt.Errorf("failed to list claimed pods: %w", err)
+	}
+
+	return slices.DeleteFunc(pods.Items, func(pod corev1.Pod) bool {
+		return !metav1.IsControlledBy(&pod, obj)
+	}), nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
+)
+
+// queueRecheckInterval is how often a queued instance checks for a free slot, in case it missed the event of one
+// being released.
+const queueRecheckInterval = 30 * time.Second
+
+// checkQueue finds where a Buildkit without a pod stands in the queue for its template's maxInstances.
+// It returns zero once the instance may start, after clearing its queued state.
+func (r *reconciler) checkQueue(ctx context.Context, obj *v1alpha1.Buildkit, builder *Builder, template *v1alpha1.BuildkitTemplate) (int32, error) {
+	ref := v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: template.Name}
+	if _, ok := builder.templateOwner.(*v1alpha1.ClusterBuildkitTemplate); ok {
+		ref.Kind = v1alpha1.TemplateKindClusterBuildkitTemplate
+	}
+
+	instances, err := buildkit_template.Instances(ctx, r.c.Client, ref, obj.Namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	position := buildkit_template.QueuePosition(obj, instances, *template.Spec.MaxInstances)
+	if position == 0 {
+		leaveQueue(obj)
+	}
+
+	return position, nil
+}
+
+// waitInQueue marks a Buildkit as queued at the given position, until a slot frees up under its template's maxInstances.
+func (r *reconciler) waitInQueue(obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, position int32, log *zap.SugaredLogger) (*state, types.Result) {
+	message := fmt.Sprintf("Waiting for one of the %d instances allowed by template %s to go away; position %d in queue", *template.Spec.MaxInstances, template.Name, position)
+	if !buildkit_template.IsQueued(obj) {
+		log.Infow("Queueing Buildkit instance", "position", position, "maxInstances", *template.Spec.MaxInstances)
+		r.recorder.Event(obj, corev1.EventTypeNormal, v1alpha1.EventReasonQueued, message)
+	}
+
+	setQueued(obj, corev1.ConditionTrue, "MaxInstancesReached", message)
+	obj.Status.QueuePosition = position
+	obj.Status.Phase = v1alpha1.BuildkitPhaseQueued
+
+	return nil, types.RequeueResultWithReason(message, "Queued", queueRecheckInterval)
+}
+
+// leaveQueue clears the queued state of a Buildkit that may now start.
+func leaveQueue(obj *v1alpha1.Buildkit) {
+	if !buildkit_template.IsQueued(obj) {
+		return
+	}
+
+	setQueued(obj, corev1.ConditionFalse, "SlotAvailable", "Buildkit may start")
+	obj.Status.QueuePosition = 0
+	if obj.Status.Phase == v1alpha1.BuildkitPhaseQueued {
+		obj.Status.Phase = v1alpha1.BuildkitPhasePending
+	}
+}
+
+func setQueued(obj *v1alpha1.Buildkit, status corev1.ConditionStatus, reason api.ConditionReason, message string) {
+	setCondition(obj, v1alpha1.TypeQueued, status, reason, message)
+}
+
+// instanceUpdated wakes up queued Buildkits when another one gives up its pod, such as when it's suspended.
+func (r *reconciler) instanceUpdated(ctx context.Context, e event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldBk, okOld := e.ObjectOld.(*v1alpha1.Buildkit)
+	newBk, okNew := e.ObjectNew.(*v1alpha1.Buildkit)
+	if okOld && okNew && buildkit_template.HasPod(oldBk) && !buildkit_template.HasPod(newBk) {
+		r.enqueueQueued(ctx, q)
+	}
+}
+
+// instanceDeleted forgets deleted Buildkits and wakes up queued ones, which may take over the slot it held.
+func (r *reconciler) instanceDeleted(ctx context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	r.forgetInstance(ctx, e, q)
+	r.enqueueQueued(ctx, q)
+}
+
+// enqueueQueued enqueues all queued Buildkits, so they can check whether a slot has been released for them.
+func (r *reconciler) enqueueQueued(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	var buildkits v1alpha1.BuildkitList
+	if err := r.c.List(ctx, &buildkits); err != nil {
+		r.log.Errorw("Failed to list Buildkits to wake up queued instances", "error", err)
+		return
+	}
+
+	for _, bk := range buildkits.Items {
+		if buildkit_template.IsQueued(&bk) {
+			q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&bk)})
+		}
+	}
+}
@@ -5,11 +5,14 @@
 package buildkit
 
 import (
-	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/reddit/achilles-sdk/pkg/fsm"
 	"github.com/reddit/achilles-sdk/pkg/fsm/types"
@@ -19,27 +22,47 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
 	"github.com/seatgeek/buildkit-operator/internal/controlplane"
+	"github.com/seatgeek/buildkit-operator/internal/merge"
+	buildkitmetrics "github.com/seatgeek/buildkit-operator/internal/metrics"
 )
 
 //+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkits,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkits/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkits/finalizers,verbs=update
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitpools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkittemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=clusterbuildkittemplates,verbs=get;list;watch
 //+kubebuilder:rbac:resources=pods,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=pods/resize,verbs=patch
+//+kubebuilder:rbac:resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=events,verbs=create;patch
+
+// The Buildkit webhook reads namespaces to find their default BuildkitTemplate
+//+kubebuilder:rbac:resources=namespaces,verbs=get
 
 const controllerName = "Buildkit"
 
 type state = types.State[*v1alpha1.Buildkit]
 
 type reconciler struct {
-	c      *io.ClientApplicator
-	scheme *runtime.Scheme
-	log    *zap.SugaredLogger
+	c        *io.ClientApplicator
+	scheme   *runtime.Scheme
+	log      *zap.SugaredLogger
+	prober   ActivityProber
+	metrics  *buildkitmetrics.Metrics
+	recorder record.EventRecorder
 }
 
 func (r *reconciler) runBuildkit() *state {
@@ -48,6 +71,77 @@ func (r *reconciler) runBuildkit() *state {
 		Condition: conditionDeployed,
 		Transition: func(ctx context.Context, obj *v1alpha1.Buildkit, out *types.OutputSet) (*state, types.Result) {
 			log := r.log.With("name", obj.Name, "namespace", obj.Namespace)
+			builder := NewBuilder(obj, r.c.Client)
+			// The pod stays nil when returning before it's known, which leaves its details in the status as they were
+			var pod *corev1.Pod
+			previousEndpoint := obj.Status.Endpoint
+			defer func() {
+				obj.Status.ObservedGeneration = obj.Generation
+				if pod != nil {
+					syncPodStatus(obj, pod)
+				} else if obj.Status.Phase == "" {
+					obj.Status.Phase = v1alpha1.BuildkitPhasePending
+				}
+				r.recordInstance(obj, builder.template)
+				r.recordEndpointChange(obj, previousEndpoint)
+			}()
+
+			// Load the template, tolerating its absence so that existing pods keep being tracked
+			template, err := builder.Template(ctx)
+			switch {
+			case apierrors.IsNotFound(err):
+				r.markTemplateMissing(obj, err)
+			case err != nil:
+				return nil, types.ErrorResult(fmt.Errorf("failed to get BuildkitTemplate: %w", err))
+			default:
+				clearTemplateMissing(obj)
+				r.recordResources(obj, template)
+			}
+
+			// Suspended instances keep everything but their pod
+			if obj.Spec.Suspended {
+				return r.stopSuspended(ctx, obj, out, log)
+			}
+
+			// Instances beyond the template's maxInstances wait for a slot before getting a pod
+			if template != nil && template.Spec.MaxInstances != nil && !buildkit_template.HasPod(obj) {
+				position, err := r.checkQueue(ctx, obj, builder, template)
+				if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+
+				if position > 0 {
+					return r.waitInQueue(obj, template, position, log)
+				}
+			} else {
+				leaveQueue(obj)
+			}
+
+			// Issue or rotate TLS certificates if the template enables TLS
+			podAnnotations := map[string]string{}
+			if template != nil {
+				serverCertificate, err := r.ensureTLSSecrets(ctx, obj, builder, template, out, log)
+				if errors.Is(err, errCANotReady) {
+					log.Debugw("Waiting for certificate authority", "error", err)
+					return nil, types.RequeueResultWithReasonAndBackoff("Waiting for certificate authority", "CertificateAuthorityNotReady")
+				} else if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+
+				if serverCertificate != "" {
+					podAnnotations[annotationServerCertificate] = serverCertificate
+				}
+			}
+
+			// Make sure the build cache volume exists, and is free to use when it's shared by cache key
+			if template != nil {
+				err := r.ensureCacheVolume(ctx, obj, builder, template, out, log)
+				if errors.Is(err, errCacheInUse) {
+					return nil, types.RequeueResultWithReasonAndBackoff("Waiting for build cache to be released", "CacheInUse")
+				} else if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+			}
 
 			// Check if we already have any Buildkit pods
 			managedPods, err := r.getExistingManagedPods(ctx, obj, log)
@@ -55,18 +149,70 @@ func (r *reconciler) runBuildkit() *state {
 				return nil, types.ErrorResult(err)
 			}
 
+			// Instances backed by a pool take over one of its warm pods rather than starting their own
+			if len(managedPods) == 0 {
+				claimed, err := r.claimWarmPod(ctx, obj, template, log)
+				if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+
+				if claimed != nil {
+					managedPods = append(managedPods, *claimed)
+				}
+			}
+
+			// Without its template, a Buildkit that has lost its pod can't get a new one
+			if len(managedPods) == 0 && template == nil {
+				return nil, types.RequeueResultWithReasonAndBackoff(obj.GetCondition(v1alpha1.TypeTemplateMissing).Message, "TemplateMissing")
+			}
+
 			// Ensure we have exactly one Buildkit pod running, creating or deleting as necessary
-			pod, err := r.ensureExactlyOnePod(ctx, obj, managedPods, out, log)
+			pod, err = r.ensureExactlyOnePod(ctx, obj, builder, managedPods, podAnnotations, out, log)
 			if err != nil {
 				return nil, types.ErrorResult(err)
 			}
 
+			// Give the pod a stable address
+			if err := r.ensureService(ctx, obj, builder, pod, out, log); err != nil {
+				return nil, types.ErrorResult(err)
+			}
+
+			// buildkitd only loads its certificates at startup, so replace pods that are still serving a rotated certificate
+			current, started := podAnnotations[annotationServerCertificate], pod.Annotations[annotationServerCertificate]
+			if current != "" && started != "" && current != started && out.GetApplied().Len() == 0 {
+				log.Infow("Replacing Buildkit pod to pick up rotated TLS certificate", "pod", pod.Name)
+				r.metrics.RecordReplacement(obj.Namespace, templateLabel(obj, template), buildkitmetrics.ReplacementCertificateRotated)
+				out.Delete(pod)
+			}
+
+			// Roll out template changes to pods that were started before them, as the template's update strategy allows
+			var rolloutRecheckAfter time.Duration
+			if template != nil && pod.Name != "" && out.GetDeleted().Len() == 0 {
+				rolloutRecheckAfter, err = r.rolloutTemplate(ctx, obj, template, pod, out, log)
+				if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+			}
+
+			// Apply changes to the instance's labels, annotations and resources to its existing pod
+			if pod.Name != "" && out.GetDeleted().Len() == 0 {
+				replace, err := r.updatePod(ctx, obj, template, pod, log)
+				if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+
+				if replace {
+					r.metrics.RecordReplacement(obj.Namespace, templateLabel(obj, template), buildkitmetrics.ReplacementResizeNotPossible)
+					out.Delete(pod)
+				}
+			}
+
 			// Do we need to add or remove anything? If so, apply those changes now and requeue.
 			if out.GetApplied().Len() > 0 || out.GetDeleted().Len() > 0 {
 				return nil, types.Result{
 					Done:                   true,
 					RequeueAfterCompletion: true,
-					RequeueMsg:             "Applying pod changes",
+					RequeueMsg:             "Applying changes",
 					Reason:                 "ApplyingChanges",
 				}
 			}
@@ -77,15 +223,7 @@ func (r *reconciler) runBuildkit() *state {
 
 			// Are all containers running and healthy?
 			if pod.Status.Phase == corev1.PodFailed {
-				log.Warnw("Buildkit pod has failed", "pod", pod.Name, "reason", pod.Status.Reason, "message", pod.Status.Message)
-				return nil, types.Result{
-					Done: true,
-					CustomStatusCondition: &types.ResultStatusCondition{
-						Reason:  "PodFailed",
-						Status:  corev1.ConditionFalse,
-						Message: fmt.Sprintf("Buildkit pod %s has failed: %s", pod.Name, cmp.Or(pod.Status.Message, pod.Status.Reason, "unknown failure")),
-					},
-				}
+				return r.recoverFailedPod(obj, template, pod, out, log)
 			}
 
 			if pod.Status.Phase != corev1.PodRunning {
@@ -101,18 +239,95 @@ func (r *reconciler) runBuildkit() *state {
 			}
 
 			// If we reach here, the pod is running and all containers are ready!
-			if len(pod.Spec.Containers) == 0 || len(pod.Spec.Containers[0].Ports) == 0 {
+			port, ok := containerPort(pod)
+			if !ok {
 				log.Errorw("Buildkit pod does not have containers with ports defined", "pod", pod.Name)
 				return nil, types.ErrorResult(fmt.Errorf("buildkit pod %s does not have containers with ports defined", pod.Name))
 			}
-			obj.Status.Endpoint = fmt.Sprintf("tcp://%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(pod.Spec.Containers[0].Ports[0].ContainerPort))))
+
+			// Publish the Service address if the template calls for it and the instance has a Service of its own;
+			// otherwise fall back to the pod IP. A missing template keeps the default so that existing pods stay reachable.
+			if publishesService(template) && obj.Status.ServiceName != "" {
+				obj.Status.Endpoint = serviceEndpoint(obj, port)
+			} else {
+				obj.Status.Endpoint = fmt.Sprintf("tcp://%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))))
+			}
+			r.observeTimeToReady(obj, template, pod)
+
+			// A pod that runs again after a failure, such as when the failed pod was deleted by hand, clears the failure
+			if obj.GetCondition(v1alpha1.TypeFailed).Status == corev1.ConditionTrue {
+				setCondition(obj, v1alpha1.TypeFailed, corev1.ConditionFalse, "Recovered", fmt.Sprintf("Buildkit pod %s is running", pod.Name))
+			}
+
+			// Keep checking for running builds if the template lets idle instances go away
+			requeueAfter := rolloutRecheckAfter
+			if template != nil && template.Spec.Lifecycle.IdleTimeout != nil {
+				idle, probeAfter := r.checkIdle(ctx, obj, template, pod, log)
+				if idle {
+					if err := r.actOnIdle(ctx, obj, template, log); err != nil {
+						return nil, types.ErrorResult(err)
+					}
+
+					return nil, types.DoneResult()
+				}
+
+				if requeueAfter == 0 || probeAfter < requeueAfter {
+					requeueAfter = probeAfter
+				}
+			}
+
+			if requeueAfter > 0 {
+				return nil, types.Result{
+					Done:                   true,
+					RequeueAfterCompletion: true,
+					RequeueAfter:           requeueAfter,
+					RequeueMsg:             "Checking for running builds",
+					Reason:                 "CheckingActivity",
+				}
+			}
 
 			return nil, types.DoneResult()
 		},
 	}
 }
 
-// getExistingManagedPods retrieves all pods that are tracked as resources managed by the Buildkit instance.
+// stopSuspended deletes the pod of a suspended Buildkit instance, leaving its other resources in place.
+func (r *reconciler) stopSuspended(ctx context.Context, obj *v1alpha1.Buildkit, out *types.OutputSet, log *zap.SugaredLogger) (*state, types.Result) {
+	managedPods, err := r.getExistingManagedPods(ctx, obj, log)
+	if err != nil {
+		return nil, types.ErrorResult(err)
+	}
+
+	obj.Status.Endpoint = ""
+	leaveQueue(obj)
+	syncPodStatus(obj, nil)
+
+	if len(managedPods) > 0 {
+		log.Infow("Stopping suspended Buildkit instance", "pods", len(managedPods))
+		for _, pod := range managedPods {
+			out.Delete(&pod)
+		}
+
+		return nil, types.Result{
+			Done:                   true,
+			RequeueAfterCompletion: true,
+			RequeueMsg:             "Applying changes",
+			Reason:                 "ApplyingChanges",
+		}
+	}
+
+	return nil, types.Result{
+		Done: true,
+		CustomStatusCondition: &types.ResultStatusCondition{
+			Reason:  "Suspended",
+			Status:  corev1.ConditionFalse,
+			Message: "Buildkit is suspended; clear spec.suspended to start it again",
+		},
+	}
+}
+
+// getExistingManagedPods retrieves all pods that are tracked as resources managed by the Buildkit instance,
+// along with any pod it claimed from a pool.
 func (r *reconciler) getExistingManagedPods(ctx context.Context, obj *v1alpha1.Buildkit, log *zap.SugaredLogger) ([]corev1.Pod, error) {
 	existingPods := make([]corev1.Pod, 0, 1) // we expect at most one pod to be managed
 	for _, ref := range obj.Status.ResourceRefs {
@@ -134,6 +349,17 @@ func (r *reconciler) getExistingManagedPods(ctx context.Context, obj *v1alpha1.B
 		existingPods = append(existingPods, pod)
 	}
 
+	claimedPods, err := r.getClaimedPods(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range claimedPods {
+		if !slices.ContainsFunc(existingPods, func(existing corev1.Pod) bool { return existing.Name == pod.Name }) {
+			existingPods = append(existingPods, pod)
+		}
+	}
+
 	return existingPods, nil
 }
 
@@ -142,25 +368,31 @@ func (r *reconciler) getExistingManagedPods(ctx context.Context, obj *v1alpha1.B
 // If exactly one pod is found, it returns that pod.
 // If multiple pods are found, it enqueues the unexpected extras for deletion and returns the first one.
 // Note that we don't actually apply those changes here, we just update the OutputSet with the changes to be applied.
-func (r *reconciler) ensureExactlyOnePod(ctx context.Context, obj *v1alpha1.Buildkit, managedPods []corev1.Pod, out *types.OutputSet, log *zap.SugaredLogger) (*corev1.Pod, error) {
+// Any podAnnotations are added to newly created pods on top of those rendered from the template.
+func (r *reconciler) ensureExactlyOnePod(ctx context.Context, obj *v1alpha1.Buildkit, builder *Builder, managedPods []corev1.Pod, podAnnotations map[string]string, out *types.OutputSet, log *zap.SugaredLogger) (*corev1.Pod, error) {
 	if len(managedPods) > 1 {
 		log.Warnw("Multiple Buildkit pods found, deleting extras", "count", len(managedPods))
+		extras := make([]string, 0, len(managedPods)-1)
 		for _, pod := range managedPods[1:] {
+			extras = append(extras, pod.Name)
 			out.Delete(&pod)
 		}
+		r.recorder.Eventf(obj, corev1.EventTypeWarning, v1alpha1.EventReasonExtraPodsDeleted, "Deleting extra Buildkit pods: %s", strings.Join(extras, ", "))
 
 		return &managedPods[0], nil
 	}
 
 	if len(managedPods) == 0 {
 		// No pod running yet, so create one
-		pod, err := NewBuilder(obj, r.c.Client).BuildPod(ctx)
+		pod, err := builder.BuildPod(ctx)
 		if err != nil {
 			log.Errorw("Failed to generate Buildkit pod definition", "error", err)
 			return nil, fmt.Errorf("failed to build Buildkit pod: %w", err)
 		}
+		pod.Annotations = merge.Maps(pod.Annotations, podAnnotations)
 
 		log.Info("Starting Buildkit instance")
+		r.recorder.Event(obj, corev1.EventTypeNormal, v1alpha1.EventReasonPodCreated, "Creating Buildkit pod")
 		out.Apply(pod)
 
 		return pod, nil
@@ -169,6 +401,41 @@ func (r *reconciler) ensureExactlyOnePod(ctx context.Context, obj *v1alpha1.Buil
 	return &managedPods[0], nil
 }
 
+// buildkitsForTemplate maps a BuildkitTemplate to the Buildkits that use it, either directly or through a pool,
+// so that template changes get rolled out to them.
+func (r *reconciler) buildkitsForTemplate(ctx context.Context, template client.Object) []reconcile.Request {
+	ref := v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: template.GetName()}
+	instances, err := buildkit_template.Instances(ctx, r.c, ref, template.GetNamespace())
+	if err != nil {
+		r.log.Errorw("Failed to list Buildkits for BuildkitTemplate", "template", template.GetName(), "error", err)
+		return nil
+	}
+
+	return instanceRequests(instances)
+}
+
+// buildkitsForClusterTemplate maps a ClusterBuildkitTemplate to the Buildkits that use it across all namespaces,
+// so that template changes get rolled out to them.
+func (r *reconciler) buildkitsForClusterTemplate(ctx context.Context, template client.Object) []reconcile.Request {
+	ref := v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: template.GetName()}
+	instances, err := buildkit_template.Instances(ctx, r.c, ref, "")
+	if err != nil {
+		r.log.Errorw("Failed to list Buildkits for ClusterBuildkitTemplate", "template", template.GetName(), "error", err)
+		return nil
+	}
+
+	return instanceRequests(instances)
+}
+
+func instanceRequests(instances []v1alpha1.Buildkit) []reconcile.Request {
+	requests := make([]reconcile.Request, 0, len(instances))
+	for _, bk := range instances {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&bk)})
+	}
+
+	return requests
+}
+
 func SetupController(
 	ctx context.Context,
 	cpCtx controlplane.Context,
@@ -182,9 +449,12 @@ func SetupController(
 	}
 
 	r := &reconciler{
-		c:      c,
-		scheme: mgr.GetScheme(),
-		log:    log,
+		c:        c,
+		scheme:   mgr.GetScheme(),
+		log:      log,
+		prober:   ControlActivityProber{},
+		metrics:  cpCtx.BuildkitMetrics,
+		recorder: mgr.GetEventRecorderFor(controllerName),
 	}
 
 	builder := fsm.NewBuilder(
@@ -193,6 +463,18 @@ func SetupController(
 		mgr.GetScheme(),
 	).Manages(
 		corev1.SchemeGroupVersion.WithKind("Pod"),
+		corev1.SchemeGroupVersion.WithKind("Secret"),
+		corev1.SchemeGroupVersion.WithKind("Service"),
+		corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"),
+	).Watches(
+		&v1alpha1.BuildkitTemplate{},
+		handler.EnqueueRequestsFromMapFunc(r.buildkitsForTemplate),
+	).Watches(
+		&v1alpha1.ClusterBuildkitTemplate{},
+		handler.EnqueueRequestsFromMapFunc(r.buildkitsForClusterTemplate),
+	).Watches(
+		&v1alpha1.Buildkit{},
+		handler.Funcs{UpdateFunc: r.instanceUpdated, DeleteFunc: r.instanceDeleted},
 	)
 
 	return builder.Build()(mgr, log, rl, cpCtx.Metrics)
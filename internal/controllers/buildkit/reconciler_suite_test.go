@@ -27,6 +27,7 @@ import (
 
 	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit"
 	"github.com/seatgeek/buildkit-operator/internal/controlplane"
+	buildkitmetrics "github.com/seatgeek/buildkit-operator/internal/metrics"
 	intscheme "github.com/seatgeek/buildkit-operator/internal/scheme"
 	"github.com/seatgeek/buildkit-operator/internal/test"
 )
@@ -69,8 +70,10 @@ var _ = BeforeSuite(func() {
 					Applicator: io.NewAPIPatchingApplicator(mgr.GetClient()),
 				}
 
+				registry := prometheus.NewRegistry()
 				cpCtx := controlplane.Context{
-					Metrics: metrics.MustMakeMetrics(scheme, prometheus.NewRegistry()),
+					Metrics:         metrics.MustMakeMetrics(scheme, registry),
+					BuildkitMetrics: buildkitmetrics.MustMakeMetrics(registry),
 				}
 
 				return buildkit.SetupController(ctx, cpCtx, mgr, rl, clientApplicator)
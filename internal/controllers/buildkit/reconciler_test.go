@@ -5,17 +5,31 @@
 package buildkit_test
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
 
+	controlapi "github.com/moby/buildkit/api/services/control"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/reddit/achilles-sdk-api/api"
 	sdktest "github.com/reddit/achilles-sdk/pkg/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/pki"
+	"github.com/seatgeek/buildkit-operator/internal/test/buildkitd"
 	. "github.com/seatgeek/buildkit-operator/internal/test/matchers"
 )
 
@@ -53,8 +67,11 @@ var _ = Describe("Buildkit Reconciler", func() {
 
 		DeferCleanup(func() {
 			Expect(c.DeleteAllOf(ctx, &v1alpha1.Buildkit{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitPool{}, client.InNamespace(namespace))).To(Succeed())
 			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitTemplate{}, client.InNamespace(namespace))).To(Succeed())
 			Expect(c.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &corev1.PersistentVolumeClaim{}, client.InNamespace(namespace))).To(Succeed())
 			Expect(c.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
 		})
 	})
@@ -159,6 +176,67 @@ var _ = Describe("Buildkit Reconciler", func() {
 				Status: corev1.ConditionFalse,
 				Reason: "PodFailed",
 			}))
+			g.Expect(updated.GetCondition(v1alpha1.TypeFailed)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionTrue,
+				Reason: "PodFailed",
+			}))
+			g.Expect(updated.Status.LastFailureReason).To(Equal("Container failed to start"))
+			g.Expect(updated.Status.LastFailureTime).NotTo(BeNil())
+			g.Expect(updated.Status.RestartCount).To(BeZero())
+		}).Should(Succeed())
+
+		By("verifying the failed pod is left in place")
+		Consistently(func(g Gomega) {
+			g.Expect(c.Get(ctx, podKey, &corev1.Pod{})).To(Succeed())
+		}).Should(Succeed())
+	})
+
+	It("should replace failed pods under the OnFailure recovery policy until maxRestarts is reached", func() {
+		By("setting the OnFailure recovery policy on the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Lifecycle.RecoveryPolicy = &v1alpha1.BuildkitTemplateRecoveryPolicy{
+				Type:           v1alpha1.RecoveryPolicyOnFailure,
+				MaxRestarts:    1,
+				InitialBackoff: metav1.Duration{Duration: time.Second},
+				MaxBackoff:     metav1.Duration{Duration: time.Second},
+			}
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource and failing its pod")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		first := failOnlyPod(namespace, "OOMKilled")
+
+		By("verifying the failed pod is replaced")
+		var second corev1.Pod
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Name).NotTo(Equal(first))
+			second = pods.Items[0]
+
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Status.RestartCount).To(Equal(int32(1)))
+			g.Expect(updated.Status.LastFailureReason).To(Equal("OOMKilled"))
+		}).Should(Succeed())
+
+		By("failing the replacement and verifying the Buildkit is marked as Failed")
+		Expect(failOnlyPod(namespace, "Evicted")).To(Equal(second.Name))
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Status.LastFailureReason).To(Equal("Evicted"))
+			g.Expect(updated.GetCondition(v1alpha1.TypeFailed)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionTrue,
+				Reason: "RestartLimitReached",
+			}))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(&second), &corev1.Pod{})).To(Succeed())
 		}).Should(Succeed())
 	})
 
@@ -187,6 +265,534 @@ var _ = Describe("Buildkit Reconciler", func() {
 		}).Should(Succeed())
 	})
 
+	It("should issue TLS certificates when the template enables TLS", func() {
+		By("enabling TLS on the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.TLS = &v1alpha1.BuildkitTemplateTLS{
+				CertificateDuration: metav1.Duration{Duration: v1alpha1.DefaultTLSCertificateDuration},
+				RenewBefore:         metav1.Duration{Duration: v1alpha1.DefaultTLSRenewBefore},
+			}
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource before the CA exists")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		Consistently(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(BeEmpty())
+		}, "2s", "100ms").Should(Succeed())
+
+		By("creating the CA secret normally maintained by the BuildkitTemplate controller")
+		ca, err := pki.NewCA("test-ca", 24*time.Hour, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("buildkit-%s-ca", buildkitTemplate.Name),
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				pki.TLSCertKey: ca.CertPEM,
+				pki.TLSKeyKey:  ca.KeyPEM,
+			},
+		})).To(Succeed())
+
+		By("verifying server and client certificates are issued by the CA")
+		for _, name := range []string{"test-buildkit-server-tls", "test-buildkit-client-tls"} {
+			Eventually(func(g Gomega) {
+				var secret corev1.Secret
+				g.Expect(c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &secret)).To(Succeed())
+				g.Expect(secret.Data).To(HaveKeyWithValue(pki.CACertKey, ca.CertPEM))
+				g.Expect(pki.NeedsRenewal(secret.Data[pki.TLSCertKey], ca, time.Hour, time.Now())).To(BeFalse())
+			}).Should(Succeed())
+		}
+
+		By("verifying the client secret is referenced from the status")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Status.ClientTLSSecretName).To(Equal("test-buildkit-client-tls"))
+		}).Should(Succeed())
+
+		By("verifying the pod mounts the server certificate")
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Annotations).To(HaveKey("buildkit.seatgeek.io/server-certificate"))
+			g.Expect(pods.Items[0].Spec.Containers[0].Args).To(ContainElement("--tlscert"))
+		}).Should(Succeed())
+	})
+
+	It("should publish an endpoint clients can verify the server certificate against when TLS is enabled", func() {
+		By("enabling TLS on the template, which keeps the default PodIP endpoint type")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.TLS = &v1alpha1.BuildkitTemplateTLS{}
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		ca, err := pki.NewCA("test-ca", 24*time.Hour, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("buildkit-%s-ca", buildkitTemplate.Name),
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				pki.TLSCertKey: ca.CertPEM,
+				pki.TLSKeyKey:  ca.KeyPEM,
+			},
+		})).To(Succeed())
+
+		By("creating a Buildkit resource and marking its pod ready")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		markOnlyPodReady(namespace, "10.0.0.1")
+
+		var endpoint *url.URL
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Status.Endpoint).To(Equal(fmt.Sprintf("tcp://test-buildkit.%s.svc:1234", namespace)))
+			endpoint, err = url.Parse(updated.Status.Endpoint)
+			g.Expect(err).NotTo(HaveOccurred())
+		}).Should(Succeed())
+
+		By("serving the issued server certificate from a fake buildkitd")
+		var serverSecret, clientSecret corev1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Name: "test-buildkit-server-tls", Namespace: namespace}, &serverSecret)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "test-buildkit-client-tls", Namespace: namespace}, &clientSecret)).To(Succeed())
+
+		roots := x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(ca.CertPEM)).To(BeTrue())
+		serverCertificate, err := tls.X509KeyPair(serverSecret.Data[pki.TLSCertKey], serverSecret.Data[pki.TLSKeyKey])
+		Expect(err).NotTo(HaveOccurred())
+		clientCertificate, err := tls.X509KeyPair(clientSecret.Data[pki.TLSCertKey], clientSecret.Data[pki.TLSKeyKey])
+		Expect(err).NotTo(HaveOccurred())
+
+		server, err := buildkitd.Start(&tls.Config{
+			Certificates: []tls.Certificate{serverCertificate},
+			ClientCAs:    roots,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Stop)
+
+		By("connecting to the endpoint without overriding the TLS server name")
+		conn, err := grpc.NewClient("passthrough:///"+endpoint.Host,
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				Certificates: []tls.Certificate{clientCertificate},
+				RootCAs:      roots,
+				MinVersion:   tls.VersionTLS12,
+			})),
+			// Stands in for the cluster DNS resolving the Service to the pod
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "tcp", server.Addr())
+			}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		stream, err := controlapi.NewControlClient(conn).ListenBuildHistory(ctx, &controlapi.BuildHistoryRequest{ActiveOnly: true, EarlyExit: true})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).To(MatchError(io.EOF))
+	})
+
+	It("should expose the pod through a stable Service", func() {
+		By("switching the template to Service endpoints")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.EndpointType = v1alpha1.EndpointTypeService
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		By("verifying the pod carries the instance label")
+		var pods corev1.PodList
+		Eventually(func(g Gomega) {
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Labels).To(HaveKeyWithValue(v1alpha1.InstanceLabel, buildkit.Name))
+		}).Should(Succeed())
+
+		By("verifying a Service selecting the pod is created")
+		Eventually(func(g Gomega) {
+			var svc corev1.Service
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &svc)).To(Succeed())
+			g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+			g.Expect(svc.Spec.Selector).To(Equal(map[string]string{v1alpha1.InstanceLabel: buildkit.Name}))
+			g.Expect(svc.Spec.Ports).To(HaveLen(1))
+			g.Expect(svc.Spec.Ports[0].Port).To(Equal(int32(1234)))
+		}).Should(Succeed())
+
+		By("simulating pod transition to running and ready")
+		pod := &pods.Items[0]
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.PodIP = "10.0.0.1"
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name:  "buildkit",
+					Ready: true,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			}
+			g.Expect(c.Status().Update(ctx, pod)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the endpoint uses the Service DNS name")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Status.Endpoint).To(Equal(fmt.Sprintf("tcp://test-buildkit.%s.svc:1234", namespace)))
+			g.Expect(updated.GetCondition(api.TypeReady).Status).To(Equal(corev1.ConditionTrue))
+		}).Should(Succeed())
+	})
+
+	It("should leave alone a Service it didn't create", func() {
+		By("switching the template to Service endpoints")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.EndpointType = v1alpha1.EndpointTypeService
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating an unrelated Service with the Buildkit's name")
+		unrelated := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: buildkit.Name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "something-else"},
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 80}},
+			},
+		}
+		Expect(c.Create(ctx, unrelated)).To(Succeed())
+
+		By("creating a Buildkit resource")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		By("verifying the conflict is reported")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(v1alpha1.TypeServiceConflict)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionTrue,
+				Reason: "NotOwned",
+			}))
+			g.Expect(updated.Status.ServiceName).To(BeEmpty())
+		}).Should(Succeed())
+
+		By("verifying the Service is left as it was")
+		Consistently(func(g Gomega) {
+			var svc corev1.Service
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(unrelated), &svc)).To(Succeed())
+			g.Expect(svc.Spec.Selector).To(Equal(map[string]string{"app": "something-else"}))
+			g.Expect(svc.OwnerReferences).To(BeEmpty())
+		}, "2s", "100ms").Should(Succeed())
+	})
+
+	It("should claim a warm pod from its pool", func() {
+		By("creating a pool with a ready warm pod")
+		pool := &v1alpha1.BuildkitPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pool",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitPoolSpec{
+				Template: buildkitTemplate.Name,
+				Size:     1,
+			},
+		}
+		Expect(c.Create(ctx, pool)).To(Succeed())
+
+		warmPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pool-warm",
+				Namespace: namespace,
+				Labels:    map[string]string{v1alpha1.PoolLabel: pool.Name},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "buildkit",
+						Image: "moby/buildkit:latest",
+						Ports: []corev1.ContainerPort{{Name: "tcp", ContainerPort: 1234}},
+					},
+				},
+			},
+		}
+		Expect(c.Create(ctx, warmPod)).To(Succeed())
+
+		warmPod.Status.Phase = corev1.PodRunning
+		warmPod.Status.PodIP = "10.0.0.2"
+		warmPod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{
+				Name:  "buildkit",
+				Ready: true,
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			},
+		}
+		Expect(c.Status().Update(ctx, warmPod)).To(Succeed())
+
+		By("creating a Buildkit resource that uses the pool")
+		buildkit.Spec.Template = ""
+		buildkit.Spec.Pool = pool.Name
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		By("verifying the warm pod is claimed instead of starting a new one")
+		Eventually(func(g Gomega) {
+			var claimed corev1.Pod
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(warmPod), &claimed)).To(Succeed())
+			g.Expect(claimed.Labels).To(HaveKeyWithValue(v1alpha1.InstanceLabel, buildkit.Name))
+			g.Expect(claimed.Labels).To(HaveKeyWithValue(v1alpha1.PoolLabel, pool.Name))
+			g.Expect(metav1.GetControllerOf(&claimed)).NotTo(BeNil())
+			g.Expect(metav1.GetControllerOf(&claimed).Name).To(Equal(buildkit.Name))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+		}, "2s", "100ms").Should(Succeed())
+
+		By("verifying the Buildkit is immediately ready")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Status.Endpoint).To(Equal("tcp://10.0.0.2:1234"))
+			g.Expect(updated.GetCondition(api.TypeReady).Status).To(Equal(corev1.ConditionTrue))
+		}).Should(Succeed())
+	})
+
+	It("should start its own pod when its pool has no warm pods", func() {
+		Expect(c.Create(ctx, &v1alpha1.BuildkitPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pool",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitPoolSpec{
+				Template: buildkitTemplate.Name,
+			},
+		})).To(Succeed())
+
+		buildkit.Spec.Template = ""
+		buildkit.Spec.Pool = "test-pool"
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Labels).To(HaveKeyWithValue(v1alpha1.InstanceLabel, buildkit.Name))
+			g.Expect(pods.Items[0].Labels).NotTo(HaveKey(v1alpha1.PoolLabel))
+		}).Should(Succeed())
+	})
+
+	It("should create a build cache PVC owned by the Buildkit", func() {
+		By("switching the template to PersistentVolumeClaim storage")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Storage = cacheStorage(v1alpha1.StorageRetentionPolicyDelete)
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		By("verifying the PVC is created and owned by the Buildkit")
+		Eventually(func(g Gomega) {
+			var pvc corev1.PersistentVolumeClaim
+			g.Expect(c.Get(ctx, client.ObjectKey{Name: "test-buildkit-cache", Namespace: namespace}, &pvc)).To(Succeed())
+			g.Expect(metav1.GetControllerOf(&pvc)).NotTo(BeNil())
+			g.Expect(metav1.GetControllerOf(&pvc).Name).To(Equal(buildkit.Name))
+		}).Should(Succeed())
+
+		By("verifying the pod mounts the PVC")
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Spec.Volumes[0].PersistentVolumeClaim).NotTo(BeNil())
+			g.Expect(pods.Items[0].Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("test-buildkit-cache"))
+		}).Should(Succeed())
+	})
+
+	It("should hand a retained build cache to the next Buildkit with the same cache key", func() {
+		By("switching the template to retained PersistentVolumeClaim storage")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Storage = cacheStorage(v1alpha1.StorageRetentionPolicyRetain)
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		const pvcName = "buildkit-test-template-cache-main"
+		pvcKey := client.ObjectKey{Name: pvcName, Namespace: namespace}
+
+		By("creating a first Buildkit with a cache key")
+		buildkit.Spec.CacheKey = "main"
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		var firstPod corev1.Pod
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			firstPod = pods.Items[0]
+
+			var pvc corev1.PersistentVolumeClaim
+			g.Expect(c.Get(ctx, pvcKey, &pvc)).To(Succeed())
+			g.Expect(pvc.Annotations).To(HaveKeyWithValue("buildkit.seatgeek.io/cache-holder", buildkit.Name))
+			g.Expect(metav1.IsControlledBy(&pvc, buildkit)).To(BeFalse())
+		}).Should(Succeed())
+
+		By("creating a second Buildkit with the same cache key")
+		second := &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "second-buildkit",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitSpec{
+				Template: buildkitTemplate.Name,
+				CacheKey: "main",
+			},
+		}
+		Expect(c.Create(ctx, second)).To(Succeed())
+
+		By("verifying the second Buildkit waits for the cache")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(second), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(v1alpha1.TypeDeployed)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: "CacheInUse",
+			}))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+		}, "2s", "100ms").Should(Succeed())
+
+		By("deleting the first Buildkit and its pod")
+		Expect(c.Delete(ctx, buildkit)).To(Succeed())
+		Expect(c.Delete(ctx, &firstPod)).To(Succeed())
+
+		By("verifying the second Buildkit takes over the PVC")
+		Eventually(func(g Gomega) {
+			var pvc corev1.PersistentVolumeClaim
+			g.Expect(c.Get(ctx, pvcKey, &pvc)).To(Succeed())
+			g.Expect(pvc.Annotations).To(HaveKeyWithValue("buildkit.seatgeek.io/cache-holder", second.Name))
+
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace), client.MatchingLabels{v1alpha1.InstanceLabel: second.Name})).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(pvcName))
+		}, "30s").Should(Succeed())
+	})
+
+	It("should delete a Buildkit once it has been idle for the template's idle timeout", func() {
+		By("starting a fake buildkitd with a build in progress")
+		server, err := buildkitd.Start(nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Stop)
+		server.SetActiveBuilds(1)
+
+		By("setting an idle timeout on the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Port = server.Port()
+			buildkitTemplate.Spec.Lifecycle.IdleTimeout = &metav1.Duration{Duration: 2 * time.Second}
+			buildkitTemplate.Spec.Lifecycle.IdleAction = v1alpha1.IdleActionDelete
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource and marking its pod ready")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		markOnlyPodReady(namespace, "127.0.0.1")
+
+		By("verifying the build in progress is recorded and keeps the Buildkit around")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Status.LastActiveTime).NotTo(BeNil())
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &v1alpha1.Buildkit{})).To(Succeed())
+		}).WithTimeout(4 * time.Second).Should(Succeed())
+
+		By("finishing the build and verifying the Buildkit is deleted")
+		server.SetActiveBuilds(0)
+		Eventually(func(g Gomega) {
+			err := c.Get(ctx, client.ObjectKeyFromObject(buildkit), &v1alpha1.Buildkit{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("should suspend an idle Buildkit and start it again when resumed", func() {
+		By("starting a fake buildkitd with no builds in progress")
+		server, err := buildkitd.Start(nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Stop)
+
+		By("setting an idle timeout that suspends instances on the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Port = server.Port()
+			buildkitTemplate.Spec.Lifecycle.IdleTimeout = &metav1.Duration{Duration: 2 * time.Second}
+			buildkitTemplate.Spec.Lifecycle.IdleAction = v1alpha1.IdleActionSuspend
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource and marking its pod ready")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		markOnlyPodReady(namespace, "127.0.0.1")
+
+		By("verifying the Buildkit is suspended and its pod stopped")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.Spec.Suspended).To(BeTrue())
+			g.Expect(updated.Status.Endpoint).To(BeEmpty())
+			g.Expect(updated.GetCondition(v1alpha1.TypeDeployed)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: "Suspended",
+			}))
+
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(BeEmpty())
+		}).Should(Succeed())
+
+		By("resuming the Buildkit")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			updated.Spec.Suspended = false
+			g.Expect(c.Update(ctx, &updated)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying a new pod is started")
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+		}).Should(Succeed())
+	})
+
 	It("should not modify existing pods when BuildkitTemplate changes", func() {
 		By("creating a Buildkit resource")
 		Expect(c.Create(ctx, buildkit)).To(Succeed())
@@ -266,5 +872,231 @@ var _ = Describe("Buildkit Reconciler", func() {
 			g.Expect(updated.GetCondition(v1alpha1.TypeDeployed).Status).To(Equal(corev1.ConditionTrue))
 			g.Expect(updated.Status.Endpoint).To(Equal("tcp://10.0.0.1:1234")) // Still using original port
 		}).Should(Succeed())
+
+		By("verifying the Buildkit reports that its pod is out of date")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(v1alpha1.TypeTemplateOutOfDate)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionTrue,
+				Reason: "OnDelete",
+			}))
+		}).Should(Succeed())
+	})
+
+	It("should replace out-of-date pods straight away with the Recreate update strategy", func() {
+		By("setting the Recreate update strategy on the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.UpdateStrategy = v1alpha1.UpdateStrategyRecreate
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource and marking its pod ready")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		markOnlyPodReady(namespace, "10.0.0.1")
+
+		var original corev1.PodList
+		Expect(c.List(ctx, &original, client.InNamespace(namespace))).To(Succeed())
+		Expect(original.Items[0].Annotations).To(HaveKey(v1alpha1.TemplateHashAnnotation))
+
+		By("verifying the pod is up to date")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(v1alpha1.TypeTemplateOutOfDate)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: "UpToDate",
+			}))
+		}).Should(Succeed())
+
+		By("changing the template image")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Image = "moby/buildkit:v0.23.2"
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the pod is replaced with one using the new image")
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Name).NotTo(Equal(original.Items[0].Name))
+			g.Expect(pods.Items[0].Spec.Containers[0].Image).To(Equal("moby/buildkit:v0.23.2"))
+		}).Should(Succeed())
+	})
+
+	It("should wait for builds to finish before replacing out-of-date pods with the RecreateWhenIdle update strategy", func() {
+		By("starting a fake buildkitd with a build in progress")
+		server, err := buildkitd.Start(nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(server.Stop)
+		server.SetActiveBuilds(1)
+
+		By("setting the RecreateWhenIdle update strategy on the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Port = server.Port()
+			buildkitTemplate.Spec.UpdateStrategy = v1alpha1.UpdateStrategyRecreateWhenIdle
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("creating a Buildkit resource and marking its pod ready")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		markOnlyPodReady(namespace, "127.0.0.1")
+
+		var original corev1.PodList
+		Expect(c.List(ctx, &original, client.InNamespace(namespace))).To(Succeed())
+
+		By("changing the template's buildkitd.toml")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.BuildkitdToml = "debug = true"
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the pod is kept while its build is running")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.Buildkit
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(v1alpha1.TypeTemplateOutOfDate)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionTrue,
+				Reason: "WaitingForIdle",
+			}))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(&original.Items[0]), &corev1.Pod{})).To(Succeed())
+		}).Should(Succeed())
+
+		By("finishing the build and verifying the pod is replaced")
+		server.SetActiveBuilds(0)
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Name).NotTo(Equal(original.Items[0].Name))
+		}).WithTimeout(45 * time.Second).Should(Succeed())
+	})
+
+	It("should patch label and annotation changes onto the existing pod", func() {
+		By("creating a Buildkit resource with labels and annotations")
+		buildkit.Spec.Labels = map[string]string{"team": "platform", "stage": "ci"}
+		buildkit.Spec.Annotations = map[string]string{"example.com/note": "first"}
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		markOnlyPodReady(namespace, "10.0.0.1")
+
+		var original corev1.PodList
+		Expect(c.List(ctx, &original, client.InNamespace(namespace))).To(Succeed())
+
+		By("changing the labels and annotations")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), buildkit)).To(Succeed())
+			buildkit.Spec.Labels = map[string]string{"team": "builds"}
+			buildkit.Spec.Annotations = map[string]string{"example.com/note": "second"}
+			g.Expect(c.Update(ctx, buildkit)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the same pod picks up the changes")
+		Eventually(func(g Gomega) {
+			var pod corev1.Pod
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(&original.Items[0]), &pod)).To(Succeed())
+			g.Expect(pod.Labels).To(HaveKeyWithValue("team", "builds"))
+			g.Expect(pod.Labels).NotTo(HaveKey("stage"))
+			g.Expect(pod.Labels).To(HaveKeyWithValue(v1alpha1.InstanceLabel, buildkit.Name))
+			g.Expect(pod.Annotations).To(HaveKeyWithValue("example.com/note", "second"))
+		}).Should(Succeed())
+	})
+
+	It("should apply resource changes to the pod by resizing or replacing it", func() {
+		By("creating a Buildkit resource with resources")
+		buildkit.Spec.Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		}
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+		markOnlyPodReady(namespace, "10.0.0.1")
+
+		By("raising the memory request")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), buildkit)).To(Succeed())
+			buildkit.Spec.Resources.Requests[corev1.ResourceMemory] = resource.MustParse("384Mi")
+			g.Expect(c.Update(ctx, buildkit)).To(Succeed())
+		}).Should(Succeed())
+
+		// envtest's API server doesn't resize pods in place, in which case the pod is replaced instead
+		By("verifying the pod runs with the new resources")
+		Eventually(func(g Gomega) {
+			var pods corev1.PodList
+			g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(1))
+			g.Expect(pods.Items[0].Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("384Mi"))
+		}).Should(Succeed())
 	})
 })
+
+func cacheStorage(retentionPolicy v1alpha1.StorageRetentionPolicy) v1alpha1.BuildkitTemplateStorage {
+	return v1alpha1.BuildkitTemplateStorage{
+		Type:            v1alpha1.StorageTypePersistentVolumeClaim,
+		RetentionPolicy: retentionPolicy,
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// markOnlyPodReady waits for the single pod in namespace to be created, then marks it running and ready at podIP.
+func markOnlyPodReady(namespace, podIP string) {
+	GinkgoHelper()
+
+	var pods corev1.PodList
+	Eventually(func(g Gomega) {
+		g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+		g.Expect(pods.Items).To(HaveLen(1))
+	}).Should(Succeed())
+
+	pod := &pods.Items[0]
+	Eventually(func(g Gomega) {
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.PodIP = podIP
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{
+				Name:  "buildkit",
+				Ready: true,
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			},
+		}
+		g.Expect(c.Status().Update(ctx, pod)).To(Succeed())
+	}).Should(Succeed())
+}
+
+// failOnlyPod waits for the namespace's only pod to exist, marks it as failed with the given message, and returns its name.
+func failOnlyPod(namespace, message string) string {
+	GinkgoHelper()
+
+	var pod corev1.Pod
+	Eventually(func(g Gomega) {
+		var pods corev1.PodList
+		g.Expect(c.List(ctx, &pods, client.InNamespace(namespace))).To(Succeed())
+		g.Expect(pods.Items).To(HaveLen(1))
+
+		pod = pods.Items[0]
+		pod.Status.Phase = corev1.PodFailed
+		pod.Status.Reason = "Error"
+		pod.Status.Message = message
+		g.Expect(c.Status().Update(ctx, &pod)).To(Succeed())
+	}).Should(Succeed())
+
+	return pod.Name
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// recoverFailedPod handles a Buildkit pod that has failed. Under the template's OnFailure recovery policy, the pod is
+// replaced once its backoff has passed, until maxRestarts is reached. Otherwise, the Buildkit is marked as Failed.
+func (r *reconciler) recoverFailedPod(obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, pod *corev1.Pod, out *types.OutputSet, log *zap.SugaredLogger) (*state, types.Result) {
+	reason := cmp.Or(pod.Status.Message, pod.Status.Reason, "unknown failure")
+	now := time.Now()
+
+	// Record each pod's failure once; failures recorded before the pod was created belong to its predecessors
+	if last := obj.Status.LastFailureTime; last == nil || last.Before(&pod.CreationTimestamp) {
+		log.Warnw("Buildkit pod has failed", "pod", pod.Name, "reason", pod.Status.Reason, "message", pod.Status.Message)
+		obj.Status.LastFailureReason = reason
+		obj.Status.LastFailureTime = &metav1.Time{Time: now}
+		r.metrics.RecordPodFailure(obj.Namespace, templateLabel(obj, template))
+		r.recorder.Eventf(obj, corev1.EventTypeWarning, v1alpha1.EventReasonPodFailed, "Buildkit pod %s has failed: %s", pod.Name, reason)
+	}
+
+	var recovery v1alpha1.BuildkitTemplateRecoveryPolicy
+	if template != nil && template.Spec.Lifecycle.RecoveryPolicy != nil {
+		recovery = *template.Spec.Lifecycle.RecoveryPolicy
+	}
+
+	if recovery.Type != v1alpha1.RecoveryPolicyOnFailure {
+		setCondition(obj, v1alpha1.TypeFailed, corev1.ConditionTrue, "PodFailed", fmt.Sprintf("Buildkit pod %s has failed: %s", pod.Name, reason))
+		return nil, podFailedResult(pod, reason)
+	}
+
+	if maxRestarts := cmp.Or(recovery.MaxRestarts, v1alpha1.DefaultRecoveryMaxRestarts); obj.Status.RestartCount >= maxRestarts {
+		setCondition(obj, v1alpha1.TypeFailed, corev1.ConditionTrue, "RestartLimitReached", fmt.Sprintf("Buildkit pod %s has failed after %d restarts: %s", pod.Name, obj.Status.RestartCount, reason))
+		return nil, podFailedResult(pod, reason)
+	}
+
+	if pod.DeletionTimestamp != nil {
+		return nil, types.RequeueResultWithReasonAndBackoff("Waiting for failed Buildkit pod to be removed", "PodFailed")
+	}
+
+	backoff := recoveryBackoff(recovery, obj.Status.RestartCount)
+	if wait := obj.Status.LastFailureTime.Add(backoff).Sub(now); wait > 0 {
+		log.Debugw("Waiting before replacing failed Buildkit pod", "pod", pod.Name, "backoff", backoff)
+		return nil, types.RequeueResultWithReason(fmt.Sprintf("Replacing failed Buildkit pod %s in %s", pod.Name, wait.Round(time.Second)), "BackingOff", wait)
+	}
+
+	log.Infow("Replacing failed Buildkit pod", "pod", pod.Name, "restartCount", obj.Status.RestartCount+1)
+	obj.Status.RestartCount++
+	r.metrics.RecordRestart(obj.Namespace, templateLabel(obj, template))
+	out.Delete(pod)
+
+	return nil, types.Result{
+		Done:                   true,
+		RequeueAfterCompletion: true,
+		RequeueMsg:             "Applying changes",
+		Reason:                 "ApplyingChanges",
+	}
+}
+
+// recoveryBackoff returns how long to wait before replacing a failed pod once the given number of restarts have happened.
+// The backoff starts at initialBackoff and doubles with every restart, up to maxBackoff.
+func recoveryBackoff(recovery v1alpha1.BuildkitTemplateRecoveryPolicy, restarts int32) time.Duration {
+	backoff := cmp.Or(recovery.InitialBackoff.Duration, v1alpha1.DefaultRecoveryInitialBackoff)
+	maxBackoff := cmp.Or(recovery.MaxBackoff.Duration, v1alpha1.DefaultRecoveryMaxBackoff)
+
+	for range restarts {
+		if backoff >= maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+func podFailedResult(pod *corev1.Pod, reason string) types.Result {
+	return types.Result{
+		Done: true,
+		CustomStatusCondition: &types.ResultStatusCondition{
+			Reason:  "PodFailed",
+			Status:  corev1.ConditionFalse,
+			Message: fmt.Sprintf("Buildkit pod %s has failed: %s", pod.Name, reason),
+		},
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestRecoveryBackoff(t *testing.T) {
+	t.Parallel()
+
+	custom := v1alpha1.BuildkitTemplateRecoveryPolicy{
+		InitialBackoff: metav1.Duration{Duration: time.Second},
+		MaxBackoff:     metav1.Duration{Duration: 5 * time.Second},
+	}
+
+	tests := []struct {
+		name     string
+		recovery v1alpha1.BuildkitTemplateRecoveryPolicy
+		restarts int32
+		want     time.Duration
+	}{
+		{
+			name: "defaults for the first restart",
+			want: v1alpha1.DefaultRecoveryInitialBackoff,
+		},
+		{
+			name:     "defaults double with every restart",
+			restarts: 2,
+			want:     4 * v1alpha1.DefaultRecoveryInitialBackoff,
+		},
+		{
+			name:     "defaults are capped",
+			restarts: 100,
+			want:     v1alpha1.DefaultRecoveryMaxBackoff,
+		},
+		{
+			name:     "custom backoff",
+			recovery: custom,
+			restarts: 2,
+			want:     4 * time.Second,
+		},
+		{
+			name:     "custom backoff is capped",
+			recovery: custom,
+			restarts: 3,
+			want:     5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, recoveryBackoff(tt.recovery, tt.restarts))
+		})
+	}
+}
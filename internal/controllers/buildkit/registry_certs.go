@@ -0,0 +1,65 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/buildkitd"
+)
+
+// registryCertProjections returns the volume projections that place the CA bundles and client certificates of the
+// configured registries where the rendered buildkitd.toml expects them, relative to buildkitd's config directory.
+func registryCertProjections(config *v1alpha1.BuildkitdConfig) []corev1.VolumeProjection {
+	if config == nil {
+		return nil
+	}
+
+	var projections []corev1.VolumeProjection
+	for _, registry := range config.Registries {
+		if projection := registryCAProjection(registry.CA, buildkitd.RegistryCAPath(registry.Host)); projection != nil {
+			projections = append(projections, *projection)
+		}
+
+		if cert := registry.ClientCertificate; cert != nil {
+			projections = append(projections, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: *cert,
+					Items: []corev1.KeyToPath{
+						{Key: corev1.TLSCertKey, Path: buildkitd.RegistryClientCertPath(registry.Host)},
+						{Key: corev1.TLSPrivateKeyKey, Path: buildkitd.RegistryClientKeyPath(registry.Host)},
+					},
+				},
+			})
+		}
+	}
+
+	return projections
+}
+
+// registryCAProjection returns the projection placing a CA bundle at path, or nil if the registry has none.
+func registryCAProjection(ca *v1alpha1.BuildkitdRegistryCA, path string) *corev1.VolumeProjection {
+	switch {
+	case ca == nil:
+		return nil
+	case ca.SecretKeyRef != nil:
+		return &corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: ca.SecretKeyRef.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: ca.SecretKeyRef.Key, Path: path}},
+			},
+		}
+	case ca.ConfigMapKeyRef != nil:
+		return &corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: ca.ConfigMapKeyRef.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: ca.ConfigMapKeyRef.Key, Path: path}},
+			},
+		}
+	default:
+		return nil
+	}
+}
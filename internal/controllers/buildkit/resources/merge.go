@@ -5,9 +5,12 @@
 package resources
 
 import (
+	"fmt"
 	"maps"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // ApplyMaximums checks the desired resource requirements against a maximum set of limits.
@@ -82,3 +85,53 @@ func ExceedsMaximums(maximum corev1.ResourceList, desired ...corev1.ResourceRequ
 	_, modified := ApplyMaximums(maximum, desired...)
 	return modified
 }
+
+// WithDefaultRequests returns the resource requirements the way the API server stores them on a pod,
+// where requests that aren't set default to their limits.
+func WithDefaultRequests(requirements corev1.ResourceRequirements) corev1.ResourceRequirements {
+	result := *requirements.DeepCopy()
+	for name, limit := range result.Limits {
+		if _, ok := result.Requests[name]; !ok {
+			if result.Requests == nil {
+				result.Requests = corev1.ResourceList{}
+			}
+			result.Requests[name] = limit
+		}
+	}
+
+	return result
+}
+
+// Excess is a request or limit that's over its maximum, and which ApplyMaximums reduces to it.
+type Excess struct {
+	// Kind is either "requests" or "limits"
+	Kind    string
+	Name    corev1.ResourceName
+	Desired resource.Quantity
+	Maximum resource.Quantity
+}
+
+func (e Excess) String() string {
+	return fmt.Sprintf("%s.%s: %s is over the maximum of %s", e.Kind, e.Name, e.Desired.String(), e.Maximum.String())
+}
+
+// Exceeding lists the merged desired requests and limits that are over the maximum, sorted by kind and name.
+// Unlike ExceedsMaximums, it leaves out limits that ApplyMaximums only fills in because they're missing.
+func Exceeding(maximum corev1.ResourceList, desired ...corev1.ResourceRequirements) []Excess {
+	merged, _ := ApplyMaximums(nil, desired...)
+
+	var excesses []Excess
+	for _, kind := range []struct {
+		name string
+		list corev1.ResourceList
+	}{{"limits", merged.Limits}, {"requests", merged.Requests}} {
+		for _, name := range slices.Sorted(maps.Keys(kind.list)) {
+			value := kind.list[name]
+			if maxValue, exists := maximum[name]; exists && value.Cmp(maxValue) > 0 {
+				excesses = append(excesses, Excess{Kind: kind.name, Name: name, Desired: value, Maximum: maxValue})
+			}
+		}
+	}
+
+	return excesses
+}
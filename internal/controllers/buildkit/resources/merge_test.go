@@ -329,3 +329,87 @@ func TestApplyMaximums(t *testing.T) {
 		})
 	}
 }
+
+func TestExceeding(t *testing.T) {
+	t.Parallel()
+
+	maximum := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("16Gi"),
+	}
+
+	tests := []struct {
+		name    string
+		desired []corev1.ResourceRequirements
+		want    []Excess
+	}{
+		{
+			name: "within maximums",
+			desired: []corev1.ResourceRequirements{{
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}},
+		},
+		{
+			name: "missing limits aren't excesses",
+			desired: []corev1.ResourceRequirements{{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			}},
+		},
+		{
+			name: "requests and limits over the maximums",
+			desired: []corev1.ResourceRequirements{{
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi"), corev1.ResourceCPU: resource.MustParse("4")},
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Gi")},
+			}},
+			want: []Excess{
+				{Kind: "limits", Name: corev1.ResourceCPU, Desired: resource.MustParse("4"), Maximum: resource.MustParse("2")},
+				{Kind: "limits", Name: corev1.ResourceMemory, Desired: resource.MustParse("64Gi"), Maximum: resource.MustParse("16Gi")},
+				{Kind: "requests", Name: corev1.ResourceMemory, Desired: resource.MustParse("32Gi"), Maximum: resource.MustParse("16Gi")},
+			},
+		},
+		{
+			name: "later requirements take precedence",
+			desired: []corev1.ResourceRequirements{
+				{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}},
+				{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Exceeding(maximum, tt.desired...))
+		})
+	}
+
+	excess := Excess{Kind: "limits", Name: corev1.ResourceMemory, Desired: resource.MustParse("64Gi"), Maximum: resource.MustParse("16Gi")}
+	assert.Equal(t, "limits.memory: 64Gi is over the maximum of 16Gi", excess.String())
+}
+
+func TestWithDefaultRequests(t *testing.T) {
+	t.Parallel()
+
+	requirements := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+
+	assert.Equal(t, corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+	}, WithDefaultRequests(requirements))
+	assert.Len(t, requirements.Requests, 1, "the given requirements are left alone")
+	assert.Equal(t, corev1.ResourceRequirements{}, WithDefaultRequests(corev1.ResourceRequirements{}))
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
+	buildkitmetrics "github.com/seatgeek/buildkit-operator/internal/metrics"
+)
+
+// rolloutProbeInterval is how often an out-of-date pod is checked for running builds under the RecreateWhenIdle strategy.
+const rolloutProbeInterval = 30 * time.Second
+
+// IsPodOutOfDate reports whether the pod was started from a different version of the template than the current one.
+// Pods that don't record a template hash predate this check and are considered out of date.
+func IsPodOutOfDate(pod *corev1.Pod, template *v1alpha1.BuildkitTemplate) (bool, error) {
+	hash, err := buildkit_template.NewBuilder(template).PodSpecHash()
+	if err != nil {
+		return false, err
+	}
+
+	return pod.Annotations[v1alpha1.TemplateHashAnnotation] != hash, nil
+}
+
+// ReplacesOutOfDatePods reports whether the template's update strategy has the operator replace out-of-date pods,
+// rather than waiting for something else to delete them.
+func ReplacesOutOfDatePods(template *v1alpha1.BuildkitTemplate) bool {
+	return template.Spec.UpdateStrategy == v1alpha1.UpdateStrategyRecreate ||
+		template.Spec.UpdateStrategy == v1alpha1.UpdateStrategyRecreateWhenIdle
+}
+
+// rolloutTemplate checks whether an existing pod was started from the current version of its template and, depending
+// on the template's update strategy, enqueues it for replacement if not. The outcome is recorded in the TemplateOutOfDate
+// condition. It returns how long to wait before checking again if the pod is waiting for its builds to finish.
+func (r *reconciler) rolloutTemplate(ctx context.Context, obj *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate, pod *corev1.Pod, out *types.OutputSet, log *zap.SugaredLogger) (time.Duration, error) {
+	outOfDate, err := IsPodOutOfDate(pod, template)
+	if err != nil {
+		return 0, err
+	}
+
+	if !outOfDate {
+		setCondition(obj, v1alpha1.TypeTemplateOutOfDate, corev1.ConditionFalse, "UpToDate", fmt.Sprintf("Buildkit pod %s runs the current version of BuildkitTemplate '%s'", pod.Name, template.Name))
+		return 0, nil
+	}
+
+	switch template.Spec.UpdateStrategy {
+	case v1alpha1.UpdateStrategyRecreate:
+		log.Infow("Replacing Buildkit pod to pick up BuildkitTemplate changes", "pod", pod.Name)
+		setCondition(obj, v1alpha1.TypeTemplateOutOfDate, corev1.ConditionTrue, "Recreating", fmt.Sprintf("Replacing Buildkit pod %s to pick up changes to BuildkitTemplate '%s'", pod.Name, template.Name))
+		r.metrics.RecordReplacement(obj.Namespace, template.Name, buildkitmetrics.ReplacementTemplateChanged)
+		out.Delete(pod)
+	case v1alpha1.UpdateStrategyRecreateWhenIdle:
+		if r.waitForIdle(ctx, obj, pod, log) {
+			setCondition(obj, v1alpha1.TypeTemplateOutOfDate, corev1.ConditionTrue, "WaitingForIdle", fmt.Sprintf("Buildkit pod %s will be replaced to pick up changes to BuildkitTemplate '%s' once its builds have finished", pod.Name, template.Name))
+			return rolloutProbeInterval, nil
+		}
+
+		log.Infow("Replacing idle Buildkit pod to pick up BuildkitTemplate changes", "pod", pod.Name)
+		setCondition(obj, v1alpha1.TypeTemplateOutOfDate, corev1.ConditionTrue, "Recreating", fmt.Sprintf("Replacing Buildkit pod %s to pick up changes to BuildkitTemplate '%s'", pod.Name, template.Name))
+		r.metrics.RecordReplacement(obj.Namespace, template.Name, buildkitmetrics.ReplacementTemplateChanged)
+		out.Delete(pod)
+	default:
+		setCondition(obj, v1alpha1.TypeTemplateOutOfDate, corev1.ConditionTrue, "OnDelete", fmt.Sprintf("Buildkit pod %s runs an older version of BuildkitTemplate '%s'; delete the pod to pick up the changes", pod.Name, template.Name))
+	}
+
+	return 0, nil
+}
+
+// waitForIdle reports whether a pod has to be left alone because it may be running builds.
+// Pods that aren't ready can't be running any builds, while pods that can't be probed might be.
+func (r *reconciler) waitForIdle(ctx context.Context, obj *v1alpha1.Buildkit, pod *corev1.Pod, log *zap.SugaredLogger) bool {
+	if !IsPodReady(pod) {
+		return false
+	}
+
+	active, err := r.podActive(ctx, obj, pod)
+	if err != nil {
+		log.Warnw("Failed to check Buildkit for running builds", "error", err)
+		return true
+	}
+
+	return active
+}
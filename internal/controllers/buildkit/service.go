@@ -0,0 +1,103 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// ensureService enqueues the Buildkit instance's Service for apply if it is missing or out of date.
+// The Service is maintained regardless of the template's endpoint type so that switching types doesn't require a new pod.
+// It is only applied when something has changed, since every apply triggers another reconcile.
+// A Service of the same name that wasn't created for the instance is left alone, and the conflict is reported instead.
+func (r *reconciler) ensureService(ctx context.Context, obj *v1alpha1.Buildkit, builder *Builder, pod *corev1.Pod, out *types.OutputSet, log *zap.SugaredLogger) error {
+	port, ok := containerPort(pod)
+	if !ok {
+		// The pod is validated later on; there's nothing to point the Service at yet
+		return nil
+	}
+
+	desired := builder.BuildService(port)
+
+	var existing corev1.Service
+	if err := r.c.Get(ctx, client.ObjectKeyFromObject(desired), &existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get service '%s': %w", desired.Name, err)
+		}
+
+		log.Info("Creating Buildkit service")
+		out.Apply(desired)
+		clearServiceConflict(obj, desired.Name)
+		return nil
+	}
+
+	if !metav1.IsControlledBy(&existing, obj) {
+		r.markServiceConflict(obj, desired.Name, log)
+		return nil
+	}
+	clearServiceConflict(obj, desired.Name)
+
+	if !maps.Equal(existing.Spec.Selector, desired.Spec.Selector) || len(existing.Spec.Ports) != 1 || existing.Spec.Ports[0].Port != port {
+		log.Infow("Updating Buildkit service", "port", port)
+		out.Apply(desired)
+	}
+
+	return nil
+}
+
+// markServiceConflict records that a Service not created for the Buildkit has the name its own would get, so the
+// instance has no Service and publishes its pod IP instead.
+func (r *reconciler) markServiceConflict(obj *v1alpha1.Buildkit, name string, log *zap.SugaredLogger) {
+	message := fmt.Sprintf("Service '%s' already exists and wasn't created for this Buildkit; its pod IP is published instead", name)
+	if obj.GetCondition(v1alpha1.TypeServiceConflict).Status != corev1.ConditionTrue {
+		log.Warnw("Leaving alone a Service not created for the Buildkit", "service", name)
+		r.recorder.Event(obj, corev1.EventTypeWarning, v1alpha1.EventReasonServiceConflict, message)
+	}
+
+	setCondition(obj, v1alpha1.TypeServiceConflict, corev1.ConditionTrue, "NotOwned", message)
+	obj.Status.ServiceName = ""
+}
+
+// clearServiceConflict records that the Buildkit has a Service of its own, with the given name.
+func clearServiceConflict(obj *v1alpha1.Buildkit, name string) {
+	obj.Status.ServiceName = name
+	if obj.GetCondition(v1alpha1.TypeServiceConflict).Status != corev1.ConditionTrue {
+		return
+	}
+
+	setCondition(obj, v1alpha1.TypeServiceConflict, corev1.ConditionFalse, "Owned", "Service was created for this Buildkit")
+}
+
+// publishesService reports whether Buildkits started from the template publish their Service's address rather than
+// their pod IP: when the template asks for it, and when it enables TLS, since server certificates are only valid for
+// the Service's DNS names.
+func publishesService(template *v1alpha1.BuildkitTemplate) bool {
+	return template != nil && (template.Spec.EndpointType == v1alpha1.EndpointTypeService || template.Spec.TLS != nil)
+}
+
+// serviceEndpoint returns the address of the Buildkit instance's Service.
+func serviceEndpoint(obj *v1alpha1.Buildkit, port int32) string {
+	return fmt.Sprintf("tcp://%s.%s.svc:%d", obj.Status.ServiceName, obj.Namespace, port)
+}
+
+// containerPort returns the TCP port the pod's buildkitd container listens on.
+func containerPort(pod *corev1.Pod) (int32, bool) {
+	if len(pod.Spec.Containers) == 0 || len(pod.Spec.Containers[0].Ports) == 0 {
+		return 0, false
+	}
+
+	return pod.Spec.Containers[0].Ports[0].ContainerPort, true
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// syncPodStatus fills in the phase and pod details of a Buildkit instance from its pod, which is nil or not yet created
+// when the instance has no pod.
+func syncPodStatus(obj *v1alpha1.Buildkit, pod *corev1.Pod) {
+	obj.Status.Phase = buildkitPhase(obj, pod)
+
+	if pod == nil || pod.Name == "" {
+		obj.Status.PodName = ""
+		obj.Status.PodIP = ""
+		obj.Status.PodIPs = nil
+		obj.Status.NodeName = ""
+		obj.Status.StartTime = nil
+		obj.Status.ReadyTime = nil
+		obj.Status.TemplateGeneration = 0
+		return
+	}
+
+	obj.Status.PodName = pod.Name
+	obj.Status.PodIP = pod.Status.PodIP
+	obj.Status.PodIPs = nil
+	for _, ip := range pod.Status.PodIPs {
+		obj.Status.PodIPs = append(obj.Status.PodIPs, ip.IP)
+	}
+	obj.Status.NodeName = pod.Spec.NodeName
+	obj.Status.StartTime = pod.Status.StartTime
+	obj.Status.ReadyTime = podReadyTime(pod)
+
+	// Pods started before the template generation was recorded report none
+	generation, _ := strconv.ParseInt(pod.Annotations[v1alpha1.TemplateGenerationAnnotation], 10, 64)
+	obj.Status.TemplateGeneration = generation
+}
+
+// buildkitPhase sums up where a Buildkit instance is in its lifecycle, given the pod it was left with by a reconcile.
+func buildkitPhase(obj *v1alpha1.Buildkit, pod *corev1.Pod) v1alpha1.BuildkitPhase {
+	switch {
+	case obj.DeletionTimestamp != nil:
+		return v1alpha1.BuildkitPhaseTerminating
+	case obj.Spec.Suspended:
+		return v1alpha1.BuildkitPhaseSuspended
+	case obj.GetCondition(v1alpha1.TypeFailed).Status == corev1.ConditionTrue:
+		return v1alpha1.BuildkitPhaseFailed
+	case (pod == nil || pod.Name == "") && obj.GetCondition(v1alpha1.TypeQueued).Status == corev1.ConditionTrue:
+		return v1alpha1.BuildkitPhaseQueued
+	case pod == nil || pod.Name == "":
+		return v1alpha1.BuildkitPhasePending
+	case pod.DeletionTimestamp != nil:
+		return v1alpha1.BuildkitPhaseTerminating
+	case pod.Status.Phase == corev1.PodFailed:
+		return v1alpha1.BuildkitPhaseFailed
+	case obj.Status.Endpoint != "":
+		return v1alpha1.BuildkitPhaseReady
+	default:
+		return v1alpha1.BuildkitPhaseStarting
+	}
+}
+
+// podReadyTime returns when the pod last became ready, or nil if it isn't ready.
+func podReadyTime(pod *corev1.Pod) *metav1.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return &condition.LastTransitionTime
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestBuildkitPhase(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	running := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-abcde"}}
+
+	tests := []struct {
+		name string
+		obj  *v1alpha1.Buildkit
+		pod  *corev1.Pod
+		want v1alpha1.BuildkitPhase
+	}{
+		{
+			name: "no pod",
+			obj:  &v1alpha1.Buildkit{},
+			want: v1alpha1.BuildkitPhasePending,
+		},
+		{
+			name: "pod not created yet",
+			obj:  &v1alpha1.Buildkit{},
+			pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "test-"}},
+			want: v1alpha1.BuildkitPhasePending,
+		},
+		{
+			name: "queued",
+			obj: &v1alpha1.Buildkit{Status: v1alpha1.BuildkitStatus{ConditionedStatus: api.ConditionedStatus{
+				Conditions: []api.Condition{{Type: v1alpha1.TypeQueued, Status: corev1.ConditionTrue}},
+			}}},
+			want: v1alpha1.BuildkitPhaseQueued,
+		},
+		{
+			name: "pod not ready",
+			obj:  &v1alpha1.Buildkit{},
+			pod:  running,
+			want: v1alpha1.BuildkitPhaseStarting,
+		},
+		{
+			name: "endpoint set",
+			obj:  &v1alpha1.Buildkit{Status: v1alpha1.BuildkitStatus{Endpoint: "tcp://10.0.0.1:1234"}},
+			pod:  running,
+			want: v1alpha1.BuildkitPhaseReady,
+		},
+		{
+			name: "pod failed and waiting to be replaced",
+			obj:  &v1alpha1.Buildkit{},
+			pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-abcde"}, Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+			want: v1alpha1.BuildkitPhaseFailed,
+		},
+		{
+			name: "failed condition",
+			obj: &v1alpha1.Buildkit{Status: v1alpha1.BuildkitStatus{ConditionedStatus: api.ConditionedStatus{
+				Conditions: []api.Condition{{Type: v1alpha1.TypeFailed, Status: corev1.ConditionTrue}},
+			}}},
+			pod:  running,
+			want: v1alpha1.BuildkitPhaseFailed,
+		},
+		{
+			name: "pod being deleted",
+			obj:  &v1alpha1.Buildkit{},
+			pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-abcde", DeletionTimestamp: &now}},
+			want: v1alpha1.BuildkitPhaseTerminating,
+		},
+		{
+			name: "instance being deleted",
+			obj:  &v1alpha1.Buildkit{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			pod:  running,
+			want: v1alpha1.BuildkitPhaseTerminating,
+		},
+		{
+			name: "suspended",
+			obj:  &v1alpha1.Buildkit{Spec: v1alpha1.BuildkitSpec{Suspended: true}},
+			want: v1alpha1.BuildkitPhaseSuspended,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, buildkitPhase(tt.obj, tt.pod))
+		})
+	}
+}
+
+func TestSyncPodStatus(t *testing.T) {
+	t.Parallel()
+
+	started := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	ready := metav1.NewTime(started.Add(10 * time.Second))
+
+	obj := &v1alpha1.Buildkit{Status: v1alpha1.BuildkitStatus{Endpoint: "tcp://10.0.0.1:1234"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-abcde",
+			Annotations: map[string]string{v1alpha1.TemplateGenerationAnnotation: "3"},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{
+			PodIP:     "10.0.0.1",
+			PodIPs:    []corev1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00::1"}},
+			StartTime: &started,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: ready},
+			},
+		},
+	}
+
+	syncPodStatus(obj, pod)
+	assert.Equal(t, v1alpha1.BuildkitPhaseReady, obj.Status.Phase)
+	assert.Equal(t, "test-abcde", obj.Status.PodName)
+	assert.Equal(t, "10.0.0.1", obj.Status.PodIP)
+	assert.Equal(t, []string{"10.0.0.1", "fd00::1"}, obj.Status.PodIPs)
+	assert.Equal(t, "node-1", obj.Status.NodeName)
+	assert.Equal(t, &started, obj.Status.StartTime)
+	assert.Equal(t, &ready, obj.Status.ReadyTime)
+	assert.Equal(t, int64(3), obj.Status.TemplateGeneration)
+
+	obj.Status.Endpoint = ""
+	syncPodStatus(obj, nil)
+	assert.Equal(t, v1alpha1.BuildkitPhasePending, obj.Status.Phase)
+	assert.Empty(t, obj.Status.PodName)
+	assert.Empty(t, obj.Status.PodIP)
+	assert.Empty(t, obj.Status.PodIPs)
+	assert.Empty(t, obj.Status.NodeName)
+	assert.Nil(t, obj.Status.StartTime)
+	assert.Nil(t, obj.Status.ReadyTime)
+	assert.Zero(t, obj.Status.TemplateGeneration)
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/merge"
+)
+
+// annotationCacheHolder records which Buildkit instance currently holds a retained build cache PVC.
+const annotationCacheHolder = "buildkit.seatgeek.io/cache-holder"
+
+// errCacheInUse indicates that a retained build cache PVC is still held by another Buildkit instance or mounted by its pod.
+var errCacheInUse = errors.New("build cache is in use")
+
+// ensureCacheVolume creates the PVC holding the build cache if the template uses PersistentVolumeClaim storage.
+// PVCs that are deleted with the instance are applied as managed resources, so they're owned by the Buildkit.
+// Retained PVCs are owned by the template instead and handed from one instance to the next by cache key.
+// For a ClusterBuildkitTemplate, that's the cluster-scoped template rather than the namespaced view of it.
+//
+// Retained PVCs are created directly rather than through the output set on purpose: everything applied through it
+// becomes a managed resource controlled by the Buildkit, which would have the garbage collector delete the cache along
+// with the instance it's meant to outlive. The template's finalizer waits for their pods to let go of them before the
+// template, and with it the caches, can go away.
+func (r *reconciler) ensureCacheVolume(ctx context.Context, obj *v1alpha1.Buildkit, builder *Builder, template *v1alpha1.BuildkitTemplate, out *types.OutputSet, log *zap.SugaredLogger) error {
+	if storageType(template) != v1alpha1.StorageTypePersistentVolumeClaim {
+		return nil
+	}
+
+	desired := builder.BuildCacheClaim(template)
+
+	var existing corev1.PersistentVolumeClaim
+	err := r.c.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get build cache PVC '%s': %w", desired.Name, err)
+	}
+	found := err == nil
+
+	if template.Spec.Storage.RetentionPolicy != v1alpha1.StorageRetentionPolicyRetain {
+		// The PVC spec is largely immutable, so there's nothing to do once it exists
+		if !found {
+			log.Infow("Creating build cache volume", "pvc", desired.Name)
+			out.Apply(desired)
+		}
+
+		return nil
+	}
+
+	if !found {
+		desired.Annotations = merge.Maps(desired.Annotations, map[string]string{annotationCacheHolder: obj.Name})
+		if err := controllerutil.SetOwnerReference(builder.templateOwner, desired, r.scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference on build cache PVC '%s': %w", desired.Name, err)
+		}
+
+		log.Infow("Creating retained build cache volume", "pvc", desired.Name)
+		if err := r.c.Create(ctx, desired); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Another instance with the same cache key got there first
+				return errCacheInUse
+			}
+
+			return fmt.Errorf("failed to create build cache PVC '%s': %w", desired.Name, err)
+		}
+
+		return nil
+	}
+
+	return r.acquireRetainedCache(ctx, obj, &existing, log)
+}
+
+// acquireRetainedCache makes the Buildkit instance the holder of an existing retained build cache PVC.
+// It returns errCacheInUse while another instance holds the PVC or an earlier pod still mounts it.
+func (r *reconciler) acquireRetainedCache(ctx context.Context, obj *v1alpha1.Buildkit, pvc *corev1.PersistentVolumeClaim, log *zap.SugaredLogger) error {
+	// buildkitd locks its state directory, so wait for any other Buildkit pod to let go of the volume
+	var pods corev1.PodList
+	if err := r.c.List(ctx, &pods, client.InNamespace(obj.Namespace), client.HasLabels{v1alpha1.InstanceLabel}); err != nil {
+		return fmt.Errorf("failed to list Buildkit pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if metav1.IsControlledBy(&pod, obj) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		if slices.ContainsFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool {
+			return volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name
+		}) {
+			log.Debugw("Build cache volume is still mounted by another pod", "pvc", pvc.Name, "pod", pod.Name)
+			return errCacheInUse
+		}
+	}
+
+	holder := pvc.Annotations[annotationCacheHolder]
+	if holder == obj.Name {
+		return nil
+	}
+
+	if holder != "" {
+		var current v1alpha1.Buildkit
+		err := r.c.Get(ctx, client.ObjectKey{Name: holder, Namespace: obj.Namespace}, &current)
+		if err == nil && current.DeletionTimestamp == nil {
+			log.Debugw("Build cache volume is held by another instance", "pvc", pvc.Name, "holder", holder)
+			return errCacheInUse
+		} else if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Buildkit '%s' holding build cache PVC '%s': %w", holder, pvc.Name, err)
+		}
+	}
+
+	// The optimistic lock ensures only one waiting instance takes over the PVC
+	updated := pvc.DeepCopy()
+	updated.Annotations = merge.Maps(pvc.Annotations, map[string]string{annotationCacheHolder: obj.Name})
+	if err := r.c.Patch(ctx, updated, client.MergeFromWithOptions(pvc, client.MergeFromWithOptimisticLock{})); err != nil {
+		if apierrors.IsConflict(err) {
+			return errCacheInUse
+		}
+
+		return fmt.Errorf("failed to take over build cache PVC '%s': %w", pvc.Name, err)
+	}
+
+	log.Infow("Reusing retained build cache volume", "pvc", pvc.Name, "previousHolder", holder)
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// markTemplateMissing records that a Buildkit's template, or the pool it belongs to, can't be found. A pod the
+// Buildkit already has keeps running, but no new one can be started until the template is back.
+func (r *reconciler) markTemplateMissing(obj *v1alpha1.Buildkit, err error) {
+	message := fmt.Sprintf("Template could not be found: %s", err)
+	if obj.GetCondition(v1alpha1.TypeTemplateMissing).Status != corev1.ConditionTrue {
+		r.recorder.Event(obj, corev1.EventTypeWarning, v1alpha1.EventReasonTemplateMissing, message)
+	}
+
+	setCondition(obj, v1alpha1.TypeTemplateMissing, corev1.ConditionTrue, "NotFound", message)
+}
+
+// clearTemplateMissing records that a Buildkit's template can be found again.
+func clearTemplateMissing(obj *v1alpha1.Buildkit) {
+	if obj.GetCondition(v1alpha1.TypeTemplateMissing).Status != corev1.ConditionTrue {
+		return
+	}
+
+	setCondition(obj, v1alpha1.TypeTemplateMissing, corev1.ConditionFalse, "Found", "Template was found")
+}
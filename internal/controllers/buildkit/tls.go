@@ -0,0 +1,189 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
+	"github.com/seatgeek/buildkit-operator/internal/pki"
+)
+
+// annotationServerCertificate records the fingerprint of the server certificate a pod was started with,
+// since buildkitd only reads its certificates at startup.
+const annotationServerCertificate = "buildkit.seatgeek.io/server-certificate"
+
+// errCANotReady indicates that the BuildkitTemplate controller has not yet created the CA Secret.
+var errCANotReady = errors.New("certificate authority is not ready")
+
+// ensureTLSSecrets issues or rotates the server and client certificates for a Buildkit instance whose template enables TLS.
+// It returns the fingerprint of the current server certificate, or an empty string if TLS is disabled.
+// Secrets are only enqueued for apply when their contents actually need to change.
+func (r *reconciler) ensureTLSSecrets(ctx context.Context, obj *v1alpha1.Buildkit, builder *Builder, template *v1alpha1.BuildkitTemplate, out *types.OutputSet, log *zap.SugaredLogger) (string, error) {
+	if template.Spec.TLS == nil {
+		if obj.Status.ClientTLSSecretName != "" {
+			log.Info("TLS disabled by template, removing certificates")
+			for _, name := range []string{builder.serverTLSSecretName(), builder.clientTLSSecretName()} {
+				out.DeleteByRef(api.TypedObjectRef{
+					Version:   "v1",
+					Kind:      "Secret",
+					Name:      name,
+					Namespace: obj.Namespace,
+				})
+			}
+			obj.Status.ClientTLSSecretName = ""
+		}
+
+		return "", nil
+	}
+
+	ca, err := r.getCertificateAuthority(ctx, template)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	validity := cmp.Or(template.Spec.TLS.CertificateDuration.Duration, v1alpha1.DefaultTLSCertificateDuration)
+	renewBefore := cmp.Or(template.Spec.TLS.RenewBefore.Duration, v1alpha1.DefaultTLSRenewBefore)
+
+	serverCertPEM, err := r.ensureCertificateSecret(ctx, obj, builder.serverTLSSecretName(), ca, pki.CertificateRequest{
+		CommonName:  obj.Name,
+		DNSNames:    serverDNSNames(obj),
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+		Usage:       pki.UsageServer,
+		Validity:    validity,
+	}, renewBefore, now, out, log)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := r.ensureCertificateSecret(ctx, obj, builder.clientTLSSecretName(), ca, pki.CertificateRequest{
+		CommonName: fmt.Sprintf("%s/%s-client", obj.Namespace, obj.Name),
+		Usage:      pki.UsageClient,
+		Validity:   validity,
+	}, renewBefore, now, out, log); err != nil {
+		return "", err
+	}
+
+	obj.Status.ClientTLSSecretName = builder.clientTLSSecretName()
+
+	return pki.Fingerprint(serverCertPEM), nil
+}
+
+// getCertificateAuthority loads the CA maintained by the BuildkitTemplate controller.
+func (r *reconciler) getCertificateAuthority(ctx context.Context, template *v1alpha1.BuildkitTemplate) (*pki.KeyPair, error) {
+	name := buildkit_template.NewBuilder(template).CASecretName()
+
+	var secret corev1.Secret
+	if err := r.c.Get(ctx, client.ObjectKey{Name: name, Namespace: template.Namespace}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errCANotReady
+		}
+
+		return nil, fmt.Errorf("failed to get certificate authority secret '%s': %w", name, err)
+	}
+
+	ca, err := pki.ParseKeyPair(secret.Data[pki.TLSCertKey], secret.Data[pki.TLSKeyKey])
+	if err != nil {
+		// The BuildkitTemplate controller will replace an invalid CA on its next reconcile
+		return nil, fmt.Errorf("%w: %w", errCANotReady, err)
+	}
+
+	return ca, nil
+}
+
+// ensureCertificateSecret re-issues the certificate in the named Secret if it is missing, close to expiry,
+// or was signed by a different CA. It returns the PEM-encoded certificate that will be in effect.
+func (r *reconciler) ensureCertificateSecret(ctx context.Context, obj *v1alpha1.Buildkit, name string, ca *pki.KeyPair, req pki.CertificateRequest, renewBefore time.Duration, now time.Time, out *types.OutputSet, log *zap.SugaredLogger) ([]byte, error) {
+	var existing corev1.Secret
+	if err := r.c.Get(ctx, client.ObjectKey{Name: name, Namespace: obj.Namespace}, &existing); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get TLS secret '%s': %w", name, err)
+	}
+
+	if !pki.NeedsRenewal(existing.Data[pki.TLSCertKey], ca, renewBefore, now) && bytes.Equal(existing.Data[pki.CACertKey], ca.CertPEM) {
+		return existing.Data[pki.TLSCertKey], nil
+	}
+
+	log.Infow("issuing TLS certificate", "secret", name)
+	issued, err := ca.Issue(req, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate for '%s': %w", name, err)
+	}
+
+	out.Apply(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: obj.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			pki.CACertKey:  ca.CertPEM,
+			pki.TLSCertKey: issued.CertPEM,
+			pki.TLSKeyKey:  issued.KeyPEM,
+		},
+	})
+
+	return issued.CertPEM, nil
+}
+
+// clientTLSConfig loads the client certificate issued to a Buildkit instance, for the operator to connect to it.
+// It returns nil if the instance doesn't have TLS enabled.
+func (r *reconciler) clientTLSConfig(ctx context.Context, obj *v1alpha1.Buildkit) (*tls.Config, error) {
+	if obj.Status.ClientTLSSecretName == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	var secret corev1.Secret
+	if err := r.c.Get(ctx, client.ObjectKey{Name: obj.Status.ClientTLSSecretName, Namespace: obj.Namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get TLS secret '%s': %w", obj.Status.ClientTLSSecretName, err)
+	}
+
+	certificate, err := tls.X509KeyPair(secret.Data[pki.TLSCertKey], secret.Data[pki.TLSKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate in '%s': %w", obj.Status.ClientTLSSecretName, err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(secret.Data[pki.CACertKey]) {
+		return nil, fmt.Errorf("failed to parse CA bundle in '%s'", obj.Status.ClientTLSSecretName)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      roots,
+		ServerName:   obj.Name,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// serverDNSNames returns the DNS names the buildkitd server certificate is valid for.
+// These are the in-cluster Service names for the Buildkit instance, which is why instances using TLS publish their
+// Service address; clients connecting by pod IP have to set the TLS server name to one of them.
+func serverDNSNames(obj *v1alpha1.Buildkit) []string {
+	return []string{
+		obj.Name,
+		fmt.Sprintf("%s.%s", obj.Name, obj.Namespace),
+		fmt.Sprintf("%s.%s.svc", obj.Name, obj.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", obj.Name, obj.Namespace),
+		"localhost",
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_group
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// buildNode returns the Buildkit that builds for one of the group's platforms.
+func buildNode(group *v1alpha1.BuildkitGroup, platform *v1alpha1.BuildkitGroupPlatform) *v1alpha1.Buildkit {
+	return &v1alpha1.Buildkit{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      platform.NodeName(group.Name),
+			Namespace: group.Namespace,
+			Labels:    map[string]string{v1alpha1.GroupLabel: group.Name},
+		},
+		Spec: v1alpha1.BuildkitSpec{
+			Template:    platform.Template,
+			TemplateRef: platform.TemplateRef.DeepCopy(),
+		},
+	}
+}
+
+// invalidNodeName explains why a node's Buildkit can't be created under the given name, which the Buildkit webhook
+// requires to be a DNS-1035 label, or returns an empty string if it can.
+func invalidNodeName(name string) string {
+	return strings.Join(validation.IsDNS1035Label(name), "; ")
+}
+
+// usesPlatformTemplate reports whether a node's Buildkit was started from the template its platform currently asks
+// for. Templates can't be changed on a Buildkit, so one that wasn't has to be replaced.
+func usesPlatformTemplate(bk *v1alpha1.Buildkit, platform *v1alpha1.BuildkitGroupPlatform) bool {
+	spec := v1alpha1.BuildkitSpec{Template: platform.Template, TemplateRef: platform.TemplateRef}
+	current, desired := bk.Spec.DirectTemplateRef(), spec.DirectTemplateRef()
+
+	return current != nil && desired != nil && *current == *desired
+}
+
+// nodeStatus reports on the Buildkit of one of the group's platforms, which is nil until it has been created.
+func nodeStatus(platform *v1alpha1.BuildkitGroupPlatform, name string, bk *v1alpha1.Buildkit) v1alpha1.BuildkitGroupNode {
+	node := v1alpha1.BuildkitGroupNode{Platform: platform.Platform, Buildkit: name}
+	if bk == nil {
+		node.Phase = v1alpha1.BuildkitPhasePending
+		return node
+	}
+
+	node.Phase = bk.Status.Phase
+	node.Endpoint = bk.Status.Endpoint
+	node.ClientTLSSecretName = bk.Status.ClientTLSSecretName
+
+	return node
+}
+
+// invalidNamesMessage describes which of the group's Buildkits can't be created because of their names.
+func invalidNamesMessage(invalid []string) string {
+	return fmt.Sprintf("The group's name doesn't make for valid Buildkit names, rename it: %s", strings.Join(invalid, ", "))
+}
+
+// waitingMessage describes which of the group's platforms are still waiting for their Buildkit to become ready.
+func waitingMessage(waiting []string) string {
+	return fmt.Sprintf("Waiting for the nodes for %s to become ready", strings.Join(waiting, ", "))
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_group
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestBuildNode(t *testing.T) {
+	t.Parallel()
+
+	group := &v1alpha1.BuildkitGroup{ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "ci"}}
+	platform := &v1alpha1.BuildkitGroupPlatform{
+		Platform:    "linux/arm64/v8",
+		TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "arm64"},
+	}
+
+	bk := buildNode(group, platform)
+	assert.Equal(t, "multi-linux-arm64-v8", bk.Name)
+	assert.Equal(t, "ci", bk.Namespace)
+	assert.Equal(t, map[string]string{v1alpha1.GroupLabel: "multi"}, bk.Labels)
+	assert.Equal(t, platform.TemplateRef, bk.Spec.TemplateRef)
+	assert.NotSame(t, platform.TemplateRef, bk.Spec.TemplateRef)
+	assert.Empty(t, bk.Spec.Template)
+}
+
+func TestInvalidNodeName(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, invalidNodeName("multi-linux-arm64-v8"))
+	assert.Contains(t, invalidNodeName("ci.arm-linux-arm64"), "a DNS-1035 label must consist of lower case alphanumeric characters or '-'")
+	assert.Contains(t, invalidNodeName("1group-linux-arm64"), "a DNS-1035 label must consist of lower case alphanumeric characters or '-'")
+	assert.Equal(t, "must be no more than 63 characters", invalidNodeName(strings.Repeat("a", 52)+"-linux-arm64"))
+
+	assert.Equal(t, "The group's name doesn't make for valid Buildkit names, rename it: 1group-linux-amd64 (invalid)", invalidNamesMessage([]string{"1group-linux-amd64 (invalid)"}))
+}
+
+func TestUsesPlatformTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		spec     v1alpha1.BuildkitSpec
+		platform v1alpha1.BuildkitGroupPlatform
+		want     bool
+	}{
+		{
+			name:     "same template",
+			spec:     v1alpha1.BuildkitSpec{Template: "arm64"},
+			platform: v1alpha1.BuildkitGroupPlatform{Template: "arm64"},
+			want:     true,
+		},
+		{
+			name:     "template by either field",
+			spec:     v1alpha1.BuildkitSpec{Template: "arm64"},
+			platform: v1alpha1.BuildkitGroupPlatform{TemplateRef: &v1alpha1.TemplateReference{Name: "arm64"}},
+			want:     true,
+		},
+		{
+			name:     "other template",
+			spec:     v1alpha1.BuildkitSpec{Template: "arm64"},
+			platform: v1alpha1.BuildkitGroupPlatform{Template: "arm64-large"},
+		},
+		{
+			name:     "other kind",
+			spec:     v1alpha1.BuildkitSpec{Template: "arm64"},
+			platform: v1alpha1.BuildkitGroupPlatform{TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "arm64"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bk := &v1alpha1.Buildkit{Spec: tt.spec}
+			assert.Equal(t, tt.want, usesPlatformTemplate(bk, &tt.platform))
+		})
+	}
+}
+
+func TestNodeStatus(t *testing.T) {
+	t.Parallel()
+
+	platform := &v1alpha1.BuildkitGroupPlatform{Platform: "linux/amd64", Template: "amd64"}
+
+	assert.Equal(t, v1alpha1.BuildkitGroupNode{
+		Platform: "linux/amd64",
+		Buildkit: "multi-linux-amd64",
+		Phase:    v1alpha1.BuildkitPhasePending,
+	}, nodeStatus(platform, "multi-linux-amd64", nil))
+
+	bk := &v1alpha1.Buildkit{Status: v1alpha1.BuildkitStatus{
+		Phase:               v1alpha1.BuildkitPhaseReady,
+		Endpoint:            "tcp://multi-linux-amd64.ci.svc:1234",
+		ClientTLSSecretName: "multi-linux-amd64-client-tls",
+	}}
+	assert.Equal(t, v1alpha1.BuildkitGroupNode{
+		Platform:            "linux/amd64",
+		Buildkit:            "multi-linux-amd64",
+		Phase:               v1alpha1.BuildkitPhaseReady,
+		Endpoint:            "tcp://multi-linux-amd64.ci.svc:1234",
+		ClientTLSSecretName: "multi-linux-amd64-client-tls",
+	}, nodeStatus(platform, "multi-linux-amd64", bk))
+}
+
+func TestWaitingMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Waiting for the nodes for linux/arm64 to become ready", waitingMessage([]string{"linux/arm64"}))
+	assert.Equal(t, "Waiting for the nodes for linux/amd64, linux/arm64 to become ready", waitingMessage([]string{"linux/amd64", "linux/arm64"}))
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_group
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"github.com/reddit/achilles-sdk/pkg/io"
+	"github.com/reddit/achilles-sdk/pkg/logging"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controlplane"
+)
+
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitgroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitgroups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitgroups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkits,verbs=get;list;watch;create;update;patch;delete
+
+const controllerName = "BuildkitGroup"
+
+type state = types.State[*v1alpha1.BuildkitGroup]
+
+type reconciler struct {
+	c      *io.ClientApplicator
+	scheme *runtime.Scheme
+	log    *zap.SugaredLogger
+}
+
+func (r *reconciler) maintainNodes() *state {
+	return &state{
+		Name:      "maintain-nodes",
+		Condition: conditionReady,
+		Transition: func(ctx context.Context, obj *v1alpha1.BuildkitGroup, out *types.OutputSet) (*state, types.Result) {
+			log := r.log.With("name", obj.Name, "namespace", obj.Namespace)
+
+			var (
+				waiting   []string
+				conflicts []string
+				invalid   []string
+			)
+
+			wanted := make(map[string]bool, len(obj.Spec.Platforms))
+			obj.Status.Nodes = make([]v1alpha1.BuildkitGroupNode, 0, len(obj.Spec.Platforms))
+			obj.Status.ReadyNodes = 0
+
+			for i := range obj.Spec.Platforms {
+				platform := &obj.Spec.Platforms[i]
+				name := platform.NodeName(obj.Name)
+				wanted[name] = true
+
+				if problem := invalidNodeName(name); problem != "" {
+					log.Warnw("Can't create a Buildkit for platform under an invalid name", "platform", platform.Platform, "buildkit", name, "problem", problem)
+					invalid = append(invalid, fmt.Sprintf("%s (%s)", name, problem))
+					obj.Status.Nodes = append(obj.Status.Nodes, nodeStatus(platform, name, nil))
+					continue
+				}
+
+				bk, err := r.getNode(ctx, obj.Namespace, name)
+				if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+
+				switch {
+				case bk == nil:
+					log.Infow("Creating Buildkit for platform", "platform", platform.Platform, "buildkit", name)
+					out.Apply(buildNode(obj, platform))
+				case !metav1.IsControlledBy(bk, obj):
+					log.Warnw("A Buildkit not belonging to the group has the name of one of its nodes", "platform", platform.Platform, "buildkit", name)
+					conflicts = append(conflicts, name)
+					bk = nil
+				case !usesPlatformTemplate(bk, platform) && bk.DeletionTimestamp == nil:
+					// It's recreated from the new template once it's gone
+					log.Infow("Replacing Buildkit to use the platform's new template", "platform", platform.Platform, "buildkit", name)
+					out.Delete(bk)
+				}
+
+				node := nodeStatus(platform, name, bk)
+				if node.Phase == v1alpha1.BuildkitPhaseReady {
+					obj.Status.ReadyNodes++
+				} else {
+					waiting = append(waiting, platform.Platform)
+				}
+				obj.Status.Nodes = append(obj.Status.Nodes, node)
+			}
+
+			if err := r.dropRemovedPlatforms(ctx, obj, wanted, out, log); err != nil {
+				return nil, types.ErrorResult(err)
+			}
+
+			if out.GetApplied().Len() > 0 || out.GetDeleted().Len() > 0 {
+				return nil, types.Result{
+					Done:                   true,
+					RequeueAfterCompletion: true,
+					RequeueMsg:             "Applying changes",
+					Reason:                 "ApplyingChanges",
+				}
+			}
+
+			if len(invalid) > 0 {
+				return nil, types.RequeueResultWithReasonAndBackoff(invalidNamesMessage(invalid), "InvalidNodeName")
+			}
+
+			if len(conflicts) > 0 {
+				return nil, types.RequeueResultWithReasonAndBackoff(fmt.Sprintf("Buildkits named after the group's nodes already exist and don't belong to it: %s", strings.Join(conflicts, ", ")), "NodeConflict")
+			}
+
+			if len(waiting) > 0 {
+				log.Debugw("Waiting for nodes to become ready", "platforms", waiting)
+				return nil, types.RequeueResultWithReasonAndBackoff(waitingMessage(waiting), "NodesNotReady")
+			}
+
+			return nil, types.DoneResult()
+		},
+	}
+}
+
+// getNode returns the Buildkit with the given name, or nil if there's none.
+func (r *reconciler) getNode(ctx context.Context, namespace, name string) (*v1alpha1.Buildkit, error) {
+	var bk v1alpha1.Buildkit
+	if err := r.c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &bk); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get Buildkit '%s': %w", name, err)
+	}
+
+	return &bk, nil
+}
+
+// dropRemovedPlatforms enqueues the group's Buildkits whose platform is no longer listed for deletion.
+func (r *reconciler) dropRemovedPlatforms(ctx context.Context, obj *v1alpha1.BuildkitGroup, wanted map[string]bool, out *types.OutputSet, log *zap.SugaredLogger) error {
+	var buildkits v1alpha1.BuildkitList
+	if err := r.c.List(ctx, &buildkits, client.InNamespace(obj.Namespace), client.MatchingLabels{v1alpha1.GroupLabel: obj.Name}); err != nil {
+		return fmt.Errorf("failed to list group Buildkits: %w", err)
+	}
+
+	for _, bk := range buildkits.Items {
+		if wanted[bk.Name] || bk.DeletionTimestamp != nil || !metav1.IsControlledBy(&bk, obj) {
+			continue
+		}
+
+		log.Infow("Removing Buildkit of a platform no longer in the group", "buildkit", bk.Name)
+		out.Delete(&bk)
+	}
+
+	return nil
+}
+
+func SetupController(
+	ctx context.Context,
+	cpCtx controlplane.Context,
+	mgr ctrl.Manager,
+	rl workqueue.TypedRateLimiter[reconcile.Request],
+	c *io.ClientApplicator,
+) error {
+	_, log, err := logging.ControllerCtx(ctx, controllerName)
+	if err != nil {
+		return err
+	}
+
+	r := &reconciler{
+		c:      c,
+		scheme: mgr.GetScheme(),
+		log:    log,
+	}
+
+	builder := fsm.NewBuilder(
+		&v1alpha1.BuildkitGroup{},
+		r.maintainNodes(),
+		mgr.GetScheme(),
+	).Manages(
+		v1alpha1.SchemeGroupVersion.WithKind("Buildkit"),
+	)
+
+	return builder.Build()(mgr, log, rl, cpCtx.Metrics)
+}
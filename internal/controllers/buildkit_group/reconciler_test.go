@@ -0,0 +1,190 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_group_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/reddit/achilles-sdk-api/api"
+	sdktest "github.com/reddit/achilles-sdk/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	. "github.com/seatgeek/buildkit-operator/internal/test/matchers"
+)
+
+var _ = Describe("BuildkitGroup Reconciler", func() {
+	var (
+		namespace string
+		group     *v1alpha1.BuildkitGroup
+	)
+
+	getNode := func(g Gomega, name string) *v1alpha1.Buildkit {
+		var bk v1alpha1.Buildkit
+		g.Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &bk)).To(Succeed())
+		return &bk
+	}
+
+	markReady := func(name, endpoint string) {
+		Eventually(func(g Gomega) {
+			bk := getNode(g, name)
+			bk.Status.Phase = v1alpha1.BuildkitPhaseReady
+			bk.Status.Endpoint = endpoint
+			g.Expect(c.Status().Update(ctx, bk)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		namespace = fmt.Sprintf("reconciler-test-%s", sdktest.GenerateRandomString(8))
+		Expect(c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+
+		group = &v1alpha1.BuildkitGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-group",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitGroupSpec{
+				Platforms: []v1alpha1.BuildkitGroupPlatform{
+					{Platform: "linux/amd64", Template: "amd64"},
+					{Platform: "linux/arm64", Template: "arm64"},
+				},
+			},
+		}
+
+		DeferCleanup(func() {
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitGroup{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.Buildkit{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+		})
+	})
+
+	It("should start one Buildkit per platform and become ready once all of them are", func() {
+		By("creating a BuildkitGroup")
+		Expect(c.Create(ctx, group)).To(Succeed())
+
+		By("verifying a Buildkit is created for each platform")
+		Eventually(func(g Gomega) {
+			amd64 := getNode(g, "test-group-linux-amd64")
+			g.Expect(amd64.Spec.Template).To(Equal("amd64"))
+			g.Expect(amd64.Labels).To(HaveKeyWithValue(v1alpha1.GroupLabel, group.Name))
+			g.Expect(metav1.IsControlledBy(amd64, group)).To(BeTrue())
+
+			arm64 := getNode(g, "test-group-linux-arm64")
+			g.Expect(arm64.Spec.Template).To(Equal("arm64"))
+		}).Should(Succeed())
+
+		By("verifying the group is not ready while only some of its nodes are")
+		markReady("test-group-linux-amd64", "tcp://test-group-linux-amd64.ns.svc:1234")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitGroup
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(group), &updated)).To(Succeed())
+			g.Expect(updated.Status.ReadyNodes).To(Equal(int32(1)))
+			g.Expect(updated.GetCondition(api.TypeReady)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: "NodesNotReady",
+			}))
+		}).Should(Succeed())
+
+		By("verifying the group is ready and lists every endpoint once all of its nodes are")
+		markReady("test-group-linux-arm64", "tcp://test-group-linux-arm64.ns.svc:1234")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitGroup
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(group), &updated)).To(Succeed())
+			g.Expect(updated.Status.ReadyNodes).To(Equal(int32(2)))
+			g.Expect(updated.Status.Nodes).To(Equal([]v1alpha1.BuildkitGroupNode{
+				{Platform: "linux/amd64", Buildkit: "test-group-linux-amd64", Phase: v1alpha1.BuildkitPhaseReady, Endpoint: "tcp://test-group-linux-amd64.ns.svc:1234"},
+				{Platform: "linux/arm64", Buildkit: "test-group-linux-arm64", Phase: v1alpha1.BuildkitPhaseReady, Endpoint: "tcp://test-group-linux-arm64.ns.svc:1234"},
+			}))
+			g.Expect(updated.GetCondition(api.TypeReady).Status).To(Equal(corev1.ConditionTrue))
+		}).Should(Succeed())
+	})
+
+	It("should remove the Buildkit of a platform dropped from the group", func() {
+		Expect(c.Create(ctx, group)).To(Succeed())
+		Eventually(func(g Gomega) {
+			getNode(g, "test-group-linux-arm64")
+		}).Should(Succeed())
+
+		By("dropping linux/arm64 from the group")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(group), group)).To(Succeed())
+			group.Spec.Platforms = group.Spec.Platforms[:1]
+			g.Expect(c.Update(ctx, group)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var buildkits v1alpha1.BuildkitList
+			g.Expect(c.List(ctx, &buildkits, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(buildkits.Items).To(HaveLen(1))
+			g.Expect(buildkits.Items[0].Name).To(Equal("test-group-linux-amd64"))
+		}).Should(Succeed())
+	})
+
+	It("should replace a node's Buildkit when its platform's template changes", func() {
+		Expect(c.Create(ctx, group)).To(Succeed())
+
+		var original *v1alpha1.Buildkit
+		Eventually(func(g Gomega) {
+			original = getNode(g, "test-group-linux-arm64")
+		}).Should(Succeed())
+
+		By("changing the template of linux/arm64")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(group), group)).To(Succeed())
+			group.Spec.Platforms[1].Template = "arm64-large"
+			g.Expect(c.Update(ctx, group)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			bk := getNode(g, "test-group-linux-arm64")
+			g.Expect(bk.UID).NotTo(Equal(original.UID))
+			g.Expect(bk.Spec.Template).To(Equal("arm64-large"))
+		}).Should(Succeed())
+	})
+
+	It("should reject names its Buildkits can't be given", func() {
+		for _, name := range []string{"ci.arm", "1group"} {
+			group.Name = name
+			Expect(c.Create(ctx, group)).To(MatchError(ContainSubstring("name must be a DNS-1035 label")))
+		}
+
+		// Node names like <name>-linux-amd64 may have up to 63 characters
+		group.Name = strings.Repeat("a", 63-len("-linux-amd64")+1)
+		Expect(c.Create(ctx, group)).To(MatchError(ContainSubstring("name is too long")))
+
+		group.Name = strings.Repeat("a", 63-len("-linux-amd64"))
+		Expect(c.Create(ctx, group)).To(Succeed())
+	})
+
+	It("should leave alone a Buildkit that doesn't belong to the group", func() {
+		By("creating a Buildkit with the name of one of the group's nodes")
+		Expect(c.Create(ctx, &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-group-linux-arm64", Namespace: namespace},
+			Spec:       v1alpha1.BuildkitSpec{Template: "other"},
+		})).To(Succeed())
+
+		Expect(c.Create(ctx, group)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitGroup
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(group), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(api.TypeReady)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: "NodeConflict",
+			}))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			bk := getNode(g, "test-group-linux-arm64")
+			g.Expect(bk.Spec.Template).To(Equal("other"))
+			g.Expect(bk.OwnerReferences).To(BeEmpty())
+		}, "2s", "100ms").Should(Succeed())
+	})
+})
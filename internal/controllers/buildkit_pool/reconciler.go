@@ -0,0 +1,253 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_pool
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"github.com/reddit/achilles-sdk/pkg/io"
+	"github.com/reddit/achilles-sdk/pkg/logging"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit"
+	"github.com/seatgeek/buildkit-operator/internal/controlplane"
+	buildkitmetrics "github.com/seatgeek/buildkit-operator/internal/metrics"
+)
+
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitpools,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitpools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitpools/finalizers,verbs=update
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkittemplates,verbs=get;list;watch
+//+kubebuilder:rbac:resources=pods,verbs=get;list;watch;create;update;patch;delete
+
+const controllerName = "BuildkitPool"
+
+type state = types.State[*v1alpha1.BuildkitPool]
+
+type reconciler struct {
+	c       *io.ClientApplicator
+	scheme  *runtime.Scheme
+	log     *zap.SugaredLogger
+	metrics *buildkitmetrics.Metrics
+}
+
+func (r *reconciler) maintainPool() *state {
+	return &state{
+		Name:      "maintain-pool",
+		Condition: conditionReady,
+		Transition: func(ctx context.Context, obj *v1alpha1.BuildkitPool, out *types.OutputSet) (*state, types.Result) {
+			log := r.log.With("name", obj.Name, "namespace", obj.Namespace)
+
+			// Take stock of the pool's pods first so the status is accurate even if we can't start new ones
+			warm, starting, err := r.countPods(ctx, obj, out, log)
+			if err != nil {
+				return nil, types.ErrorResult(err)
+			}
+
+			builder := buildkit.NewPoolBuilder(obj, r.c.Client)
+			template, err := builder.Template(ctx)
+			if apierrors.IsNotFound(err) {
+				log.Debugw("BuildkitTemplate not found", "template", obj.Spec.Template)
+				return nil, types.RequeueResultWithReasonAndBackoff(fmt.Sprintf("BuildkitTemplate '%s' not found", obj.Spec.Template), "TemplateNotFound")
+			} else if err != nil {
+				return nil, types.ErrorResult(fmt.Errorf("failed to get BuildkitTemplate '%s': %w", obj.Spec.Template, err))
+			}
+
+			if reason := buildkit.PoolIncompatibility(template); reason != "" {
+				return nil, types.Result{
+					Done: true,
+					CustomStatusCondition: &types.ResultStatusCondition{
+						Reason:  "TemplateUnsupported",
+						Status:  corev1.ConditionFalse,
+						Message: fmt.Sprintf("BuildkitTemplate '%s' can't be used for warm pods: %s", template.Name, reason),
+					},
+				}
+			}
+
+			// Warm pods aren't running any builds, so replace those started from an older version of the template
+			// straight away if the template's update strategy lets the operator replace pods at all
+			if buildkit.ReplacesOutOfDatePods(template) {
+				warm, err = r.dropOutOfDate(warm, template, out, log)
+				if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+
+				starting, err = r.dropOutOfDate(starting, template, out, log)
+				if err != nil {
+					return nil, types.ErrorResult(err)
+				}
+			}
+
+			switch missing := int(obj.Spec.Size) - len(warm) - len(starting); {
+			case missing > 0:
+				// Pods have generated names, so only one can be enqueued per pass
+				pod, err := builder.BuildPod(ctx)
+				if err != nil {
+					return nil, types.ErrorResult(fmt.Errorf("failed to build warm pod: %w", err))
+				}
+
+				log.Infow("Starting warm pod", "missing", missing)
+				out.Apply(pod)
+			case missing < 0:
+				// Shrink the pool, giving up on pods that are still starting before ready ones
+				surplus := slices.Concat(starting, warm)[:-missing]
+				log.Infow("Removing surplus warm pods", "count", len(surplus))
+				for _, pod := range surplus {
+					out.Delete(&pod)
+				}
+			}
+
+			if out.GetApplied().Len() > 0 || out.GetDeleted().Len() > 0 {
+				return nil, types.Result{
+					Done:                   true,
+					RequeueAfterCompletion: true,
+					RequeueMsg:             "Applying changes",
+					Reason:                 "ApplyingChanges",
+				}
+			}
+
+			if len(starting) > 0 {
+				log.Debugw("Waiting for warm pods to become ready", "starting", len(starting))
+				return nil, types.RequeueResultWithReasonAndBackoff("Waiting for warm pods to become ready", "PodsStarting")
+			}
+
+			return nil, types.DoneResult()
+		},
+	}
+}
+
+// countPods lists the pods started by the pool and records how many are warm, starting and bound in its status.
+// Unclaimed pods that have terminated are enqueued for deletion so that they get replaced.
+// It returns the unclaimed pods that are warm and starting, respectively.
+func (r *reconciler) countPods(ctx context.Context, obj *v1alpha1.BuildkitPool, out *types.OutputSet, log *zap.SugaredLogger) ([]corev1.Pod, []corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := r.c.List(ctx, &pods, client.InNamespace(obj.Namespace), client.MatchingLabels{v1alpha1.PoolLabel: obj.Name}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list pool pods: %w", err)
+	}
+
+	var warm, starting []corev1.Pod
+	obj.Status.Bound = 0
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		if _, claimed := pod.Labels[v1alpha1.InstanceLabel]; claimed {
+			obj.Status.Bound++
+			continue
+		}
+
+		if !metav1.IsControlledBy(&pod, obj) {
+			// Left behind by an earlier pool of the same name
+			continue
+		}
+
+		switch {
+		case pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded:
+			log.Warnw("Warm pod has terminated, replacing it", "pod", pod.Name, "phase", pod.Status.Phase, "reason", pod.Status.Reason)
+			if pod.Status.Phase == corev1.PodFailed {
+				r.metrics.RecordPodFailure(obj.Namespace, obj.Spec.Template)
+			}
+			out.Delete(&pod)
+		case buildkit.IsPodReady(&pod):
+			warm = append(warm, pod)
+		default:
+			starting = append(starting, pod)
+		}
+	}
+
+	obj.Status.Warm = int32(len(warm))         //nolint:gosec // bounded by the pool size
+	obj.Status.Starting = int32(len(starting)) //nolint:gosec // bounded by the pool size
+
+	return warm, starting, nil
+}
+
+// dropOutOfDate enqueues pods started from an older version of the template for deletion,
+// returning the remaining ones.
+func (r *reconciler) dropOutOfDate(pods []corev1.Pod, template *v1alpha1.BuildkitTemplate, out *types.OutputSet, log *zap.SugaredLogger) ([]corev1.Pod, error) {
+	current := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		outOfDate, err := buildkit.IsPodOutOfDate(&pod, template)
+		if err != nil {
+			return nil, err
+		}
+
+		if outOfDate {
+			log.Infow("Replacing warm pod to pick up BuildkitTemplate changes", "pod", pod.Name)
+			r.metrics.RecordReplacement(pod.Namespace, template.Name, buildkitmetrics.ReplacementTemplateChanged)
+			out.Delete(&pod)
+			continue
+		}
+
+		current = append(current, pod)
+	}
+
+	return current, nil
+}
+
+// poolsForTemplate maps a BuildkitTemplate to the BuildkitPools that use it, so that template changes reach warm pods.
+func (r *reconciler) poolsForTemplate(ctx context.Context, template client.Object) []reconcile.Request {
+	var pools v1alpha1.BuildkitPoolList
+	if err := r.c.List(ctx, &pools, client.InNamespace(template.GetNamespace())); err != nil {
+		r.log.Errorw("Failed to list BuildkitPools for BuildkitTemplate", "template", template.GetName(), "error", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, pool := range pools.Items {
+		if pool.Spec.Template == template.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pool)})
+		}
+	}
+
+	return requests
+}
+
+func SetupController(
+	ctx context.Context,
+	cpCtx controlplane.Context,
+	mgr ctrl.Manager,
+	rl workqueue.TypedRateLimiter[reconcile.Request],
+	c *io.ClientApplicator,
+) error {
+	_, log, err := logging.ControllerCtx(ctx, controllerName)
+	if err != nil {
+		return err
+	}
+
+	r := &reconciler{
+		c:       c,
+		scheme:  mgr.GetScheme(),
+		log:     log,
+		metrics: cpCtx.BuildkitMetrics,
+	}
+
+	builder := fsm.NewBuilder(
+		&v1alpha1.BuildkitPool{},
+		r.maintainPool(),
+		mgr.GetScheme(),
+	).Manages(
+		corev1.SchemeGroupVersion.WithKind("Pod"),
+	).Watches(
+		&v1alpha1.BuildkitTemplate{},
+		handler.EnqueueRequestsFromMapFunc(r.poolsForTemplate),
+	)
+
+	return builder.Build()(mgr, log, rl, cpCtx.Metrics)
+}
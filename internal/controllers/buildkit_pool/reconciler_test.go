@@ -0,0 +1,240 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_pool_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/reddit/achilles-sdk-api/api"
+	sdktest "github.com/reddit/achilles-sdk/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	. "github.com/seatgeek/buildkit-operator/internal/test/matchers"
+)
+
+var _ = Describe("BuildkitPool Reconciler", func() {
+	var (
+		namespace        string
+		buildkitTemplate *v1alpha1.BuildkitTemplate
+		pool             *v1alpha1.BuildkitPool
+	)
+
+	listPoolPods := func(g Gomega) []corev1.Pod {
+		var pods corev1.PodList
+		g.Expect(c.List(ctx, &pods, client.InNamespace(namespace), client.MatchingLabels{v1alpha1.PoolLabel: pool.Name})).To(Succeed())
+		return pods.Items
+	}
+
+	markReady := func(pod *corev1.Pod) {
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.PodIP = "10.0.0.1"
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name:  "buildkit",
+					Ready: true,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			}
+			g.Expect(c.Status().Update(ctx, pod)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		namespace = fmt.Sprintf("reconciler-test-%s", sdktest.GenerateRandomString(8))
+		Expect(c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+
+		buildkitTemplate = &v1alpha1.BuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-template",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitTemplateSpec{
+				Port: 1234,
+			},
+		}
+		Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+
+		pool = &v1alpha1.BuildkitPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pool",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitPoolSpec{
+				Template: buildkitTemplate.Name,
+				Size:     2,
+			},
+		}
+
+		DeferCleanup(func() {
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitPool{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitTemplate{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+		})
+	})
+
+	It("should keep the configured number of warm pods", func() {
+		By("creating a BuildkitPool")
+		Expect(c.Create(ctx, pool)).To(Succeed())
+
+		By("verifying warm pods are started")
+		var pods []corev1.Pod
+		Eventually(func(g Gomega) {
+			pods = listPoolPods(g)
+			g.Expect(pods).To(HaveLen(2))
+			for _, pod := range pods {
+				g.Expect(pod.Labels).NotTo(HaveKey(v1alpha1.InstanceLabel))
+				g.Expect(metav1.IsControlledBy(&pod, pool)).To(BeTrue())
+			}
+		}).Should(Succeed())
+
+		By("verifying the pool is not ready while its pods are starting")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitPool
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), &updated)).To(Succeed())
+			g.Expect(updated.Status.Starting).To(Equal(int32(2)))
+			g.Expect(updated.Status.Warm).To(BeZero())
+			g.Expect(updated.GetCondition(api.TypeReady).Status).To(Equal(corev1.ConditionFalse))
+		}).Should(Succeed())
+
+		By("simulating the pods becoming ready")
+		for i := range pods {
+			markReady(&pods[i])
+		}
+
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitPool
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), &updated)).To(Succeed())
+			g.Expect(updated.Status.Warm).To(Equal(int32(2)))
+			g.Expect(updated.Status.Starting).To(BeZero())
+			g.Expect(updated.GetCondition(api.TypeReady).Status).To(Equal(corev1.ConditionTrue))
+		}).Should(Succeed())
+	})
+
+	It("should refill the pool once a pod is claimed", func() {
+		pool.Spec.Size = 1
+		Expect(c.Create(ctx, pool)).To(Succeed())
+
+		var pod corev1.Pod
+		Eventually(func(g Gomega) {
+			pods := listPoolPods(g)
+			g.Expect(pods).To(HaveLen(1))
+			pod = pods[0]
+		}).Should(Succeed())
+		markReady(&pod)
+
+		By("simulating a Buildkit instance claiming the pod")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(&pod), &pod)).To(Succeed())
+			pod.Labels[v1alpha1.InstanceLabel] = "some-buildkit"
+			pod.OwnerReferences = nil
+			g.Expect(c.Update(ctx, &pod)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying a replacement is started and the claimed pod is counted as bound")
+		Eventually(func(g Gomega) {
+			g.Expect(listPoolPods(g)).To(HaveLen(2))
+
+			var updated v1alpha1.BuildkitPool
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), &updated)).To(Succeed())
+			g.Expect(updated.Status.Bound).To(Equal(int32(1)))
+			g.Expect(updated.Status.Starting).To(Equal(int32(1)))
+		}).Should(Succeed())
+	})
+
+	It("should replace warm pods that have failed", func() {
+		pool.Spec.Size = 1
+		Expect(c.Create(ctx, pool)).To(Succeed())
+
+		var pod corev1.Pod
+		Eventually(func(g Gomega) {
+			pods := listPoolPods(g)
+			g.Expect(pods).To(HaveLen(1))
+			pod = pods[0]
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(&pod), &pod)).To(Succeed())
+			pod.Status.Phase = corev1.PodFailed
+			g.Expect(c.Status().Update(ctx, &pod)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			pods := listPoolPods(g)
+			g.Expect(pods).To(HaveLen(1))
+			g.Expect(pods[0].Name).NotTo(Equal(pod.Name))
+		}).Should(Succeed())
+	})
+
+	It("should refuse templates that enable TLS", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.TLS = &v1alpha1.BuildkitTemplateTLS{
+				CertificateDuration: metav1.Duration{Duration: v1alpha1.DefaultTLSCertificateDuration},
+				RenewBefore:         metav1.Duration{Duration: v1alpha1.DefaultTLSRenewBefore},
+			}
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		Expect(c.Create(ctx, pool)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitPool
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(api.TypeReady)).To(MatchCondition(api.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: "TemplateUnsupported",
+			}))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(listPoolPods(g)).To(BeEmpty())
+		}, "2s", "100ms").Should(Succeed())
+	})
+
+	It("should replace warm pods when the template changes and its update strategy allows it", func() {
+		By("setting the Recreate update strategy on the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.UpdateStrategy = v1alpha1.UpdateStrategyRecreate
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		pool.Spec.Size = 1
+		Expect(c.Create(ctx, pool)).To(Succeed())
+
+		var pod corev1.Pod
+		Eventually(func(g Gomega) {
+			pods := listPoolPods(g)
+			g.Expect(pods).To(HaveLen(1))
+			pod = pods[0]
+		}).Should(Succeed())
+		markReady(&pod)
+
+		By("changing the template image")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.Image = "moby/buildkit:v0.23.2"
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the warm pod is replaced with one using the new image")
+		Eventually(func(g Gomega) {
+			pods := listPoolPods(g)
+			g.Expect(pods).To(HaveLen(1))
+			g.Expect(pods[0].Name).NotTo(Equal(pod.Name))
+			g.Expect(pods[0].Spec.Containers[0].Image).To(Equal("moby/buildkit:v0.23.2"))
+		}).Should(Succeed())
+	})
+})
@@ -0,0 +1,121 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_quota
+
+import (
+	"context"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"github.com/reddit/achilles-sdk/pkg/io"
+	"github.com/reddit/achilles-sdk/pkg/logging"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controlplane"
+)
+
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitquotas,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitquotas/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitquotas/finalizers,verbs=update
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkits,verbs=get;list;watch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitpools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkittemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=clusterbuildkittemplates,verbs=get;list;watch
+
+const controllerName = "BuildkitQuota"
+
+type state = types.State[*v1alpha1.BuildkitQuota]
+
+type reconciler struct {
+	c      *io.ClientApplicator
+	scheme *runtime.Scheme
+	log    *zap.SugaredLogger
+}
+
+func (r *reconciler) reportUsage() *state {
+	return &state{
+		Name:      "report-usage",
+		Condition: conditionReady,
+		Transition: func(ctx context.Context, obj *v1alpha1.BuildkitQuota, _ *types.OutputSet) (*state, types.Result) {
+			usage, err := Usage(ctx, r.c, obj.Namespace)
+			if err != nil {
+				return nil, types.ErrorResult(err)
+			}
+
+			obj.Status.Used = usage
+
+			return nil, types.DoneResult()
+		},
+	}
+}
+
+// quotasInNamespace maps a Buildkit or BuildkitTemplate to the BuildkitQuotas in its namespace,
+// whose usage it may have changed.
+func (r *reconciler) quotasInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.quotaRequests(ctx, client.InNamespace(obj.GetNamespace()))
+}
+
+// allQuotas maps a ClusterBuildkitTemplate to every BuildkitQuota, since Buildkits in any namespace may use it.
+func (r *reconciler) allQuotas(ctx context.Context, _ client.Object) []reconcile.Request {
+	return r.quotaRequests(ctx)
+}
+
+func (r *reconciler) quotaRequests(ctx context.Context, opts ...client.ListOption) []reconcile.Request {
+	var quotas v1alpha1.BuildkitQuotaList
+	if err := r.c.List(ctx, &quotas, opts...); err != nil {
+		r.log.Errorw("Failed to list BuildkitQuotas", "error", err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(quotas.Items))
+	for _, quota := range quotas.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&quota)})
+	}
+
+	return requests
+}
+
+func SetupController(
+	ctx context.Context,
+	cpCtx controlplane.Context,
+	mgr ctrl.Manager,
+	rl workqueue.TypedRateLimiter[reconcile.Request],
+	c *io.ClientApplicator,
+) error {
+	_, log, err := logging.ControllerCtx(ctx, controllerName)
+	if err != nil {
+		return err
+	}
+
+	r := &reconciler{
+		c:      c,
+		scheme: mgr.GetScheme(),
+		log:    log,
+	}
+
+	builder := fsm.NewBuilder(
+		&v1alpha1.BuildkitQuota{},
+		r.reportUsage(),
+		mgr.GetScheme(),
+	).Watches(
+		&v1alpha1.Buildkit{},
+		handler.EnqueueRequestsFromMapFunc(r.quotasInNamespace),
+	).Watches(
+		&v1alpha1.BuildkitTemplate{},
+		handler.EnqueueRequestsFromMapFunc(r.quotasInNamespace),
+	).Watches(
+		&v1alpha1.ClusterBuildkitTemplate{},
+		handler.EnqueueRequestsFromMapFunc(r.allQuotas),
+	)
+
+	return builder.Build()(mgr, log, rl, cpCtx.Metrics)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_quota_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktest "github.com/reddit/achilles-sdk/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+var _ = Describe("BuildkitQuota Reconciler", func() {
+	var (
+		namespace string
+		quota     *v1alpha1.BuildkitQuota
+	)
+
+	newBuildkit := func(name string) *v1alpha1.Buildkit {
+		return &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitSpec{
+				Template: "test-template",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		namespace = fmt.Sprintf("reconciler-test-%s", sdktest.GenerateRandomString(8))
+		Expect(c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+
+		Expect(c.Create(ctx, &v1alpha1.BuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-template",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitTemplateSpec{
+				Port: 1234,
+				Resources: v1alpha1.BuildkitTemplateResources{
+					Maximum: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			},
+		})).To(Succeed())
+
+		quota = &v1alpha1.BuildkitQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-quota",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitQuotaSpec{
+				Instances: new(int32(5)),
+			},
+		}
+		Expect(c.Create(ctx, quota)).To(Succeed())
+
+		DeferCleanup(func() {
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.Buildkit{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitQuota{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitTemplate{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+		})
+	})
+
+	It("should report the effective resources of the namespace's Buildkits", func() {
+		Expect(c.Create(ctx, newBuildkit("first"))).To(Succeed())
+		Expect(c.Create(ctx, newBuildkit("second"))).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(quota), quota)).To(Succeed())
+			g.Expect(quota.Status.Used.Instances).To(Equal(int32(2)))
+			g.Expect(quota.Status.Used.Requests.Memory().Equal(resource.MustParse("2Gi"))).To(BeTrue())
+			g.Expect(quota.Status.Used.Limits.Cpu().Equal(resource.MustParse("4"))).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("should stop counting deleted Buildkits", func() {
+		buildkit := newBuildkit("first")
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(quota), quota)).To(Succeed())
+			g.Expect(quota.Status.Used.Instances).To(Equal(int32(1)))
+		}).Should(Succeed())
+
+		Expect(c.Delete(ctx, buildkit)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(quota), quota)).To(Succeed())
+			g.Expect(quota.Status.Used.Instances).To(BeZero())
+			g.Expect(quota.Status.Used.Requests).To(BeEmpty())
+		}).Should(Succeed())
+	})
+})
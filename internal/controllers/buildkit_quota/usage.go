@@ -0,0 +1,212 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_quota
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit/resources"
+)
+
+// quotaResources are the resources a BuildkitQuota can cap.
+var quotaResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage}
+
+// EffectiveResources returns the resources a Buildkit's pod gets once its template's defaults and maximums are
+// applied, just as they are when the pod is built, and its requests default to its limits, as the API server does.
+// A nil template leaves the Buildkit's own resources as they are.
+func EffectiveResources(template *v1alpha1.BuildkitTemplateSpec, bk *v1alpha1.Buildkit) corev1.ResourceRequirements {
+	if template == nil {
+		return resources.WithDefaultRequests(resources.WithMaximums(nil, bk.Spec.Resources))
+	}
+
+	return resources.WithDefaultRequests(resources.WithMaximums(template.Resources.Maximum, template.Resources.Default, bk.Spec.Resources))
+}
+
+// Add counts one more Buildkit, with the given effective resources, towards the usage.
+func Add(usage *v1alpha1.BuildkitQuotaUsage, requirements corev1.ResourceRequirements) {
+	usage.Instances++
+	usage.Requests = addResources(usage.Requests, requirements.Requests)
+	usage.Limits = addResources(usage.Limits, requirements.Limits)
+}
+
+func addResources(total, list corev1.ResourceList) corev1.ResourceList {
+	for _, name := range quotaResources {
+		quantity, ok := list[name]
+		if !ok {
+			continue
+		}
+
+		if total == nil {
+			total = corev1.ResourceList{}
+		}
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+
+	return total
+}
+
+// Usage sums up what the Buildkits in a namespace use, counting each with its effective resources.
+// Buildkits that are being deleted no longer count, and those whose template can't be found count with their own
+// resources only.
+func Usage(ctx context.Context, c client.Reader, namespace string) (v1alpha1.BuildkitQuotaUsage, error) {
+	return UsageWithout(ctx, c, namespace, "")
+}
+
+// UsageWithout is like Usage, but leaves out the Buildkit with the given name, such as one being resized, so that it
+// can be counted with its new resources instead.
+func UsageWithout(ctx context.Context, c client.Reader, namespace, name string) (v1alpha1.BuildkitQuotaUsage, error) {
+	var usage v1alpha1.BuildkitQuotaUsage
+
+	var buildkits v1alpha1.BuildkitList
+	if err := c.List(ctx, &buildkits, client.InNamespace(namespace)); err != nil {
+		return usage, fmt.Errorf("failed to list Buildkits in namespace '%s': %w", namespace, err)
+	}
+
+	templates, err := loadTemplates(ctx, c, namespace)
+	if err != nil {
+		return usage, err
+	}
+
+	for _, bk := range buildkits.Items {
+		if bk.DeletionTimestamp != nil || bk.Name == name {
+			continue
+		}
+		Add(&usage, EffectiveResources(templates.forBuildkit(&bk), &bk))
+	}
+
+	return usage, nil
+}
+
+// Check returns what adding a Buildkit with the given effective resources to the usage would take over the quota's
+// caps, or nothing if the Buildkit fits.
+func Check(quota *v1alpha1.BuildkitQuota, usage v1alpha1.BuildkitQuotaUsage, requirements corev1.ResourceRequirements) []string {
+	var exceeded []string
+
+	if quota.Spec.Instances != nil && usage.Instances+1 > *quota.Spec.Instances {
+		exceeded = append(exceeded, fmt.Sprintf("instances: %d of %d used", usage.Instances, *quota.Spec.Instances))
+	}
+
+	return append(exceeded, CheckResources(quota, usage, requirements)...)
+}
+
+// CheckResources is like Check, but leaves out the instance count, for a Buildkit that's already counted as one.
+func CheckResources(quota *v1alpha1.BuildkitQuota, usage v1alpha1.BuildkitQuotaUsage, requirements corev1.ResourceRequirements) []string {
+	return append(
+		checkResources("requests", quota.Spec.Requests, usage.Requests, requirements.Requests),
+		checkResources("limits", quota.Spec.Limits, usage.Limits, requirements.Limits)...,
+	)
+}
+
+// Grows reports whether any request or limit a BuildkitQuota can cap is higher in the desired resources than in the
+// current ones, counting one that's newly set as higher.
+func Grows(current, desired corev1.ResourceRequirements) bool {
+	return growsList(current.Requests, desired.Requests) || growsList(current.Limits, desired.Limits)
+}
+
+func growsList(current, desired corev1.ResourceList) bool {
+	for _, name := range quotaResources {
+		quantity, ok := desired[name]
+		if !ok {
+			continue
+		}
+
+		if was, ok := current[name]; !ok || quantity.Cmp(was) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkResources(kind string, hard, used, requested corev1.ResourceList) []string {
+	var exceeded []string
+
+	for _, name := range quotaResources {
+		capped, ok := hard[name]
+		if !ok {
+			continue
+		}
+
+		quantity, ok := requested[name]
+		if !ok {
+			exceeded = append(exceeded, fmt.Sprintf("%s.%s: must be set, since it's capped at %s", kind, name, capped.String()))
+			continue
+		}
+
+		current := used[name]
+		total := current.DeepCopy()
+		total.Add(quantity)
+		if total.Cmp(capped) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s.%s: %s requested, %s of %s used", kind, name, quantity.String(), current.String(), capped.String()))
+		}
+	}
+
+	return exceeded
+}
+
+// templates holds the templates the Buildkits in a namespace may use, so that each is only loaded once.
+type templates struct {
+	namespaced map[string]*v1alpha1.BuildkitTemplateSpec
+	cluster    map[string]*v1alpha1.BuildkitTemplateSpec
+	pools      map[string]string
+}
+
+func loadTemplates(ctx context.Context, c client.Reader, namespace string) (*templates, error) {
+	t := &templates{
+		namespaced: map[string]*v1alpha1.BuildkitTemplateSpec{},
+		cluster:    map[string]*v1alpha1.BuildkitTemplateSpec{},
+		pools:      map[string]string{},
+	}
+
+	var namespaced v1alpha1.BuildkitTemplateList
+	if err := c.List(ctx, &namespaced, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list BuildkitTemplates in namespace '%s': %w", namespace, err)
+	}
+	for _, template := range namespaced.Items {
+		t.namespaced[template.Name] = &template.Spec
+	}
+
+	var cluster v1alpha1.ClusterBuildkitTemplateList
+	if err := c.List(ctx, &cluster); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterBuildkitTemplates: %w", err)
+	}
+	for _, template := range cluster.Items {
+		t.cluster[template.Name] = &template.Spec.BuildkitTemplateSpec
+	}
+
+	var pools v1alpha1.BuildkitPoolList
+	if err := c.List(ctx, &pools, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list BuildkitPools in namespace '%s': %w", namespace, err)
+	}
+	for _, pool := range pools.Items {
+		t.pools[pool.Name] = pool.Spec.Template
+	}
+
+	return t, nil
+}
+
+// forBuildkit returns the spec of the template a Buildkit uses, directly or through its pool, or nil if it's missing.
+func (t *templates) forBuildkit(bk *v1alpha1.Buildkit) *v1alpha1.BuildkitTemplateSpec {
+	if bk.Spec.Pool != "" {
+		return t.namespaced[t.pools[bk.Spec.Pool]]
+	}
+
+	ref := bk.Spec.DirectTemplateRef()
+	switch {
+	case ref == nil:
+		return nil
+	case ref.IsCluster():
+		return t.cluster[ref.Name]
+	default:
+		return t.namespaced[ref.Name]
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func requirements(requests, limits corev1.ResourceList) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{Requests: requests, Limits: limits}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	usage := v1alpha1.BuildkitQuotaUsage{
+		Instances: 2,
+		Requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		Limits:    corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+
+	tests := []struct {
+		name         string
+		spec         v1alpha1.BuildkitQuotaSpec
+		requirements corev1.ResourceRequirements
+		want         []string
+	}{
+		{
+			name:         "no caps",
+			requirements: requirements(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100")}, nil),
+		},
+		{
+			name: "room to spare",
+			spec: v1alpha1.BuildkitQuotaSpec{
+				Instances: new(int32(3)),
+				Requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+				Limits:    corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+			},
+			requirements: requirements(
+				corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			),
+		},
+		{
+			name:         "instances exhausted",
+			spec:         v1alpha1.BuildkitQuotaSpec{Instances: new(int32(2))},
+			requirements: requirements(nil, nil),
+			want:         []string{"instances: 2 of 2 used"},
+		},
+		{
+			name: "resources exceeded",
+			spec: v1alpha1.BuildkitQuotaSpec{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("6Gi")},
+			},
+			requirements: requirements(
+				corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+				corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			),
+			want: []string{
+				"requests.cpu: 1500m requested, 2 of 3 used",
+				"limits.memory: 4Gi requested, 4Gi of 6Gi used",
+			},
+		},
+		{
+			name: "capped resource not set",
+			spec: v1alpha1.BuildkitQuotaSpec{
+				Limits: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("100Gi")},
+			},
+			requirements: requirements(nil, nil),
+			want:         []string{"limits.ephemeral-storage: must be set, since it's capped at 100Gi"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			quota := &v1alpha1.BuildkitQuota{Spec: tt.spec}
+			assert.Equal(t, tt.want, Check(quota, usage, tt.requirements))
+		})
+	}
+}
+
+func TestEffectiveResources(t *testing.T) {
+	t.Parallel()
+
+	template := &v1alpha1.BuildkitTemplateSpec{
+		Resources: v1alpha1.BuildkitTemplateResources{
+			Maximum: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+	}
+	bk := &v1alpha1.Buildkit{Spec: v1alpha1.BuildkitSpec{
+		Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}},
+	}}
+
+	effective := EffectiveResources(template, bk)
+	assert.Equal(t, requirements(
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+	), effective)
+	assert.Nil(t, bk.Spec.Resources.Requests)
+
+	// A Buildkit that only sets limits requests them, rather than leaving a capped request unset
+	quota := &v1alpha1.BuildkitQuota{Spec: v1alpha1.BuildkitQuotaSpec{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+	}}
+	assert.Empty(t, Check(quota, v1alpha1.BuildkitQuotaUsage{}, effective))
+	assert.Equal(t, []string{"requests.cpu: 2 requested, 2 of 3 used"}, Check(quota, v1alpha1.BuildkitQuotaUsage{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+	}, effective))
+}
+
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	now := metav1.Now()
+	objects := []runtime.Object{
+		&v1alpha1.BuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "capped", Namespace: "test-ns"},
+			Spec: v1alpha1.BuildkitTemplateSpec{
+				Resources: v1alpha1.BuildkitTemplateResources{
+					Default: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+					Maximum: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			},
+		},
+		&v1alpha1.ClusterBuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+			Spec: v1alpha1.ClusterBuildkitTemplateSpec{
+				BuildkitTemplateSpec: v1alpha1.BuildkitTemplateSpec{
+					Resources: v1alpha1.BuildkitTemplateResources{
+						Maximum: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+			},
+		},
+		&v1alpha1.BuildkitPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: "test-ns"},
+			Spec:       v1alpha1.BuildkitPoolSpec{Template: "capped"},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "direct", Namespace: "test-ns"},
+			Spec: v1alpha1.BuildkitSpec{
+				Template:  "capped",
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}},
+			},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooled", Namespace: "test-ns"},
+			Spec:       v1alpha1.BuildkitSpec{Pool: "warm"},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "test-ns"},
+			Spec: v1alpha1.BuildkitSpec{
+				TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "shared"},
+			},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing-template", Namespace: "test-ns"},
+			Spec: v1alpha1.BuildkitSpec{
+				Template:  "missing",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}},
+			},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "deleting", Namespace: "test-ns", DeletionTimestamp: &now, Finalizers: []string{"test"}},
+			Spec:       v1alpha1.BuildkitSpec{Template: "capped"},
+		},
+		&v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other-ns"},
+			Spec:       v1alpha1.BuildkitSpec{Template: "capped"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+
+	usage, err := Usage(t.Context(), c, "test-ns")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(4), usage.Instances)
+	assert.True(t, usage.Requests.Memory().Equal(resource.MustParse("2560Mi")), "requests.memory is %s", usage.Requests.Memory())
+	assert.True(t, usage.Limits.Cpu().Equal(resource.MustParse("5")), "limits.cpu is %s", usage.Limits.Cpu())
+	assert.True(t, usage.Requests.Cpu().Equal(resource.MustParse("5")), "requests.cpu is %s", usage.Requests.Cpu())
+
+	usage, err = UsageWithout(t.Context(), c, "test-ns", "direct")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), usage.Instances)
+	assert.True(t, usage.Limits.Cpu().Equal(resource.MustParse("3")), "limits.cpu is %s", usage.Limits.Cpu())
+}
+
+func TestCheckResources(t *testing.T) {
+	t.Parallel()
+
+	quota := &v1alpha1.BuildkitQuota{Spec: v1alpha1.BuildkitQuotaSpec{
+		Instances: new(int32(1)),
+		Requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+	}}
+	usage := v1alpha1.BuildkitQuotaUsage{
+		Instances: 1,
+		Requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+	}
+
+	assert.Empty(t, CheckResources(quota, usage, requirements(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}, nil)))
+	assert.Equal(t, []string{"requests.cpu: 3 requested, 2 of 4 used"}, CheckResources(quota, usage, requirements(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}, nil)))
+}
+
+func TestGrows(t *testing.T) {
+	t.Parallel()
+
+	cpu := func(quantity string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(quantity)}
+	}
+
+	tests := []struct {
+		name             string
+		current, desired corev1.ResourceRequirements
+		want             bool
+	}{
+		{name: "unchanged", current: requirements(cpu("1"), cpu("2")), desired: requirements(cpu("1"), cpu("2"))},
+		{name: "lowered", current: requirements(cpu("2"), cpu("4")), desired: requirements(cpu("1"), cpu("2"))},
+		{name: "dropped", current: requirements(cpu("1"), cpu("2")), desired: requirements(cpu("1"), nil)},
+		{name: "raised request", current: requirements(cpu("1"), nil), desired: requirements(cpu("1500m"), nil), want: true},
+		{name: "raised limit", current: requirements(nil, cpu("2")), desired: requirements(nil, cpu("3")), want: true},
+		{name: "newly set", current: requirements(nil, nil), desired: requirements(cpu("1"), nil), want: true},
+		{
+			name:    "uncapped resource",
+			current: requirements(nil, nil),
+			desired: requirements(corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Grows(tt.current, tt.desired))
+		})
+	}
+}
@@ -5,12 +5,16 @@
 package buildkit_template
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/buildkitd"
 	"github.com/seatgeek/buildkit-operator/internal/prestop"
 )
 
@@ -36,13 +40,23 @@ func (b Builder) configMapName() string {
 	if b.template == nil {
 		return ""
 	}
-	return fmt.Sprintf("buildkit-%s-toml", b.template.Name)
+	return b.template.ResourcePrefix() + "-toml"
 }
 
-// ConfigMap returns a ConfigMap containing the buildkitd.toml configuration from the BuildkitTemplate.
-// If the BuildkitTemplate does not have a buildkitd.toml, it returns nil.
+// ConfigMap returns a ConfigMap containing the buildkitd.toml configuration from the BuildkitTemplate, which is its
+// typed config merged on top of its raw buildkitdToml. If the BuildkitTemplate configures neither, it returns nil.
 func (b Builder) ConfigMap() *corev1.ConfigMap {
-	if b.template == nil || b.template.Spec.BuildkitdToml == "" {
+	if b.template == nil {
+		return nil
+	}
+
+	config, err := buildkitd.Toml(&b.template.Spec)
+	if err != nil {
+		// The webhook rejects templates whose buildkitdToml doesn't parse, so this only happens to templates admitted
+		// before it did; leave their config as it was rather than dropping it
+		config = b.template.Spec.BuildkitdToml
+	}
+	if config == "" {
 		return nil
 	}
 
@@ -52,7 +66,7 @@ func (b Builder) ConfigMap() *corev1.ConfigMap {
 			Namespace: b.template.Namespace,
 		},
 		Data: map[string]string{
-			"buildkitd.toml": b.template.Spec.BuildkitdToml,
+			"buildkitd.toml": config,
 		},
 	}
 }
@@ -61,7 +75,73 @@ func (b Builder) scriptsConfigMapName() string {
 	if b.template == nil {
 		return ""
 	}
-	return fmt.Sprintf("buildkit-%s-scripts", b.template.Name)
+	return b.template.ResourcePrefix() + "-scripts"
+}
+
+// CASecretName returns the name of the Secret holding the certificate authority that issues TLS certificates
+// for Buildkit instances created from the BuildkitTemplate.
+func (b Builder) CASecretName() string {
+	if b.template == nil {
+		return ""
+	}
+	return b.template.ResourcePrefix() + "-ca"
+}
+
+// RegistryAuthSecretName returns the name of the Secret holding the Docker config merged from the registryAuth Secrets
+// of the BuildkitTemplate.
+func (b Builder) RegistryAuthSecretName() string {
+	if b.template == nil {
+		return ""
+	}
+	return b.template.ResourcePrefix() + "-registry-auth"
+}
+
+// PodSpecHash returns a hash of the parts of the BuildkitTemplate spec that end up in Buildkit pods or their
+// configuration, so that pods started from an older version of the template can be told apart.
+func (b Builder) PodSpecHash() (string, error) {
+	if b.template == nil {
+		return "", nil
+	}
+
+	// Leave out settings that the operator acts on without changing the pods themselves
+	spec := b.template.Spec.DeepCopy()
+	spec.EndpointType = ""
+	spec.UpdateStrategy = ""
+	spec.MaxInstances = nil
+	spec.DeletionPolicy = ""
+	spec.Resources.Strict = false
+	spec.AllowedOverrides = nil
+	spec.Lifecycle.RequireOwner = false
+	spec.Lifecycle.IdleTimeout = nil
+	spec.Lifecycle.IdleAction = ""
+	spec.Lifecycle.RecoveryPolicy = nil
+	if spec.TLS != nil {
+		spec.TLS = &v1alpha1.BuildkitTemplateTLS{}
+	}
+	// Running pods pick up changes to the merged Docker config, so only whether there is one matters
+	if len(spec.RegistryAuth) > 0 {
+		spec.RegistryAuth = []corev1.LocalObjectReference{{}}
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash BuildkitTemplate spec: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// ConfigHash returns a hash of the buildkitd.toml rendered from the BuildkitTemplate, or an empty string if it renders
+// none, so that changes to the config buildkitd actually gets can be told apart from other changes to the template.
+func (b Builder) ConfigHash() string {
+	configMap := b.ConfigMap()
+	if configMap == nil {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(configMap.Data["buildkitd.toml"]))
+	return hex.EncodeToString(sum[:8])
 }
 
 const PreStopScriptName = "buildkit-prestop.sh"
@@ -73,7 +153,7 @@ func (b Builder) ScriptsConfigMap() *corev1.ConfigMap {
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("buildkit-%s-scripts", b.template.Name),
+			Name:      b.scriptsConfigMapName(),
 			Namespace: b.template.Namespace,
 		},
 		Data: map[string]string{
@@ -6,6 +6,7 @@ package buildkit_template
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,6 +46,13 @@ func TestBuilder_ConfigMapName(t *testing.T) {
 			},
 			want: "buildkit-test-template-toml",
 		},
+		{
+			name: "returns a name of its own for a ClusterBuildkitTemplate",
+			template: (&v1alpha1.ClusterBuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template"},
+			}).InNamespace("test-namespace"),
+			want: "buildkit-cluster-test-template-toml",
+		},
 		{
 			name:     "returns empty string when template is nil",
 			template: nil,
@@ -64,6 +72,22 @@ func TestBuilder_ConfigMapName(t *testing.T) {
 	}
 }
 
+func TestBuilder_ClusterTemplateNames(t *testing.T) {
+	t.Parallel()
+
+	clusterTemplate := &v1alpha1.ClusterBuildkitTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Annotations: map[string]string{"team": "ci"}},
+	}
+	namespaced := NewBuilder(&v1alpha1.BuildkitTemplate{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "test-namespace"}})
+	cluster := NewBuilder(clusterTemplate.InNamespace("test-namespace"))
+
+	assert.Equal(t, "buildkit-cluster-shared-scripts", cluster.scriptsConfigMapName())
+	assert.Equal(t, "buildkit-cluster-shared-ca", cluster.CASecretName())
+	assert.Equal(t, "buildkit-cluster-shared-registry-auth", cluster.RegistryAuthSecretName())
+	assert.NotEqual(t, namespaced.CASecretName(), cluster.CASecretName())
+	assert.Equal(t, map[string]string{"team": "ci"}, clusterTemplate.Annotations, "the ClusterBuildkitTemplate is left alone")
+}
+
 func TestBuilder_ConfigMap(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +117,70 @@ func TestBuilder_ConfigMap(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns configmap with config merged over BuildkitdToml",
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-namespace",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					BuildkitdToml: someToml,
+					Config: &v1alpha1.BuildkitdConfig{
+						MaxParallelism: new(int32(8)),
+						LogFormat:      v1alpha1.BuildkitdLogFormatJSON,
+					},
+				},
+			},
+			want: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "buildkit-test-template-toml",
+					Namespace: "test-namespace",
+				},
+				Data: map[string]string{
+					"buildkitd.toml": `[log]
+  format = "json"
+
+[registry]
+  [registry."docker.io"]
+    mirrors = ["mirror.gcr.io"]
+
+[worker]
+  [worker.containerd]
+    enabled = false
+  [worker.oci]
+    enabled = true
+    max-parallelism = 8
+`,
+				},
+			},
+		},
+		{
+			name: "returns configmap when only config is set",
+			template: &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-template",
+					Namespace: "test-namespace",
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Config: &v1alpha1.BuildkitdConfig{
+						Registries: []v1alpha1.BuildkitdRegistry{{Host: "registry.internal", HTTP: true}},
+					},
+				},
+			},
+			want: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "buildkit-test-template-toml",
+					Namespace: "test-namespace",
+				},
+				Data: map[string]string{
+					"buildkitd.toml": `[registry]
+  [registry."registry.internal"]
+    http = true
+`,
+				},
+			},
+		},
 		{
 			name: "returns nil when BuildkitdToml is empty",
 			template: &v1alpha1.BuildkitTemplate{
@@ -240,3 +328,139 @@ func TestBuilder_ScriptsConfigMap(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_PodSpecHash(t *testing.T) {
+	t.Parallel()
+
+	base := v1alpha1.BuildkitTemplateSpec{
+		Port:          1234,
+		Image:         "moby/buildkit:v0.23.2",
+		BuildkitdToml: someToml,
+		RegistryAuth:  []corev1.LocalObjectReference{{Name: "dockerhub"}},
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(spec *v1alpha1.BuildkitTemplateSpec)
+		wantChange bool
+	}{
+		{
+			name:       "image change",
+			modify:     func(spec *v1alpha1.BuildkitTemplateSpec) { spec.Image = "moby/buildkit:v0.24.0" },
+			wantChange: true,
+		},
+		{
+			name:       "toml change",
+			modify:     func(spec *v1alpha1.BuildkitTemplateSpec) { spec.BuildkitdToml = "debug = true" },
+			wantChange: true,
+		},
+		{
+			name: "scheduling change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) {
+				spec.Scheduling.NodeSelector = map[string]string{"kubernetes.io/arch": "arm64"}
+			},
+			wantChange: true,
+		},
+		{
+			name:       "enabling TLS",
+			modify:     func(spec *v1alpha1.BuildkitTemplateSpec) { spec.TLS = &v1alpha1.BuildkitTemplateTLS{} },
+			wantChange: true,
+		},
+		{
+			name:       "disabling registry auth",
+			modify:     func(spec *v1alpha1.BuildkitTemplateSpec) { spec.RegistryAuth = nil },
+			wantChange: true,
+		},
+		{
+			name: "image pull secrets change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) {
+				spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "image-pull"}}
+			},
+			wantChange: true,
+		},
+		{
+			name: "registry auth secrets change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) {
+				spec.RegistryAuth = append(spec.RegistryAuth, corev1.LocalObjectReference{Name: "private-registry"})
+			},
+		},
+		{
+			name:   "update strategy change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) { spec.UpdateStrategy = v1alpha1.UpdateStrategyRecreate },
+		},
+		{
+			name:   "max instances change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) { spec.MaxInstances = new(int32(3)) },
+		},
+		{
+			name:   "deletion policy change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) { spec.DeletionPolicy = v1alpha1.DeletionPolicyCascade },
+		},
+		{
+			name:   "strict resources change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) { spec.Resources.Strict = true },
+		},
+		{
+			name: "allowed overrides change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) {
+				spec.AllowedOverrides = &v1alpha1.BuildkitTemplateAllowedOverrides{NodeSelectorKeys: []string{"topology.kubernetes.io/zone"}}
+			},
+		},
+		{
+			name:   "endpoint type change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) { spec.EndpointType = v1alpha1.EndpointTypeService },
+		},
+		{
+			name: "idle timeout change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) {
+				spec.Lifecycle.IdleTimeout = &metav1.Duration{Duration: time.Hour}
+				spec.Lifecycle.IdleAction = v1alpha1.IdleActionSuspend
+			},
+		},
+		{
+			name: "recovery policy change",
+			modify: func(spec *v1alpha1.BuildkitTemplateSpec) {
+				spec.Lifecycle.RecoveryPolicy = &v1alpha1.BuildkitTemplateRecoveryPolicy{
+					Type:        v1alpha1.RecoveryPolicyOnFailure,
+					MaxRestarts: 5,
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			before, err := NewBuilder(&v1alpha1.BuildkitTemplate{Spec: base}).PodSpecHash()
+			require.NoError(t, err)
+
+			modified := base.DeepCopy()
+			tt.modify(modified)
+			after, err := NewBuilder(&v1alpha1.BuildkitTemplate{Spec: *modified}).PodSpecHash()
+			require.NoError(t, err)
+
+			assert.NotEmpty(t, after)
+			if tt.wantChange {
+				assert.NotEqual(t, before, after)
+			} else {
+				assert.Equal(t, before, after)
+			}
+		})
+	}
+}
+
+func TestBuilder_ConfigHash(t *testing.T) {
+	t.Parallel()
+
+	hash := func(spec v1alpha1.BuildkitTemplateSpec) string {
+		return NewBuilder(&v1alpha1.BuildkitTemplate{ObjectMeta: metav1.ObjectMeta{Name: "test-template"}, Spec: spec}).ConfigHash()
+	}
+
+	assert.Empty(t, hash(v1alpha1.BuildkitTemplateSpec{}))
+
+	base := hash(v1alpha1.BuildkitTemplateSpec{BuildkitdToml: someToml})
+	assert.NotEmpty(t, base)
+	assert.Equal(t, base, hash(v1alpha1.BuildkitTemplateSpec{BuildkitdToml: someToml, Image: "moby/buildkit:v0.24.0"}))
+	assert.NotEqual(t, base, hash(v1alpha1.BuildkitTemplateSpec{BuildkitdToml: someToml, Config: &v1alpha1.BuildkitdConfig{LogFormat: v1alpha1.BuildkitdLogFormatJSON}}))
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// retainedCachesInUse returns the retained build cache PVCs owned by a template that a Buildkit pod still mounts, as
+// namespace/name. Those of a BuildkitTemplate are in its namespace, while those of a ClusterBuildkitTemplate are found
+// across all namespaces.
+func retainedCachesInUse(ctx context.Context, c client.Reader, owner client.Object, namespace string) ([]string, error) {
+	var claims corev1.PersistentVolumeClaimList
+	if err := c.List(ctx, &claims, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list PVCs: %w", err)
+	}
+
+	owned := make(map[client.ObjectKey]bool)
+	for _, claim := range claims.Items {
+		if slices.ContainsFunc(claim.OwnerReferences, func(ref metav1.OwnerReference) bool { return ref.UID == owner.GetUID() }) {
+			owned[client.ObjectKeyFromObject(&claim)] = true
+		}
+	}
+
+	if len(owned) == 0 {
+		return nil, nil
+	}
+
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(namespace), client.HasLabels{v1alpha1.InstanceLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list Buildkit pods: %w", err)
+	}
+
+	var inUse []string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+
+			key := client.ObjectKey{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}
+			if owned[key] && !slices.Contains(inUse, key.String()) {
+				inUse = append(inUse, key.String())
+			}
+		}
+	}
+
+	slices.Sort(inUse)
+	return inUse, nil
+}
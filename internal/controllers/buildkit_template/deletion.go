@@ -0,0 +1,140 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// ReasonDeletionBlocked is the reason a template being deleted reports while Buildkits still use it.
+const ReasonDeletionBlocked api.ConditionReason = "DeletionBlocked"
+
+// maxListedConsumers caps how many Buildkits or BuildkitPools are named when reporting why a template can't be
+// deleted yet.
+const maxListedConsumers = 5
+
+// Conditioned is a template whose conditions can be read, such as a BuildkitTemplate or ClusterBuildkitTemplate.
+type Conditioned interface {
+	client.Object
+	GetCondition(api.ConditionType) api.Condition
+}
+
+// ReleaseConsumers deals with the Buildkits and BuildkitPools still using a template that's being deleted, as its
+// deletionPolicy says: Block waits for them to be deleted by someone else, while Cascade deletes them. It returns the
+// result of the template's finalizer state, which is only done once nothing uses the template anymore, and no pod
+// mounts any of the retained build caches the template owns, which are deleted along with it.
+func ReleaseConsumers(ctx context.Context, c client.Client, recorder record.EventRecorder, owner Conditioned, ref v1alpha1.TemplateReference, namespace string, policy v1alpha1.DeletionPolicyType, log *zap.SugaredLogger) types.Result {
+	instances, err := Instances(ctx, c, ref, namespace)
+	if err != nil {
+		return types.ErrorResult(err)
+	}
+
+	pools, err := Pools(ctx, c, ref, namespace)
+	if err != nil {
+		return types.ErrorResult(err)
+	}
+
+	if len(instances) == 0 && len(pools) == 0 {
+		inUse, err := retainedCachesInUse(ctx, c, owner, namespace)
+		if err != nil {
+			return types.ErrorResult(err)
+		}
+
+		if len(inUse) > 0 {
+			log.Debugw("Waiting for pods to let go of retained build caches", "pvcs", inUse)
+			return types.RequeueResultWithReasonAndBackoff(fmt.Sprintf("Waiting for pods to stop using retained build caches %s", strings.Join(inUse, ", ")), "CachesInUse")
+		}
+
+		log.Debugw("Nothing uses the template anymore, releasing it")
+		return types.DoneResult()
+	}
+
+	if policy == v1alpha1.DeletionPolicyCascade {
+		// Deleting a pool doesn't delete the Buildkits claimed from it, so both are deleted
+		for i := range pools {
+			if err := deleteConsumer(ctx, c, recorder, owner, "BuildkitPool", &pools[i], log); err != nil {
+				return types.ErrorResult(err)
+			}
+		}
+
+		for i := range instances {
+			if err := deleteConsumer(ctx, c, recorder, owner, "Buildkit", &instances[i], log); err != nil {
+				return types.ErrorResult(err)
+			}
+		}
+
+		return types.RequeueResultWithReasonAndBackoff(fmt.Sprintf("Waiting for %s to be deleted", countConsumers(instances, pools)), "DeletingConsumers")
+	}
+
+	var using []string
+	if len(instances) > 0 {
+		using = append(using, fmt.Sprintf("%d Buildkits (%s)", len(instances), describeConsumers(instances)))
+	}
+	if len(pools) > 0 {
+		using = append(using, fmt.Sprintf("%d BuildkitPools (%s)", len(pools), describeConsumers(pools)))
+	}
+
+	message := fmt.Sprintf("Still used by %s; delete them first, or set deletionPolicy to Cascade", strings.Join(using, " and "))
+	if owner.GetCondition(api.TypeReady).Reason != ReasonDeletionBlocked {
+		log.Infow("Blocking deletion of template still in use", "buildkits", len(instances), "pools", len(pools))
+		recorder.Event(owner, corev1.EventTypeWarning, v1alpha1.EventReasonDeletionBlocked, message)
+	}
+
+	return types.RequeueResultWithReasonAndBackoff(message, ReasonDeletionBlocked)
+}
+
+// deleteConsumer deletes a Buildkit or BuildkitPool along with the template it uses, unless it's already going away.
+func deleteConsumer(ctx context.Context, c client.Client, recorder record.EventRecorder, owner Conditioned, kind string, consumer client.Object, log *zap.SugaredLogger) error {
+	if consumer.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	key := client.ObjectKeyFromObject(consumer)
+	log.Infow("Deleting consumer along with its template", "kind", kind, "name", key)
+	if err := c.Delete(ctx, consumer); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %s '%s': %w", kind, key, err)
+	}
+	recorder.Eventf(owner, corev1.EventTypeNormal, v1alpha1.EventReasonConsumerDeleted, "Deleted %s %s along with the template", kind, key)
+
+	return nil
+}
+
+// countConsumers sums up how many Buildkits and BuildkitPools use a template.
+func countConsumers(instances []v1alpha1.Buildkit, pools []v1alpha1.BuildkitPool) string {
+	if len(pools) == 0 {
+		return fmt.Sprintf("%d Buildkits", len(instances))
+	}
+
+	return fmt.Sprintf("%d Buildkits and %d BuildkitPools", len(instances), len(pools))
+}
+
+// describeConsumers names the first few Buildkits or BuildkitPools using a template, noting how many more there are.
+func describeConsumers[T any, PT interface {
+	*T
+	client.Object
+}](consumers []T) string {
+	names := make([]string, 0, min(len(consumers), maxListedConsumers))
+	for i := range consumers[:min(len(consumers), maxListedConsumers)] {
+		names = append(names, client.ObjectKeyFromObject(PT(&consumers[i])).String())
+	}
+
+	description := strings.Join(names, ", ")
+	if more := len(consumers) - len(names); more > 0 {
+		description += fmt.Sprintf(" and %d more", more)
+	}
+
+	return description
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestReleaseConsumers(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	ref := v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: "test-template"}
+	blocked := &v1alpha1.BuildkitTemplate{ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "test-namespace"}}
+	blocked.SetConditions(api.Condition{Type: api.TypeReady, Status: corev1.ConditionFalse, Reason: ReasonDeletionBlocked})
+	withCache := &v1alpha1.BuildkitTemplate{ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "test-namespace", UID: "template-uid"}}
+
+	tests := []struct {
+		name       string
+		owner      *v1alpha1.BuildkitTemplate
+		policy     v1alpha1.DeletionPolicyType
+		buildkits  []v1alpha1.Buildkit
+		objects    []client.Object
+		wantDone   bool
+		wantReason api.ConditionReason
+		wantEvents int
+		wantLeft   int
+		wantPools  int
+	}{
+		{
+			name:     "no consumers",
+			owner:    &v1alpha1.BuildkitTemplate{},
+			policy:   v1alpha1.DeletionPolicyBlock,
+			wantDone: true,
+		},
+		{
+			name:       "blocked by consumers",
+			owner:      &v1alpha1.BuildkitTemplate{},
+			policy:     v1alpha1.DeletionPolicyBlock,
+			buildkits:  []v1alpha1.Buildkit{instance("a", 0), instance("b", 1)},
+			wantReason: ReasonDeletionBlocked,
+			wantEvents: 1,
+			wantLeft:   2,
+		},
+		{
+			name:       "still blocked",
+			owner:      blocked,
+			policy:     v1alpha1.DeletionPolicyBlock,
+			buildkits:  []v1alpha1.Buildkit{instance("a", 0)},
+			wantReason: ReasonDeletionBlocked,
+			wantLeft:   1,
+		},
+		{
+			name:       "cascade",
+			owner:      &v1alpha1.BuildkitTemplate{},
+			policy:     v1alpha1.DeletionPolicyCascade,
+			buildkits:  []v1alpha1.Buildkit{instance("a", 0), instance("b", 1)},
+			wantReason: "DeletingConsumers",
+			wantEvents: 2,
+		},
+		{
+			name:       "blocked by pools",
+			owner:      &v1alpha1.BuildkitTemplate{},
+			policy:     v1alpha1.DeletionPolicyBlock,
+			objects:    []client.Object{pool("warm", "test-template"), pool("other", "other-template")},
+			wantReason: ReasonDeletionBlocked,
+			wantEvents: 1,
+			wantPools:  2,
+		},
+		{
+			name:       "cascade to pools",
+			owner:      &v1alpha1.BuildkitTemplate{},
+			policy:     v1alpha1.DeletionPolicyCascade,
+			buildkits:  []v1alpha1.Buildkit{instance("a", 0)},
+			objects:    []client.Object{pool("warm", "test-template"), pool("other", "other-template")},
+			wantReason: "DeletingConsumers",
+			wantEvents: 2,
+			wantPools:  1,
+		},
+		{
+			name:     "retained cache no longer mounted",
+			owner:    withCache,
+			policy:   v1alpha1.DeletionPolicyBlock,
+			objects:  []client.Object{cacheClaim(withCache), cachePod(corev1.PodSucceeded)},
+			wantDone: true,
+		},
+		{
+			name:       "retained cache still mounted",
+			owner:      withCache,
+			policy:     v1alpha1.DeletionPolicyBlock,
+			objects:    []client.Object{cacheClaim(withCache), cachePod(corev1.PodRunning)},
+			wantReason: "CachesInUse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, bk := range tt.buildkits {
+				builder = builder.WithObjects(&bk)
+			}
+			builder = builder.WithObjects(tt.objects...)
+			c := builder.Build()
+			recorder := record.NewFakeRecorder(10)
+
+			result := ReleaseConsumers(t.Context(), c, recorder, tt.owner, ref, "test-namespace", tt.policy, zap.NewNop().Sugar())
+			require.NoError(t, result.Err)
+			assert.Equal(t, tt.wantDone, result.Done)
+			assert.Equal(t, tt.wantReason, result.Reason)
+			assert.Len(t, recorder.Events, tt.wantEvents)
+
+			var remaining v1alpha1.BuildkitList
+			require.NoError(t, c.List(t.Context(), &remaining))
+			assert.Len(t, remaining.Items, tt.wantLeft)
+
+			var pools v1alpha1.BuildkitPoolList
+			require.NoError(t, c.List(t.Context(), &pools))
+			assert.Len(t, pools.Items, tt.wantPools)
+		})
+	}
+}
+
+// pool returns a BuildkitPool that starts its Buildkits from the given template.
+func pool(name, template string) *v1alpha1.BuildkitPool {
+	return &v1alpha1.BuildkitPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+		Spec:       v1alpha1.BuildkitPoolSpec{Template: template},
+	}
+}
+
+// cacheClaim returns a retained build cache PVC owned by the given template.
+func cacheClaim(owner *v1alpha1.BuildkitTemplate) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name:            "buildkit-test-template-cache-shared",
+		Namespace:       "test-namespace",
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "BuildkitTemplate", Name: owner.Name, UID: owner.UID}},
+	}}
+}
+
+// cachePod returns a Buildkit pod in the given phase that mounts the PVC returned by cacheClaim.
+func cachePod(phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bk-a", Namespace: "test-namespace", Labels: map[string]string{v1alpha1.InstanceLabel: "a"}},
+		Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+			Name:         "cache",
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "buildkit-test-template-cache-shared"}},
+		}}},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestDescribeConsumers(t *testing.T) {
+	t.Parallel()
+
+	var instances []v1alpha1.Buildkit
+	for i := range 7 {
+		instances = append(instances, instance(fmt.Sprintf("bk-%d", i), i))
+	}
+
+	assert.Equal(t, "test-namespace/bk-0", describeConsumers(instances[:1]))
+	assert.Equal(t, "test-namespace/bk-0, test-namespace/bk-1, test-namespace/bk-2, test-namespace/bk-3, test-namespace/bk-4 and 2 more", describeConsumers(instances))
+	assert.Equal(t, "test-namespace/warm", describeConsumers([]v1alpha1.BuildkitPool{*pool("warm", "test-template")}))
+}
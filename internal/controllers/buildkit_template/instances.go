@@ -0,0 +1,152 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// Instances returns the Buildkits started from a template. The Buildkits of a BuildkitTemplate are those in the given
+// namespace that reference it directly or through one of its pools, while those of a ClusterBuildkitTemplate are
+// found across all namespaces.
+func Instances(ctx context.Context, c client.Reader, ref v1alpha1.TemplateReference, namespace string) ([]v1alpha1.Buildkit, error) {
+	if ref.IsCluster() {
+		var buildkits v1alpha1.BuildkitList
+		if err := c.List(ctx, &buildkits); err != nil {
+			return nil, fmt.Errorf("failed to list Buildkits: %w", err)
+		}
+
+		return slices.DeleteFunc(buildkits.Items, func(bk v1alpha1.Buildkit) bool {
+			direct := bk.Spec.DirectTemplateRef()
+			return !direct.IsCluster() || direct.Name != ref.Name
+		}), nil
+	}
+
+	var buildkits v1alpha1.BuildkitList
+	if err := c.List(ctx, &buildkits, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Buildkits in namespace '%s': %w", namespace, err)
+	}
+
+	var pools v1alpha1.BuildkitPoolList
+	if err := c.List(ctx, &pools, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list BuildkitPools in namespace '%s': %w", namespace, err)
+	}
+
+	poolsUsingTemplate := make(map[string]bool, len(pools.Items))
+	for _, pool := range pools.Items {
+		poolsUsingTemplate[pool.Name] = pool.Spec.Template == ref.Name
+	}
+
+	return slices.DeleteFunc(buildkits.Items, func(bk v1alpha1.Buildkit) bool {
+		if bk.Spec.Pool != "" {
+			return !poolsUsingTemplate[bk.Spec.Pool]
+		}
+		direct := bk.Spec.DirectTemplateRef()
+		return direct == nil || direct.IsCluster() || direct.Name != ref.Name
+	}), nil
+}
+
+// Pools returns the BuildkitPools in the given namespace that start their Buildkits from a BuildkitTemplate. Pools
+// can't use a ClusterBuildkitTemplate, so there are none for one.
+func Pools(ctx context.Context, c client.Reader, ref v1alpha1.TemplateReference, namespace string) ([]v1alpha1.BuildkitPool, error) {
+	if ref.IsCluster() {
+		return nil, nil
+	}
+
+	var pools v1alpha1.BuildkitPoolList
+	if err := c.List(ctx, &pools, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list BuildkitPools in namespace '%s': %w", namespace, err)
+	}
+
+	return slices.DeleteFunc(pools.Items, func(pool v1alpha1.BuildkitPool) bool {
+		return pool.Spec.Template != ref.Name
+	}), nil
+}
+
+// HasPod reports whether a Buildkit has a pod, as last recorded in its status. Buildkits with a pod count towards
+// their template's maxInstances.
+func HasPod(bk *v1alpha1.Buildkit) bool {
+	return bk.Status.PodName != "" || slices.ContainsFunc(bk.Status.ResourceRefs, func(ref api.TypedObjectRef) bool {
+		return ref.Kind == "Pod"
+	})
+}
+
+// IsQueued reports whether a Buildkit is waiting for a free slot under its template's maxInstances.
+func IsQueued(bk *v1alpha1.Buildkit) bool {
+	return bk.GetCondition(v1alpha1.TypeQueued).Status == corev1.ConditionTrue
+}
+
+// CountInstances sums up a template's Buildkits by where they are in their lifecycle.
+func CountInstances(instances []v1alpha1.Buildkit) v1alpha1.TemplateInstanceCounts {
+	var counts v1alpha1.TemplateInstanceCounts
+	for i := range instances {
+		bk := &instances[i]
+		counts.TotalInstances++
+
+		switch {
+		case HasPod(bk):
+			counts.Instances++
+		case IsQueued(bk):
+			counts.QueuedInstances++
+		}
+
+		switch bk.Status.Phase {
+		case v1alpha1.BuildkitPhaseReady:
+			counts.ReadyInstances++
+		case v1alpha1.BuildkitPhaseFailed:
+			counts.FailedInstances++
+		}
+
+		if bk.GetCondition(v1alpha1.TypeTemplateOutOfDate).Status == corev1.ConditionTrue {
+			counts.OutOfDateInstances++
+		}
+	}
+
+	return counts
+}
+
+// QueuePosition returns where a Buildkit without a pod stands in the queue of its template's Buildkits waiting for one
+// of maxInstances slots, starting at 1, or zero if a slot is free for it. Waiting Buildkits get the free slots in the
+// order they were created, so that they start first come, first served.
+func QueuePosition(bk *v1alpha1.Buildkit, instances []v1alpha1.Buildkit, maxInstances int32) int32 {
+	var running int32
+	var waiting []*v1alpha1.Buildkit
+	for i := range instances {
+		other := &instances[i]
+		switch {
+		case HasPod(other):
+			running++
+		case other.UID == bk.UID, other.Spec.Suspended, other.DeletionTimestamp != nil:
+		default:
+			waiting = append(waiting, other)
+		}
+	}
+
+	// The Buildkit itself may not have made it into the informer cache yet
+	waiting = append(waiting, bk)
+	slices.SortFunc(waiting, func(a, b *v1alpha1.Buildkit) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+
+	free := max(maxInstances-running, 0)
+	ahead := int32(slices.Index(waiting, bk))
+	if ahead < free {
+		return 0
+	}
+
+	return ahead - free + 1
+}
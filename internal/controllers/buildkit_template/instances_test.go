@@ -0,0 +1,257 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+var created = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// instance returns a Buildkit created the given number of minutes after the others started being created
+func instance(name string, minute int) v1alpha1.Buildkit {
+	return v1alpha1.Buildkit{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "test-namespace",
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(created.Add(time.Duration(minute) * time.Minute)),
+		},
+		Spec: v1alpha1.BuildkitSpec{Template: "test-template"},
+	}
+}
+
+func withPod(bk v1alpha1.Buildkit) v1alpha1.Buildkit {
+	bk.Status.PodName = bk.Name + "-abcde"
+	return bk
+}
+
+func queued(bk v1alpha1.Buildkit) v1alpha1.Buildkit {
+	bk.SetConditions(api.Condition{Type: v1alpha1.TypeQueued, Status: corev1.ConditionTrue})
+	return bk
+}
+
+func TestQueuePosition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	suspended := instance("suspended", 0)
+	suspended.Spec.Suspended = true
+	deleting := instance("deleting", 0)
+	deleting.DeletionTimestamp = &now
+
+	tests := []struct {
+		name         string
+		bk           v1alpha1.Buildkit
+		instances    []v1alpha1.Buildkit
+		maxInstances int32
+		want         int32
+	}{
+		{
+			name:         "first instance",
+			bk:           instance("new", 0),
+			maxInstances: 1,
+			want:         0,
+		},
+		{
+			name:         "slot free",
+			bk:           instance("new", 2),
+			instances:    []v1alpha1.Buildkit{withPod(instance("running", 1)), instance("new", 2)},
+			maxInstances: 2,
+			want:         0,
+		},
+		{
+			name:         "cap reached",
+			bk:           instance("new", 2),
+			instances:    []v1alpha1.Buildkit{withPod(instance("a", 0)), withPod(instance("b", 1)), instance("new", 2)},
+			maxInstances: 2,
+			want:         1,
+		},
+		{
+			name:         "zero cap queues everything",
+			bk:           instance("new", 0),
+			maxInstances: 0,
+			want:         1,
+		},
+		{
+			name: "older waiting instances go first",
+			bk:   instance("new", 5),
+			instances: []v1alpha1.Buildkit{
+				withPod(instance("running", 0)),
+				queued(instance("first", 1)),
+				queued(instance("second", 2)),
+				instance("new", 5),
+			},
+			maxInstances: 1,
+			want:         3,
+		},
+		{
+			name: "free slot goes to the oldest waiting instance",
+			bk:   instance("new", 5),
+			instances: []v1alpha1.Buildkit{
+				queued(instance("first", 1)),
+				instance("new", 5),
+			},
+			maxInstances: 1,
+			want:         1,
+		},
+		{
+			name: "oldest waiting instance takes the free slot",
+			bk:   queued(instance("first", 1)),
+			instances: []v1alpha1.Buildkit{
+				queued(instance("first", 1)),
+				queued(instance("second", 2)),
+			},
+			maxInstances: 1,
+			want:         0,
+		},
+		{
+			name: "instances created at once are ordered by name",
+			bk:   instance("b", 1),
+			instances: []v1alpha1.Buildkit{
+				instance("a", 1),
+				instance("b", 1),
+			},
+			maxInstances: 1,
+			want:         1,
+		},
+		{
+			name:         "suspended and deleted instances don't wait",
+			bk:           instance("new", 5),
+			instances:    []v1alpha1.Buildkit{suspended, deleting},
+			maxInstances: 1,
+			want:         0,
+		},
+		{
+			name:         "instances over a lowered cap keep running",
+			bk:           instance("new", 5),
+			instances:    []v1alpha1.Buildkit{withPod(instance("a", 0)), withPod(instance("b", 1)), withPod(instance("c", 2))},
+			maxInstances: 1,
+			want:         1,
+		},
+		{
+			name:         "instance not in the cache yet",
+			bk:           instance("new", 5),
+			instances:    []v1alpha1.Buildkit{withPod(instance("running", 0))},
+			maxInstances: 1,
+			want:         1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, QueuePosition(&tt.bk, tt.instances, tt.maxInstances))
+		})
+	}
+}
+
+func TestCountInstances(t *testing.T) {
+	t.Parallel()
+
+	ready := withPod(instance("ready", 0))
+	ready.Status.Phase = v1alpha1.BuildkitPhaseReady
+	outOfDate := withPod(instance("out-of-date", 0))
+	outOfDate.Status.Phase = v1alpha1.BuildkitPhaseReady
+	outOfDate.SetConditions(api.Condition{Type: v1alpha1.TypeTemplateOutOfDate, Status: corev1.ConditionTrue})
+	claimed := instance("claimed", 0)
+	claimed.Status.ResourceRefs = []api.TypedObjectRef{{Kind: "Pod", Name: "claimed-abcde"}}
+	claimed.Status.Phase = v1alpha1.BuildkitPhaseStarting
+	failed := withPod(instance("failed", 0))
+	failed.Status.Phase = v1alpha1.BuildkitPhaseFailed
+	suspended := instance("suspended", 0)
+	suspended.Spec.Suspended = true
+	suspended.Status.Phase = v1alpha1.BuildkitPhaseSuspended
+
+	counts := CountInstances([]v1alpha1.Buildkit{
+		ready,
+		outOfDate,
+		claimed,
+		failed,
+		suspended,
+		queued(instance("queued", 1)),
+		instance("pending", 2),
+	})
+
+	assert.Equal(t, v1alpha1.TemplateInstanceCounts{
+		TotalInstances:     7,
+		Instances:          4,
+		ReadyInstances:     2,
+		FailedInstances:    1,
+		OutOfDateInstances: 1,
+		QueuedInstances:    1,
+	}, counts)
+}
+
+func TestInstances(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	direct := instance("direct", 0)
+	pooled := instance("pooled", 0)
+	pooled.Spec = v1alpha1.BuildkitSpec{Pool: "test-pool"}
+	otherPool := instance("other-pool", 0)
+	otherPool.Spec = v1alpha1.BuildkitSpec{Pool: "other-pool"}
+	otherTemplate := instance("other-template", 0)
+	otherTemplate.Spec = v1alpha1.BuildkitSpec{Template: "other-template"}
+	otherNamespace := instance("other-namespace", 0)
+	otherNamespace.Namespace = "other-namespace"
+	clusterA := instance("cluster-a", 0)
+	clusterA.Spec = v1alpha1.BuildkitSpec{TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "test-template"}}
+	clusterB := clusterA
+	clusterB.Name, clusterB.Namespace = "cluster-b", "other-namespace"
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&direct, &pooled, &otherPool, &otherTemplate, &otherNamespace, &clusterA, &clusterB,
+		&v1alpha1.BuildkitPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "test-namespace"},
+			Spec:       v1alpha1.BuildkitPoolSpec{Template: "test-template"},
+		},
+		&v1alpha1.BuildkitPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-pool", Namespace: "test-namespace"},
+			Spec:       v1alpha1.BuildkitPoolSpec{Template: "other-template"},
+		},
+	).Build()
+
+	names := func(instances []v1alpha1.Buildkit) []string {
+		var names []string
+		for _, bk := range instances {
+			names = append(names, bk.Namespace+"/"+bk.Name)
+		}
+		return names
+	}
+
+	instances, err := Instances(t.Context(), c, v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: "test-template"}, "test-namespace")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"test-namespace/direct", "test-namespace/pooled"}, names(instances))
+
+	instances, err = Instances(t.Context(), c, v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "test-template"}, "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"test-namespace/cluster-a", "other-namespace/cluster-b"}, names(instances))
+
+	pools, err := Pools(t.Context(), c, v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: "test-template"}, "test-namespace")
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, "test-pool", pools[0].Name)
+
+	pools, err = Pools(t.Context(), c, v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "test-template"}, "")
+	require.NoError(t, err)
+	assert.Empty(t, pools)
+}
@@ -6,6 +6,8 @@ package buildkit_template
 
 import (
 	"context"
+	"fmt"
+	"maps"
 
 	"github.com/reddit/achilles-sdk-api/api"
 	"github.com/reddit/achilles-sdk/pkg/fsm"
@@ -14,9 +16,13 @@ import (
 	"github.com/reddit/achilles-sdk/pkg/logging"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
@@ -26,16 +32,23 @@ import (
 //+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkittemplates,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkittemplates/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkittemplates/finalizers,verbs=update
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkits,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkitpools,verbs=get;list;watch;delete
 //+kubebuilder:rbac:resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=persistentvolumeclaims,verbs=get;list;watch
+//+kubebuilder:rbac:resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=events,verbs=create;patch
 
 const controllerName = "BuildkitTemplate"
 
 type state = types.State[*v1alpha1.BuildkitTemplate]
 
 type reconciler struct {
-	c      *io.ClientApplicator
-	scheme *runtime.Scheme
-	log    *zap.SugaredLogger
+	c        *io.ClientApplicator
+	scheme   *runtime.Scheme
+	log      *zap.SugaredLogger
+	recorder record.EventRecorder
 }
 
 func (r *reconciler) createConfigMaps() *state {
@@ -45,27 +58,127 @@ func (r *reconciler) createConfigMaps() *state {
 		Transition: func(ctx context.Context, obj *v1alpha1.BuildkitTemplate, out *types.OutputSet) (*state, types.Result) {
 			log := r.log.With("name", obj.Name, "namespace", obj.Namespace)
 
-			builder := NewBuilder(obj)
-			for name, configMap := range builder.AllConfigMaps() {
-				if configMap != nil {
-					log.Debugw("applying configmap", "configmap", name)
-					out.Apply(configMap)
-				} else {
-					log.Debugw("removing configmap", "configmap", name)
-					out.DeleteByRef(api.TypedObjectRef{
-						Version:   "v1",
-						Kind:      "ConfigMap",
-						Name:      name,
-						Namespace: obj.Namespace,
-					})
-				}
+			if err := ApplyResources(ctx, r.c, r.recorder, obj, obj, out, log); err != nil {
+				return nil, types.ErrorResult(err)
 			}
 
+			instances, err := Instances(ctx, r.c, v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: obj.Name}, obj.Namespace)
+			if err != nil {
+				return nil, types.ErrorResult(err)
+			}
+			obj.Status.TemplateInstanceCounts = CountInstances(instances)
+			obj.Status.ConfigHash = NewBuilder(obj).ConfigHash()
+			obj.Status.ObservedGeneration = obj.Generation
+
 			return nil, types.DoneResult()
 		},
 	}
 }
 
+// releaseConsumers keeps the template around until the Buildkits using it are gone, per its deletionPolicy.
+func (r *reconciler) releaseConsumers() *state {
+	return &state{
+		Name:      "release-consumers",
+		Condition: conditionReady,
+		Transition: func(ctx context.Context, obj *v1alpha1.BuildkitTemplate, _ *types.OutputSet) (*state, types.Result) {
+			log := r.log.With("name", obj.Name, "namespace", obj.Namespace)
+			ref := v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: obj.Name}
+
+			return nil, ReleaseConsumers(ctx, r.c, r.recorder, obj, ref, obj.Namespace, obj.Spec.DeletionPolicy, log)
+		},
+	}
+}
+
+// ApplyResources enqueues the ConfigMaps, merged registry credentials and certificate authority that Buildkit pods
+// started from the template need in the template's namespace, and removes the ones its spec no longer calls for.
+// Changes to the ConfigMaps and skipped registry credentials are recorded as Events on owner, which is the template
+// itself unless it was derived from a ClusterBuildkitTemplate.
+func ApplyResources(ctx context.Context, c client.Reader, recorder record.EventRecorder, owner runtime.Object, obj *v1alpha1.BuildkitTemplate, out *types.OutputSet, log *zap.SugaredLogger) error {
+	for name, configMap := range NewBuilder(obj).AllConfigMaps() {
+		key := client.ObjectKey{Name: name, Namespace: obj.Namespace}
+
+		var existing corev1.ConfigMap
+		err := c.Get(ctx, key, &existing)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get ConfigMap '%s': %w", key, err)
+		}
+		exists := err == nil
+
+		if configMap != nil {
+			log.Debugw("applying configmap", "configmap", name)
+			if !exists || !maps.Equal(existing.Data, configMap.Data) {
+				recorder.Eventf(owner, corev1.EventTypeNormal, v1alpha1.EventReasonConfigMapApplied, "Applied ConfigMap %s", key)
+			}
+			out.Apply(configMap)
+		} else {
+			log.Debugw("removing configmap", "configmap", name)
+			if exists {
+				recorder.Eventf(owner, corev1.EventTypeNormal, v1alpha1.EventReasonConfigMapRemoved, "Removed ConfigMap %s", key)
+			}
+			out.DeleteByRef(api.TypedObjectRef{
+				Version:   "v1",
+				Kind:      "ConfigMap",
+				Name:      name,
+				Namespace: obj.Namespace,
+			})
+		}
+	}
+
+	if err := ensureRegistryAuth(ctx, c, recorder, owner, obj, out, log); err != nil {
+		return err
+	}
+
+	return ensureCertificateAuthority(ctx, c, obj, out, log)
+}
+
+// templatesForSecret maps a Secret to the BuildkitTemplates in its namespace that list it in their registryAuth,
+// so that changes to the credentials are merged into their Docker config.
+func (r *reconciler) templatesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var templates v1alpha1.BuildkitTemplateList
+	if err := r.c.List(ctx, &templates, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Errorw("Failed to list BuildkitTemplates for Secret", "secret", client.ObjectKeyFromObject(obj), "error", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, template := range templates.Items {
+		if UsesRegistryAuthSecret(&template.Spec, obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&template)})
+		}
+	}
+
+	return requests
+}
+
+// templateForBuildkit maps a Buildkit to the BuildkitTemplate it uses, either directly or through its pool,
+// so that the template's instance counts stay current.
+func (r *reconciler) templateForBuildkit(ctx context.Context, obj client.Object) []reconcile.Request {
+	bk, ok := obj.(*v1alpha1.Buildkit)
+	if !ok {
+		return nil
+	}
+
+	name := ""
+	if ref := bk.Spec.DirectTemplateRef(); ref != nil && !ref.IsCluster() {
+		name = ref.Name
+	} else if bk.Spec.Pool != "" {
+		var pool v1alpha1.BuildkitPool
+		if err := r.c.Get(ctx, client.ObjectKey{Name: bk.Spec.Pool, Namespace: bk.Namespace}, &pool); err != nil {
+			if !apierrors.IsNotFound(err) {
+				r.log.Errorw("Failed to get BuildkitPool for Buildkit", "buildkit", client.ObjectKeyFromObject(bk), "error", err)
+			}
+			return nil
+		}
+		name = pool.Spec.Template
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: name, Namespace: bk.Namespace}}}
+}
+
 func SetupController(
 	ctx context.Context,
 	cpCtx controlplane.Context,
@@ -79,9 +192,10 @@ func SetupController(
 	}
 
 	r := &reconciler{
-		c:      c,
-		scheme: mgr.GetScheme(),
-		log:    log,
+		c:        c,
+		scheme:   mgr.GetScheme(),
+		log:      log,
+		recorder: mgr.GetEventRecorderFor(controllerName),
 	}
 
 	builder := fsm.NewBuilder(
@@ -90,7 +204,14 @@ func SetupController(
 		mgr.GetScheme(),
 	).Manages(
 		corev1.SchemeGroupVersion.WithKind("ConfigMap"),
-	)
+		corev1.SchemeGroupVersion.WithKind("Secret"),
+	).Watches(
+		&corev1.Secret{},
+		handler.EnqueueRequestsFromMapFunc(r.templatesForSecret),
+	).Watches(
+		&v1alpha1.Buildkit{},
+		handler.EnqueueRequestsFromMapFunc(r.templateForBuildkit),
+	).WithFinalizerState(r.releaseConsumers())
 
 	return builder.Build()(mgr, log, rl, cpCtx.Metrics)
 }
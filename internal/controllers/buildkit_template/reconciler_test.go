@@ -17,6 +17,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
+	"github.com/seatgeek/buildkit-operator/internal/pki"
 )
 
 var _ = Describe("BuildkitTemplate Reconciler", func() {
@@ -82,6 +84,107 @@ var _ = Describe("BuildkitTemplate Reconciler", func() {
 		}, "3s", "100ms").Should(Succeed())
 	})
 
+	It("should report the Buildkits that use the template", func() {
+		buildkitTemplate.Spec.BuildkitdToml = someTomlContent
+		Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+
+		for _, name := range []string{"first", "second"} {
+			Expect(c.Create(ctx, &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       v1alpha1.BuildkitSpec{Template: buildkitTemplate.Name},
+			})).To(Succeed())
+		}
+		DeferCleanup(func() {
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.Buildkit{}, client.InNamespace(namespace))).To(Succeed())
+		})
+
+		var configHash string
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &updated)).To(Succeed())
+			g.Expect(updated.Status.TotalInstances).To(Equal(int32(2)))
+			g.Expect(updated.Status.ObservedGeneration).To(Equal(updated.Generation))
+			g.Expect(updated.Status.ConfigHash).NotTo(BeEmpty())
+			configHash = updated.Status.ConfigHash
+		}).Should(Succeed())
+
+		By("changing the buildkitd.toml")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.BuildkitdToml = someOtherTomlContent
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &updated)).To(Succeed())
+			g.Expect(updated.Status.ObservedGeneration).To(Equal(updated.Generation))
+			g.Expect(updated.Status.ConfigHash).NotTo(Equal(configHash))
+		}).Should(Succeed())
+
+		By("deleting one of the Buildkits")
+		Expect(c.Delete(ctx, &v1alpha1.Buildkit{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: namespace}})).To(Succeed())
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &updated)).To(Succeed())
+			g.Expect(updated.Status.TotalInstances).To(Equal(int32(1)))
+		}).Should(Succeed())
+	})
+
+	It("should keep a template in use until its Buildkits are gone", func() {
+		Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+		buildkit := &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: namespace},
+			Spec:       v1alpha1.BuildkitSpec{Template: buildkitTemplate.Name},
+		}
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		By("deleting the template while a Buildkit uses it")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &updated)).To(Succeed())
+			g.Expect(updated.Finalizers).NotTo(BeEmpty())
+		}).Should(Succeed())
+		Expect(c.Delete(ctx, buildkitTemplate)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(api.TypeReady).Reason).To(Equal(buildkit_template.ReasonDeletionBlocked))
+		}).Should(Succeed())
+
+		By("deleting the Buildkit")
+		Expect(c.Delete(ctx, buildkit)).To(Succeed())
+		Eventually(func(g Gomega) {
+			err := c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &v1alpha1.BuildkitTemplate{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "BuildkitTemplate should be deleted")
+		}).Should(Succeed())
+	})
+
+	It("should delete the Buildkits along with a template whose deletionPolicy is Cascade", func() {
+		buildkitTemplate.Spec.DeletionPolicy = v1alpha1.DeletionPolicyCascade
+		Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+		buildkit := &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: namespace},
+			Spec:       v1alpha1.BuildkitSpec{Template: buildkitTemplate.Name},
+		}
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.BuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &updated)).To(Succeed())
+			g.Expect(updated.Finalizers).NotTo(BeEmpty())
+		}).Should(Succeed())
+		Expect(c.Delete(ctx, buildkitTemplate)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			err := c.Get(ctx, client.ObjectKeyFromObject(buildkit), &v1alpha1.Buildkit{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "Buildkit should be deleted")
+			err = c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &v1alpha1.BuildkitTemplate{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "BuildkitTemplate should be deleted")
+		}).Should(Succeed())
+	})
+
 	It("should create ConfigMap when buildkitd.toml is added", func() {
 		By("creating BuildkitTemplate first")
 		Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
@@ -101,6 +204,16 @@ var _ = Describe("BuildkitTemplate Reconciler", func() {
 			g.Expect(configMap.Data).To(HaveKeyWithValue("buildkitd.toml", someTomlContent))
 		}).Should(Succeed())
 
+		By("verifying an Event is recorded on the BuildkitTemplate")
+		Eventually(func(g Gomega) {
+			var events corev1.EventList
+			g.Expect(c.List(ctx, &events, client.InNamespace(namespace))).To(Succeed())
+			g.Expect(events.Items).To(ContainElement(And(
+				HaveField("InvolvedObject.Name", buildkitTemplate.Name),
+				HaveField("Reason", v1alpha1.EventReasonConfigMapApplied),
+			)))
+		}).Should(Succeed())
+
 		By("verifying Ready condition remains True")
 		Eventually(func(g Gomega) {
 			var updated v1alpha1.BuildkitTemplate
@@ -213,4 +326,99 @@ var _ = Describe("BuildkitTemplate Reconciler", func() {
 			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "ConfigMap should be deleted")
 		}).Should(Succeed())
 	})
+
+	It("should manage a certificate authority while TLS is enabled", func() {
+		By("creating BuildkitTemplate with TLS enabled")
+		buildkitTemplate.Spec.TLS = &v1alpha1.BuildkitTemplateTLS{}
+		Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+
+		By("verifying a CA secret is created")
+		secretKey := client.ObjectKey{Name: fmt.Sprintf("buildkit-%s-ca", buildkitTemplate.Name), Namespace: namespace}
+		var original corev1.Secret
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, secretKey, &original)).To(Succeed())
+			g.Expect(original.Type).To(Equal(corev1.SecretTypeTLS))
+
+			ca, err := pki.ParseKeyPair(original.Data[pki.TLSCertKey], original.Data[pki.TLSKeyKey])
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(ca.Certificate.IsCA).To(BeTrue())
+		}).Should(Succeed())
+
+		By("verifying the CA is not regenerated on subsequent reconciles")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.BuildkitdToml = someTomlContent
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			var current corev1.Secret
+			g.Expect(c.Get(ctx, secretKey, &current)).To(Succeed())
+			g.Expect(current.Data).To(Equal(original.Data))
+		}, "2s", "100ms").Should(Succeed())
+
+		By("disabling TLS")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.TLS = nil
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the CA secret is deleted")
+		Eventually(func(g Gomega) {
+			err := c.Get(ctx, secretKey, &corev1.Secret{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "Secret should be deleted")
+		}).Should(Succeed())
+	})
+
+	It("should merge registry credentials and follow changes to their Secrets", func() {
+		By("creating a registry credentials Secret and a BuildkitTemplate using it along with a missing one")
+		credentials := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dockerhub", Namespace: namespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"dXNlcjpvbGQ="}}}`),
+			},
+		}
+		Expect(c.Create(ctx, credentials)).To(Succeed())
+
+		buildkitTemplate.Spec.RegistryAuth = []corev1.LocalObjectReference{{Name: "dockerhub"}, {Name: "missing"}}
+		Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+
+		By("verifying the merged Docker config is created without the missing Secret")
+		secretKey := client.ObjectKey{Name: fmt.Sprintf("buildkit-%s-registry-auth", buildkitTemplate.Name), Namespace: namespace}
+		Eventually(func(g Gomega) {
+			var merged corev1.Secret
+			g.Expect(c.Get(ctx, secretKey, &merged)).To(Succeed())
+			g.Expect(merged.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+			g.Expect(merged.Data[corev1.DockerConfigJsonKey]).To(MatchJSON(`{"auths":{"https://index.docker.io/v1/":{"auth":"dXNlcjpvbGQ="}}}`))
+		}).Should(Succeed())
+
+		By("updating the credentials")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(credentials), credentials)).To(Succeed())
+			credentials.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"dXNlcjpuZXc="}}}`)
+			g.Expect(c.Update(ctx, credentials)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the merged Docker config follows")
+		Eventually(func(g Gomega) {
+			var merged corev1.Secret
+			g.Expect(c.Get(ctx, secretKey, &merged)).To(Succeed())
+			g.Expect(merged.Data[corev1.DockerConfigJsonKey]).To(MatchJSON(`{"auths":{"https://index.docker.io/v1/":{"auth":"dXNlcjpuZXc="}}}`))
+		}).Should(Succeed())
+
+		By("removing the registry credentials from the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), buildkitTemplate)).To(Succeed())
+			buildkitTemplate.Spec.RegistryAuth = nil
+			g.Expect(c.Update(ctx, buildkitTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		By("verifying the merged Docker config is deleted")
+		Eventually(func(g Gomega) {
+			err := c.Get(ctx, secretKey, &corev1.Secret{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "Secret should be deleted")
+		}).Should(Succeed())
+	})
 })
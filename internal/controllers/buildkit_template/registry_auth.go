@@ -0,0 +1,116 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// dockerConfig is the part of a Docker config.json that holds registry credentials.
+type dockerConfig struct {
+	Auths map[string]json.RawMessage `json:"auths"`
+}
+
+// ensureRegistryAuth enqueues the Secret holding the credentials of the template's registryAuth Secrets merged into a
+// single Docker config. Secrets that are missing or don't hold a Docker config are left out and recorded as Events on
+// owner. If the template has no registryAuth, the Secret is removed.
+func ensureRegistryAuth(ctx context.Context, c client.Reader, recorder record.EventRecorder, owner runtime.Object, obj *v1alpha1.BuildkitTemplate, out *types.OutputSet, log *zap.SugaredLogger) error {
+	name := NewBuilder(obj).RegistryAuthSecretName()
+
+	if len(obj.Spec.RegistryAuth) == 0 {
+		log.Debugw("removing registry auth", "secret", name)
+		out.DeleteByRef(api.TypedObjectRef{
+			Version:   "v1",
+			Kind:      "Secret",
+			Name:      name,
+			Namespace: obj.Namespace,
+		})
+
+		return nil
+	}
+
+	var secrets []corev1.Secret
+	for _, ref := range obj.Spec.RegistryAuth {
+		key := client.ObjectKey{Name: ref.Name, Namespace: obj.Namespace}
+
+		var secret corev1.Secret
+		if err := c.Get(ctx, key, &secret); apierrors.IsNotFound(err) {
+			log.Warnw("registry auth secret not found", "secret", key)
+			recorder.Eventf(owner, corev1.EventTypeWarning, v1alpha1.EventReasonRegistryAuthSkipped, "Secret %s was not found, leaving its registry credentials out", key)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get registry auth secret '%s': %w", key, err)
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	merged, skipped := mergeDockerConfigs(secrets)
+	for _, key := range skipped {
+		log.Warnw("registry auth secret does not hold a docker config", "secret", key)
+		recorder.Eventf(owner, corev1.EventTypeWarning, v1alpha1.EventReasonRegistryAuthSkipped, "Secret %s is not a valid %s Secret, leaving its registry credentials out", key, corev1.SecretTypeDockerConfigJson)
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to encode merged docker config: %w", err)
+	}
+
+	out.Apply(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: obj.Namespace,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		},
+	})
+
+	return nil
+}
+
+// mergeDockerConfigs merges the registry credentials of kubernetes.io/dockerconfigjson Secrets, with later Secrets
+// taking precedence for the same registry. It also returns the Secrets that were left out for not holding a valid
+// Docker config.
+func mergeDockerConfigs(secrets []corev1.Secret) (dockerConfig, []client.ObjectKey) {
+	merged := dockerConfig{Auths: map[string]json.RawMessage{}}
+	var skipped []client.ObjectKey
+
+	for _, secret := range secrets {
+		var config dockerConfig
+		if secret.Type != corev1.SecretTypeDockerConfigJson || json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config) != nil {
+			skipped = append(skipped, client.ObjectKeyFromObject(&secret))
+			continue
+		}
+
+		maps.Copy(merged.Auths, config.Auths)
+	}
+
+	return merged, skipped
+}
+
+// UsesRegistryAuthSecret returns whether a template spec lists the named Secret in its registryAuth.
+func UsesRegistryAuthSecret(spec *v1alpha1.BuildkitTemplateSpec, name string) bool {
+	return slices.ContainsFunc(spec.RegistryAuth, func(ref corev1.LocalObjectReference) bool {
+		return ref.Name == name
+	})
+}
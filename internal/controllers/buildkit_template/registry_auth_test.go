@@ -0,0 +1,87 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func dockerConfigSecret(name string, config string) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(config)},
+	}
+}
+
+func TestMergeDockerConfigs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		secrets     []corev1.Secret
+		want        string
+		wantSkipped []client.ObjectKey
+	}{
+		{
+			name:    "no secrets",
+			secrets: nil,
+			want:    `{"auths":{}}`,
+		},
+		{
+			name: "merges the credentials of every secret",
+			secrets: []corev1.Secret{
+				dockerConfigSecret("dockerhub", `{"auths":{"https://index.docker.io/v1/":{"auth":"aHViOnNlY3JldA=="}}}`),
+				dockerConfigSecret("private", `{"auths":{"registry.example.com":{"username":"ci","password":"secret"}}}`),
+			},
+			want: `{"auths":{"https://index.docker.io/v1/":{"auth":"aHViOnNlY3JldA=="},"registry.example.com":{"username":"ci","password":"secret"}}}`,
+		},
+		{
+			name: "later secrets win for the same registry",
+			secrets: []corev1.Secret{
+				dockerConfigSecret("old", `{"auths":{"registry.example.com":{"auth":"b2xkOm9sZA=="}}}`),
+				dockerConfigSecret("new", `{"auths":{"registry.example.com":{"auth":"bmV3Om5ldw=="}}}`),
+			},
+			want: `{"auths":{"registry.example.com":{"auth":"bmV3Om5ldw=="}}}`,
+		},
+		{
+			name: "skips secrets that don't hold a docker config",
+			secrets: []corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "test-namespace"},
+					Type:       corev1.SecretTypeOpaque,
+					Data:       map[string][]byte{"token": []byte("secret")},
+				},
+				dockerConfigSecret("malformed", `{"auths":`),
+				dockerConfigSecret("valid", `{"auths":{"registry.example.com":{"auth":"Y2k6c2VjcmV0"}}}`),
+			},
+			want: `{"auths":{"registry.example.com":{"auth":"Y2k6c2VjcmV0"}}}`,
+			wantSkipped: []client.ObjectKey{
+				{Name: "opaque", Namespace: "test-namespace"},
+				{Name: "malformed", Namespace: "test-namespace"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, skipped := mergeDockerConfigs(tt.secrets)
+
+			got, err := json.Marshal(merged)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+			assert.Equal(t, tt.wantSkipped, skipped)
+		})
+	}
+}
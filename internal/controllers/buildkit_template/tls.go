@@ -0,0 +1,80 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package buildkit_template
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reddit/achilles-sdk-api/api"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/pki"
+)
+
+const (
+	// caValidity is how long a newly generated certificate authority is valid for.
+	caValidity = 5 * 365 * 24 * time.Hour
+	// caRenewBefore is how long before expiry the certificate authority is replaced.
+	// Buildkit instances pick up the new CA by having their own certificates re-issued.
+	caRenewBefore = 365 * 24 * time.Hour
+)
+
+// ensureCertificateAuthority enqueues the CA Secret for the BuildkitTemplate if TLS is enabled, generating a new CA
+// only when none exists yet or the existing one is close to expiry. If TLS is disabled, the Secret is removed.
+func ensureCertificateAuthority(ctx context.Context, c client.Reader, obj *v1alpha1.BuildkitTemplate, out *types.OutputSet, log *zap.SugaredLogger) error {
+	name := NewBuilder(obj).CASecretName()
+
+	if obj.Spec.TLS == nil {
+		log.Debugw("removing certificate authority", "secret", name)
+		out.DeleteByRef(api.TypedObjectRef{
+			Version:   "v1",
+			Kind:      "Secret",
+			Name:      name,
+			Namespace: obj.Namespace,
+		})
+
+		return nil
+	}
+
+	var existing corev1.Secret
+	if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: obj.Namespace}, &existing); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get certificate authority secret '%s': %w", name, err)
+	}
+
+	now := time.Now()
+	certPEM, keyPEM := existing.Data[pki.TLSCertKey], existing.Data[pki.TLSKeyKey]
+	if _, err := pki.ParseKeyPair(certPEM, keyPEM); err != nil || pki.NeedsRenewal(certPEM, nil, caRenewBefore, now) {
+		log.Infow("generating new certificate authority", "secret", name)
+
+		ca, err := pki.NewCA(fmt.Sprintf("buildkit-operator %s/%s", obj.Namespace, obj.Name), caValidity, now)
+		if err != nil {
+			return fmt.Errorf("failed to generate certificate authority: %w", err)
+		}
+
+		certPEM, keyPEM = ca.CertPEM, ca.KeyPEM
+	}
+
+	out.Apply(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: obj.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			pki.TLSCertKey: certPEM,
+			pki.TLSKeyKey:  keyPEM,
+		},
+	})
+
+	return nil
+}
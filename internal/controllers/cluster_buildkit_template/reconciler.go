@@ -0,0 +1,186 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cluster_buildkit_template
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/reddit/achilles-sdk/pkg/fsm"
+	"github.com/reddit/achilles-sdk/pkg/fsm/types"
+	"github.com/reddit/achilles-sdk/pkg/io"
+	"github.com/reddit/achilles-sdk/pkg/logging"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_template"
+	"github.com/seatgeek/buildkit-operator/internal/controlplane"
+)
+
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=clusterbuildkittemplates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=clusterbuildkittemplates/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=clusterbuildkittemplates/finalizers,verbs=update
+//+kubebuilder:rbac:groups=buildkit.seatgeek.io,resources=buildkits,verbs=get;list;watch;delete
+//+kubebuilder:rbac:resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=persistentvolumeclaims,verbs=get;list;watch
+//+kubebuilder:rbac:resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:resources=events,verbs=create;patch
+
+const controllerName = "ClusterBuildkitTemplate"
+
+type state = types.State[*v1alpha1.ClusterBuildkitTemplate]
+
+type reconciler struct {
+	c        *io.ClientApplicator
+	scheme   *runtime.Scheme
+	log      *zap.SugaredLogger
+	recorder record.EventRecorder
+}
+
+func (r *reconciler) createResources() *state {
+	return &state{
+		Name:      "create-resources",
+		Condition: conditionReady,
+		Transition: func(ctx context.Context, obj *v1alpha1.ClusterBuildkitTemplate, out *types.OutputSet) (*state, types.Result) {
+			log := r.log.With("name", obj.Name)
+
+			instances, err := buildkit_template.Instances(ctx, r.c, v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: obj.Name}, "")
+			if err != nil {
+				return nil, types.ErrorResult(err)
+			}
+			namespaces := consumingNamespaces(instances)
+
+			// Each namespace with Buildkits using the template gets its own copy of the ConfigMaps, registry credentials and
+			// certificate authority
+			for _, namespace := range namespaces {
+				if err := buildkit_template.ApplyResources(ctx, r.c, r.recorder, obj, obj.InNamespace(namespace), out, log.With("namespace", namespace)); err != nil {
+					return nil, types.ErrorResult(fmt.Errorf("failed to apply resources in namespace '%s': %w", namespace, err))
+				}
+			}
+
+			// Clean up after namespaces that no longer use the template
+			for _, ref := range obj.Status.ResourceRefs {
+				if !slices.Contains(namespaces, ref.Namespace) {
+					log.Debugw("removing resource from namespace that no longer uses the template", "resource", ref)
+					out.DeleteByRef(ref)
+				}
+			}
+
+			obj.Status.Namespaces = namespaces
+			obj.Status.TemplateInstanceCounts = buildkit_template.CountInstances(instances)
+			obj.Status.ConfigHash = buildkit_template.NewBuilder(obj.InNamespace("")).ConfigHash()
+			obj.Status.ObservedGeneration = obj.Generation
+
+			return nil, types.DoneResult()
+		},
+	}
+}
+
+// releaseConsumers keeps the template around until the Buildkits using it are gone, per its deletionPolicy.
+func (r *reconciler) releaseConsumers() *state {
+	return &state{
+		Name:      "release-consumers",
+		Condition: conditionReady,
+		Transition: func(ctx context.Context, obj *v1alpha1.ClusterBuildkitTemplate, _ *types.OutputSet) (*state, types.Result) {
+			log := r.log.With("name", obj.Name)
+			ref := v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: obj.Name}
+
+			return nil, buildkit_template.ReleaseConsumers(ctx, r.c, r.recorder, obj, ref, "", obj.Spec.DeletionPolicy, log)
+		},
+	}
+}
+
+// consumingNamespaces returns the sorted namespaces of the Buildkits started from a ClusterBuildkitTemplate.
+// Whether those namespaces are allowed to use the template is checked when the Buildkits are admitted.
+func consumingNamespaces(instances []v1alpha1.Buildkit) []string {
+	var namespaces []string
+	for _, bk := range instances {
+		namespaces = append(namespaces, bk.Namespace)
+	}
+	slices.Sort(namespaces)
+
+	return slices.Compact(namespaces)
+}
+
+// templateForBuildkit maps a Buildkit to the ClusterBuildkitTemplate it references, if any,
+// so that its namespace gets the template's resources as soon as it starts using it.
+func (r *reconciler) templateForBuildkit(_ context.Context, obj client.Object) []reconcile.Request {
+	bk, ok := obj.(*v1alpha1.Buildkit)
+	if !ok {
+		return nil
+	}
+
+	if ref := bk.Spec.DirectTemplateRef(); ref.IsCluster() {
+		return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: ref.Name}}}
+	}
+
+	return nil
+}
+
+// templatesForSecret maps a Secret to the ClusterBuildkitTemplates that list it in their registryAuth and are used in
+// its namespace, so that changes to the credentials are merged into their Docker config there.
+func (r *reconciler) templatesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var templates v1alpha1.ClusterBuildkitTemplateList
+	if err := r.c.List(ctx, &templates); err != nil {
+		r.log.Errorw("Failed to list ClusterBuildkitTemplates for Secret", "secret", client.ObjectKeyFromObject(obj), "error", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, template := range templates.Items {
+		if slices.Contains(template.Status.Namespaces, obj.GetNamespace()) && buildkit_template.UsesRegistryAuthSecret(&template.Spec.BuildkitTemplateSpec, obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: template.Name}})
+		}
+	}
+
+	return requests
+}
+
+func SetupController(
+	ctx context.Context,
+	cpCtx controlplane.Context,
+	mgr ctrl.Manager,
+	rl workqueue.TypedRateLimiter[reconcile.Request],
+	c *io.ClientApplicator,
+) error {
+	_, log, err := logging.ControllerCtx(ctx, controllerName)
+	if err != nil {
+		return err
+	}
+
+	r := &reconciler{
+		c:        c,
+		scheme:   mgr.GetScheme(),
+		log:      log,
+		recorder: mgr.GetEventRecorderFor(controllerName),
+	}
+
+	builder := fsm.NewBuilder(
+		&v1alpha1.ClusterBuildkitTemplate{},
+		r.createResources(),
+		mgr.GetScheme(),
+	).Manages(
+		corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		corev1.SchemeGroupVersion.WithKind("Secret"),
+	).Watches(
+		&v1alpha1.Buildkit{},
+		handler.EnqueueRequestsFromMapFunc(r.templateForBuildkit),
+	).Watches(
+		&corev1.Secret{},
+		handler.EnqueueRequestsFromMapFunc(r.templatesForSecret),
+	).WithFinalizerState(r.releaseConsumers())
+
+	return builder.Build()(mgr, log, rl, cpCtx.Metrics)
+}
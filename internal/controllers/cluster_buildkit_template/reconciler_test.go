@@ -0,0 +1,146 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cluster_buildkit_template_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/reddit/achilles-sdk-api/api"
+	sdktest "github.com/reddit/achilles-sdk/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+var _ = Describe("ClusterBuildkitTemplate Reconciler", func() {
+	const someTomlContent = `
+[worker.oci]
+  enabled = true`
+
+	var (
+		namespaces      []string
+		clusterTemplate *v1alpha1.ClusterBuildkitTemplate
+	)
+
+	BeforeEach(func() {
+		suffix := sdktest.GenerateRandomString(8)
+
+		namespaces = []string{"reconciler-test-a-" + suffix, "reconciler-test-b-" + suffix}
+		for _, namespace := range namespaces {
+			Expect(c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+		}
+
+		clusterTemplate = &v1alpha1.ClusterBuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-template-" + suffix,
+			},
+			Spec: v1alpha1.ClusterBuildkitTemplateSpec{
+				BuildkitTemplateSpec: v1alpha1.BuildkitTemplateSpec{
+					BuildkitdToml: someTomlContent,
+				},
+				NamespaceSelector: &metav1.LabelSelector{},
+			},
+		}
+
+		DeferCleanup(func() {
+			for _, namespace := range namespaces {
+				Expect(c.DeleteAllOf(ctx, &v1alpha1.Buildkit{}, client.InNamespace(namespace))).To(Succeed())
+				Expect(c.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+			}
+			Expect(client.IgnoreNotFound(c.Delete(ctx, clusterTemplate))).To(Succeed())
+		})
+	})
+
+	createBuildkit := func(namespace string) *v1alpha1.Buildkit {
+		GinkgoHelper()
+
+		buildkit := &v1alpha1.Buildkit{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-buildkit",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.BuildkitSpec{
+				TemplateRef: &v1alpha1.TemplateReference{
+					Kind: v1alpha1.TemplateKindClusterBuildkitTemplate,
+					Name: clusterTemplate.Name,
+				},
+			},
+		}
+		Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+		return buildkit
+	}
+
+	It("should create the template's ConfigMaps in every namespace that uses it", func() {
+		By("creating the ClusterBuildkitTemplate")
+		Expect(c.Create(ctx, clusterTemplate)).To(Succeed())
+
+		By("verifying Ready condition is True")
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.ClusterBuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(clusterTemplate), &updated)).To(Succeed())
+			g.Expect(updated.GetCondition(api.TypeReady).Status).To(Equal(corev1.ConditionTrue))
+			g.Expect(updated.Status.Namespaces).To(BeEmpty())
+		}).Should(Succeed())
+
+		By("creating Buildkits that reference the template in both namespaces")
+		createBuildkit(namespaces[0])
+		second := createBuildkit(namespaces[1])
+
+		By("verifying the ConfigMap is created in both namespaces")
+		for _, namespace := range namespaces {
+			configMapKey := client.ObjectKey{Name: fmt.Sprintf("buildkit-cluster-%s-toml", clusterTemplate.Name), Namespace: namespace}
+			Eventually(func(g Gomega) {
+				configMap := &corev1.ConfigMap{}
+				g.Expect(c.Get(ctx, configMapKey, configMap)).To(Succeed())
+				g.Expect(configMap.Data).To(HaveKeyWithValue("buildkitd.toml", someTomlContent))
+			}).Should(Succeed())
+		}
+
+		Eventually(func(g Gomega) {
+			var updated v1alpha1.ClusterBuildkitTemplate
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(clusterTemplate), &updated)).To(Succeed())
+			g.Expect(updated.Status.Namespaces).To(Equal(namespaces))
+		}).Should(Succeed())
+
+		By("deleting the Buildkit in the second namespace")
+		Expect(c.Delete(ctx, second)).To(Succeed())
+
+		By("verifying the ConfigMap is removed from the second namespace only")
+		Eventually(func(g Gomega) {
+			err := c.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("buildkit-cluster-%s-toml", clusterTemplate.Name), Namespace: namespaces[1]}, &corev1.ConfigMap{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "ConfigMap should be deleted")
+		}).Should(Succeed())
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("buildkit-cluster-%s-toml", clusterTemplate.Name), Namespace: namespaces[0]}, &corev1.ConfigMap{})).To(Succeed())
+	})
+
+	It("should update the ConfigMaps when the template changes", func() {
+		Expect(c.Create(ctx, clusterTemplate)).To(Succeed())
+		createBuildkit(namespaces[0])
+
+		configMapKey := client.ObjectKey{Name: fmt.Sprintf("buildkit-cluster-%s-toml", clusterTemplate.Name), Namespace: namespaces[0]}
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, configMapKey, &corev1.ConfigMap{})).To(Succeed())
+		}).Should(Succeed())
+
+		By("removing buildkitd.toml from the template")
+		Eventually(func(g Gomega) {
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(clusterTemplate), clusterTemplate)).To(Succeed())
+			clusterTemplate.Spec.BuildkitdToml = ""
+			g.Expect(c.Update(ctx, clusterTemplate)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			err := c.Get(ctx, configMapKey, &corev1.ConfigMap{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "ConfigMap should be deleted")
+		}).Should(Succeed())
+	})
+})
@@ -4,11 +4,18 @@
 
 package controlplane
 
-import "github.com/reddit/achilles-sdk/pkg/fsm/metrics"
+import (
+	"github.com/reddit/achilles-sdk/pkg/fsm/metrics"
+
+	buildkitmetrics "github.com/seatgeek/buildkit-operator/internal/metrics"
+)
 
 // Context holds information on how the controller should run. These values may
 // be referenced during the execution of transition functions.
 type Context struct {
 	// Metrics is the prometheus metrics sink for this controller binary.
 	Metrics *metrics.Metrics
+
+	// BuildkitMetrics records the operator's own metrics about Buildkit instances and their pods.
+	BuildkitMetrics *buildkitmetrics.Metrics
 }
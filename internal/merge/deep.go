@@ -0,0 +1,28 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package merge
+
+// Deep merges documents like those decoded from TOML or JSON, with later documents taking precedence. Nested maps are
+// merged key by key, while any other value, slices included, replaces the earlier one as a whole. The inputs are not
+// modified.
+func Deep(docs ...map[string]any) map[string]any {
+	merged := make(map[string]any)
+	for _, doc := range docs {
+		for k, v := range doc {
+			src, srcIsMap := v.(map[string]any)
+			dst, dstIsMap := merged[k].(map[string]any)
+			switch {
+			case srcIsMap && dstIsMap:
+				merged[k] = Deep(dst, src)
+			case srcIsMap:
+				merged[k] = Deep(src)
+			default:
+				merged[k] = v
+			}
+		}
+	}
+
+	return merged
+}
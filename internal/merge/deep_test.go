@@ -0,0 +1,84 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package merge_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seatgeek/buildkit-operator/internal/merge"
+)
+
+func TestDeep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    []map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "no documents",
+			input:    nil,
+			expected: map[string]any{},
+		},
+		{
+			name: "merges nested maps key by key",
+			input: []map[string]any{
+				{"worker": map[string]any{"oci": map[string]any{"gc": true, "max-parallelism": 2}}},
+				{"worker": map[string]any{"oci": map[string]any{"max-parallelism": 4}}, "debug": true},
+			},
+			expected: map[string]any{
+				"worker": map[string]any{"oci": map[string]any{"gc": true, "max-parallelism": 4}},
+				"debug":  true,
+			},
+		},
+		{
+			name: "replaces slices as a whole",
+			input: []map[string]any{
+				{"mirrors": []any{"a", "b"}},
+				{"mirrors": []string{"c"}},
+			},
+			expected: map[string]any{
+				"mirrors": []string{"c"},
+			},
+		},
+		{
+			name: "replaces values of a different type",
+			input: []map[string]any{
+				{"log": "json"},
+				{"log": map[string]any{"format": "text"}},
+				{"history": map[string]any{"maxEntries": 10}},
+				{"history": false},
+			},
+			expected: map[string]any{
+				"log":     map[string]any{"format": "text"},
+				"history": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := merge.Deep(tt.input...)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDeep_DoesNotModifyInputs(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]any{"worker": map[string]any{"oci": map[string]any{"gc": true}}}
+	overlay := map[string]any{"worker": map[string]any{"oci": map[string]any{"gc": false}}}
+
+	merge.Deep(base, overlay)
+
+	assert.Equal(t, map[string]any{"worker": map[string]any{"oci": map[string]any{"gc": true}}}, base)
+	assert.Equal(t, map[string]any{"worker": map[string]any{"oci": map[string]any{"gc": false}}}, overlay)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package metrics provides the Prometheus metrics that describe Buildkit instances, labelled by namespace and template.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const metricsNamespace = "buildkit_operator"
+
+// Reasons for replacing a Buildkit pod, as reported by the replacements counter.
+// Failed pods replaced under a template's recovery policy are counted as restarts instead.
+const (
+	ReplacementTemplateChanged    = "TemplateChanged"
+	ReplacementCertificateRotated = "CertificateRotated"
+	ReplacementResizeNotPossible  = "ResizeNotPossible"
+)
+
+// Metrics records what happens to Buildkit instances and their pods.
+type Metrics struct {
+	timeToReady  *prometheus.HistogramVec
+	instances    *prometheus.GaugeVec
+	clamped      *prometheus.GaugeVec
+	podFailures  *prometheus.CounterVec
+	restarts     *prometheus.CounterVec
+	replacements *prometheus.CounterVec
+
+	// mu guards tracked, which remembers what each instance last contributed to the gauges,
+	// and readyPods, which remembers the last pod of each instance whose time to ready was observed
+	mu        sync.Mutex
+	tracked   map[types.NamespacedName]instance
+	readyPods map[types.NamespacedName]string
+}
+
+type instance struct {
+	template string
+	phase    string
+	clamped  bool
+}
+
+// MustMakeMetrics creates the metrics and registers them with the given registerer, panicking if that fails.
+func MustMakeMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		timeToReady: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "time_to_ready_seconds",
+			Help:      "Time from a Buildkit instance or its pod being created, whichever is later, until the pod is ready.",
+			Buckets:   []float64{1, 2.5, 5, 10, 20, 30, 60, 120, 300, 600},
+		}, []string{"namespace", "template"}),
+		instances: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "instances",
+			Help:      "Number of Buildkit instances by phase.",
+		}, []string{"namespace", "template", "phase"}),
+		clamped: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "resources_clamped_instances",
+			Help:      "Number of Buildkit instances whose resources were clamped to their template's maximums.",
+		}, []string{"namespace", "template"}),
+		podFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pod_failures_total",
+			Help:      "Number of Buildkit pods seen to have failed.",
+		}, []string{"namespace", "template"}),
+		restarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pod_restarts_total",
+			Help:      "Number of failed Buildkit pods replaced under their template's recovery policy.",
+		}, []string{"namespace", "template"}),
+		replacements: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pod_replacements_total",
+			Help:      "Number of healthy Buildkit pods replaced by the operator, by reason.",
+		}, []string{"namespace", "template", "reason"}),
+		tracked:   map[types.NamespacedName]instance{},
+		readyPods: map[types.NamespacedName]string{},
+	}
+
+	reg.MustRegister(m.timeToReady, m.instances, m.clamped, m.podFailures, m.restarts, m.replacements)
+
+	return m
+}
+
+// ObserveTimeToReady records how long an instance's pod took to become ready.
+// Each pod is only observed once, however often it's reported.
+func (m *Metrics) ObserveTimeToReady(key types.NamespacedName, pod, template string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.readyPods[key] == pod {
+		return
+	}
+
+	m.readyPods[key] = pod
+	m.timeToReady.WithLabelValues(key.Namespace, template).Observe(max(d, 0).Seconds())
+}
+
+// RecordInstance records the current phase of an instance and whether its resources are clamped,
+// replacing whatever was recorded for it before.
+func (m *Metrics) RecordInstance(key types.NamespacedName, template, phase string, clamped bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := instance{template: template, phase: phase, clamped: clamped}
+	if previous, ok := m.tracked[key]; ok {
+		if previous == current {
+			return
+		}
+		m.untrack(key, previous)
+	}
+
+	m.tracked[key] = current
+	m.instances.WithLabelValues(key.Namespace, template, phase).Inc()
+	if clamped {
+		m.clamped.WithLabelValues(key.Namespace, template).Inc()
+	}
+}
+
+// ForgetInstance removes an instance that no longer exists from the gauges.
+func (m *Metrics) ForgetInstance(key types.NamespacedName) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if previous, ok := m.tracked[key]; ok {
+		m.untrack(key, previous)
+		delete(m.tracked, key)
+	}
+	delete(m.readyPods, key)
+}
+
+func (m *Metrics) untrack(key types.NamespacedName, previous instance) {
+	m.instances.WithLabelValues(key.Namespace, previous.template, previous.phase).Dec()
+	if previous.clamped {
+		m.clamped.WithLabelValues(key.Namespace, previous.template).Dec()
+	}
+}
+
+// RecordPodFailure records that an instance's pod has failed.
+func (m *Metrics) RecordPodFailure(namespace, template string) {
+	m.podFailures.WithLabelValues(namespace, template).Inc()
+}
+
+// RecordRestart records that a failed pod was replaced under its template's recovery policy.
+func (m *Metrics) RecordRestart(namespace, template string) {
+	m.restarts.WithLabelValues(namespace, template).Inc()
+}
+
+// RecordReplacement records that a pod was replaced for the given reason.
+func (m *Metrics) RecordReplacement(namespace, template, reason string) {
+	m.replacements.WithLabelValues(namespace, template, reason).Inc()
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRecordInstance(t *testing.T) {
+	t.Parallel()
+
+	m := MustMakeMetrics(prometheus.NewRegistry())
+	first := types.NamespacedName{Namespace: "ns", Name: "first"}
+	second := types.NamespacedName{Namespace: "ns", Name: "second"}
+
+	m.RecordInstance(first, "amd64", "Pending", false)
+	m.RecordInstance(second, "amd64", "Pending", true)
+	assert.InDelta(t, 2, testutil.ToFloat64(m.instances.WithLabelValues("ns", "amd64", "Pending")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.clamped.WithLabelValues("ns", "amd64")), 0)
+
+	// Recording the same state again doesn't count the instance twice
+	m.RecordInstance(first, "amd64", "Pending", false)
+	assert.InDelta(t, 2, testutil.ToFloat64(m.instances.WithLabelValues("ns", "amd64", "Pending")), 0)
+
+	// Moving to another phase moves the instance between series
+	m.RecordInstance(first, "amd64", "Ready", false)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.instances.WithLabelValues("ns", "amd64", "Pending")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.instances.WithLabelValues("ns", "amd64", "Ready")), 0)
+
+	// Forgetting an instance removes everything it contributed
+	m.ForgetInstance(second)
+	assert.InDelta(t, 0, testutil.ToFloat64(m.instances.WithLabelValues("ns", "amd64", "Pending")), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(m.clamped.WithLabelValues("ns", "amd64")), 0)
+
+	// Forgetting an unknown instance is harmless
+	m.ForgetInstance(second)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.instances.WithLabelValues("ns", "amd64", "Ready")), 0)
+}
+
+func TestObserveTimeToReady(t *testing.T) {
+	t.Parallel()
+
+	m := MustMakeMetrics(prometheus.NewRegistry())
+	key := types.NamespacedName{Namespace: "ns", Name: "buildkit"}
+
+	// The same pod is only observed once
+	m.ObserveTimeToReady(key, "pod-a", "amd64", 10*time.Second)
+	m.ObserveTimeToReady(key, "pod-a", "amd64", 20*time.Second)
+	assert.Equal(t, uint64(1), sampleCount(t, m.timeToReady.WithLabelValues("ns", "amd64")))
+
+	// A replacement pod is observed again, as is the same pod once its instance has been forgotten
+	m.ObserveTimeToReady(key, "pod-b", "amd64", 5*time.Second)
+	m.ForgetInstance(key)
+	m.ObserveTimeToReady(key, "pod-b", "amd64", 5*time.Second)
+	assert.Equal(t, uint64(3), sampleCount(t, m.timeToReady.WithLabelValues("ns", "amd64")))
+}
+
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+
+	var metric dto.Metric
+	require.NoError(t, observer.(prometheus.Metric).Write(&metric))
+
+	return metric.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package pki implements the small certificate authority the operator runs for Buildkit mTLS.
+// It deliberately avoids any dependency on cert-manager: CAs and leaf certificates are generated
+// in-process and persisted in Secrets by the controllers.
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// Secret data keys; these match the conventions of kubernetes.io/tls Secrets.
+const (
+	CACertKey  = "ca.crt"
+	TLSCertKey = "tls.crt"
+	TLSKeyKey  = "tls.key"
+)
+
+// clockSkew backdates NotBefore so that freshly issued certificates are accepted by peers with slightly slow clocks.
+const clockSkew = 5 * time.Minute
+
+// KeyPair is a parsed certificate along with its private key and their PEM encodings.
+type KeyPair struct {
+	Certificate *x509.Certificate
+	Key         crypto.Signer
+
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// Usage describes which side of a TLS connection a leaf certificate is issued for.
+type Usage int
+
+const (
+	UsageServer Usage = iota
+	UsageClient
+)
+
+// CertificateRequest describes a leaf certificate to be issued by a CA.
+type CertificateRequest struct {
+	CommonName  string
+	DNSNames    []string
+	IPAddresses []net.IP
+	Usage       Usage
+	Validity    time.Duration
+}
+
+// NewCA generates a self-signed certificate authority valid for the given duration starting at now.
+func NewCA(commonName string, validity time.Duration, now time.Time) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating CA key: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	return sign(template, template, key, key)
+}
+
+// Issue signs a new leaf certificate for the given request. The certificate never outlives the CA.
+func (ca *KeyPair) Issue(req CertificateRequest, now time.Time) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	notAfter := now.Add(req.Validity)
+	if notAfter.After(ca.Certificate.NotAfter) {
+		notAfter = ca.Certificate.NotAfter
+	}
+
+	extKeyUsage := x509.ExtKeyUsageServerAuth
+	if req.Usage == UsageClient {
+		extKeyUsage = x509.ExtKeyUsageClientAuth
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: req.CommonName},
+		DNSNames:     req.DNSNames,
+		IPAddresses:  req.IPAddresses,
+		NotBefore:    now.Add(-clockSkew),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+	}
+
+	return sign(template, ca.Certificate, key, ca.Key)
+}
+
+// ParseKeyPair parses a PEM-encoded certificate and private key, such as those stored in a Secret.
+func ParseKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("no PEM data found for private key")
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	key, ok := parsedKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", parsedKey)
+	}
+
+	return &KeyPair{
+		Certificate: cert,
+		Key:         key,
+		CertPEM:     certPEM,
+		KeyPEM:      keyPEM,
+	}, nil
+}
+
+// NeedsRenewal reports whether the PEM-encoded certificate is missing, unparseable, not signed by the given CA,
+// or will expire within renewBefore of now.
+// Passing a nil CA skips the signature check, which is useful for checking the CA itself.
+func NeedsRenewal(certPEM []byte, ca *KeyPair, renewBefore time.Duration, now time.Time) bool {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return true
+	}
+
+	if ca != nil && cert.CheckSignatureFrom(ca.Certificate) != nil {
+		return true
+	}
+
+	return !now.Add(renewBefore).Before(cert.NotAfter)
+}
+
+// Fingerprint returns a short, stable identifier for a PEM-encoded certificate.
+func Fingerprint(certPEM []byte) string {
+	sum := sha256.Sum256(certPEM)
+	return hex.EncodeToString(sum[:8])
+}
+
+func sign(template, parent *x509.Certificate, key crypto.Signer, parentKey crypto.Signer) (*KeyPair, error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing created certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling private key: %w", err)
+	}
+
+	return &KeyPair{
+		Certificate: cert,
+		Key:         key,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:      pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data found for certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generating serial number: %w", err)
+	}
+
+	return serial, nil
+}
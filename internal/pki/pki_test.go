@@ -0,0 +1,162 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package pki_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seatgeek/buildkit-operator/internal/pki"
+)
+
+func TestIssue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	ca, err := pki.NewCA("test-ca", 24*time.Hour, now)
+	require.NoError(t, err)
+	assert.True(t, ca.Certificate.IsCA)
+
+	server, err := ca.Issue(pki.CertificateRequest{
+		CommonName:  "buildkit",
+		DNSNames:    []string{"buildkit.ns.svc"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		Usage:       pki.UsageServer,
+		Validity:    time.Hour,
+	}, now)
+	require.NoError(t, err)
+
+	client, err := ca.Issue(pki.CertificateRequest{
+		CommonName: "client",
+		Usage:      pki.UsageClient,
+		Validity:   48 * time.Hour, // longer than the CA
+	}, now)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+
+	_, err = server.Certificate.Verify(x509.VerifyOptions{
+		DNSName:   "buildkit.ns.svc",
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Certificate.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, ca.Certificate.NotAfter, client.Certificate.NotAfter, "leaf certificates must not outlive the CA")
+
+	// The PEM output must be usable as-is by TLS clients and servers
+	_, err = tls.X509KeyPair(server.CertPEM, server.KeyPEM)
+	require.NoError(t, err)
+}
+
+func TestParseKeyPair(t *testing.T) {
+	t.Parallel()
+
+	ca, err := pki.NewCA("test-ca", time.Hour, time.Now())
+	require.NoError(t, err)
+
+	parsed, err := pki.ParseKeyPair(ca.CertPEM, ca.KeyPEM)
+	require.NoError(t, err)
+	assert.Equal(t, ca.Certificate.SerialNumber, parsed.Certificate.SerialNumber)
+
+	_, err = pki.ParseKeyPair([]byte("garbage"), ca.KeyPEM)
+	require.Error(t, err)
+
+	_, err = pki.ParseKeyPair(ca.CertPEM, nil)
+	require.Error(t, err)
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	ca, err := pki.NewCA("test-ca", 30*24*time.Hour, now)
+	require.NoError(t, err)
+
+	otherCA, err := pki.NewCA("other-ca", 30*24*time.Hour, now)
+	require.NoError(t, err)
+
+	leaf, err := ca.Issue(pki.CertificateRequest{CommonName: "leaf", Validity: 10 * 24 * time.Hour}, now)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		certPEM     []byte
+		ca          *pki.KeyPair
+		renewBefore time.Duration
+		now         time.Time
+		want        bool
+	}{
+		{
+			name:        "valid certificate",
+			certPEM:     leaf.CertPEM,
+			ca:          ca,
+			renewBefore: 24 * time.Hour,
+			now:         now,
+			want:        false,
+		},
+		{
+			name:        "within renewal window",
+			certPEM:     leaf.CertPEM,
+			ca:          ca,
+			renewBefore: 24 * time.Hour,
+			now:         now.Add(9*24*time.Hour + time.Minute),
+			want:        true,
+		},
+		{
+			name:        "expired",
+			certPEM:     leaf.CertPEM,
+			ca:          ca,
+			renewBefore: 0,
+			now:         now.Add(11 * 24 * time.Hour),
+			want:        true,
+		},
+		{
+			name:        "signed by a different CA",
+			certPEM:     leaf.CertPEM,
+			ca:          otherCA,
+			renewBefore: 24 * time.Hour,
+			now:         now,
+			want:        true,
+		},
+		{
+			name:        "missing certificate",
+			certPEM:     nil,
+			ca:          ca,
+			renewBefore: 24 * time.Hour,
+			now:         now,
+			want:        true,
+		},
+		{
+			name:        "self-signed CA without signature check",
+			certPEM:     ca.CertPEM,
+			ca:          nil,
+			renewBefore: 24 * time.Hour,
+			now:         now,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, pki.NeedsRenewal(tt.certPEM, tt.ca, tt.renewBefore, tt.now))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package buildkitd provides a fake buildkitd control API for tests.
+package buildkitd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Server is a fake buildkitd that reports a configurable number of builds in progress through its build history.
+type Server struct {
+	controlapi.UnimplementedControlServer
+
+	listener    net.Listener
+	grpc        *grpc.Server
+	activeBuild atomic.Int32
+}
+
+// Start serves the fake control API on a random local port, using tlsConfig if it is not nil.
+func Start(tlsConfig *tls.Config) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	s := &Server{
+		listener: listener,
+		grpc:     grpc.NewServer(opts...),
+	}
+	controlapi.RegisterControlServer(s.grpc, s)
+
+	go s.grpc.Serve(listener) //nolint:errcheck // returns once the server is stopped
+
+	return s, nil
+}
+
+// Addr returns the host:port the server is listening on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Port returns the port the server is listening on.
+func (s *Server) Port() int32 {
+	_, port, _ := net.SplitHostPort(s.Addr())
+	p, _ := strconv.ParseInt(port, 10, 32)
+	return int32(p) //nolint:gosec // parsed as a 32-bit integer
+}
+
+// SetActiveBuilds sets how many builds the server reports as in progress.
+func (s *Server) SetActiveBuilds(n int32) {
+	s.activeBuild.Store(n)
+}
+
+// Stop shuts the server down, closing any open connections.
+func (s *Server) Stop() {
+	s.grpc.Stop()
+}
+
+// ListenBuildHistory sends a STARTED event for each build in progress, then ends the stream like buildkitd does
+// for requests with EarlyExit set.
+func (s *Server) ListenBuildHistory(req *controlapi.BuildHistoryRequest, stream grpc.ServerStreamingServer[controlapi.BuildHistoryEvent]) error {
+	if !req.GetActiveOnly() || !req.GetEarlyExit() {
+		return errors.New("fake buildkitd only supports active-only, early-exit history requests")
+	}
+
+	for i := range s.activeBuild.Load() {
+		if err := stream.Send(&controlapi.BuildHistoryEvent{
+			Type:   controlapi.BuildHistoryEventType_STARTED,
+			Record: &controlapi.BuildHistoryRecord{Ref: fmt.Sprintf("build-%d", i)},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
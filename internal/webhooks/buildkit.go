@@ -7,11 +7,17 @@ package webhooks
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"slices"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -40,27 +46,63 @@ func (v *BuildkitValidator) ValidateCreate(ctx context.Context, obj runtime.Obje
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected Buildkit object but got %T", obj))
 	}
 
-	var errorList field.ErrorList
+	var (
+		warnings  admission.Warnings
+		errorList field.ErrorList
+	)
 
-	var template v1alpha1.BuildkitTemplate
+	// The instance's Service is named after it, so the name has to be a valid Service name too
+	for _, message := range validation.IsDNS1035Label(bk.Name) {
+		errorList = append(errorList, field.Invalid(field.NewPath("metadata", "name"), bk.Name, message))
+	}
 
-	// Validate template
-	if bk.Spec.Template == "" {
-		errorList = append(errorList, field.Required(field.NewPath("spec", "template"), "BuildkitTemplate name must be specified"))
-	} else if err := v.c.Get(ctx, client.ObjectKey{Namespace: bk.Namespace, Name: bk.Spec.Template}, &template); err != nil {
-		if !apierrors.IsNotFound(err) {
-			return nil, apierrors.NewInternalError(fmt.Errorf("failed to get BuildkitTemplate '%s' in namespace '%s': %w", bk.Spec.Template, bk.Namespace, err))
+	if bk.Spec.Template != "" && bk.Spec.TemplateRef != nil {
+		errorList = append(errorList, field.Forbidden(field.NewPath("spec", "templateRef"), "templateRef may not be set together with template"))
+	}
+
+	// Validate pool, which determines the template when set
+	ref := bk.Spec.DirectTemplateRef()
+	if bk.Spec.Pool != "" {
+		poolTemplate, poolErrs, err := v.validatePool(ctx, bk)
+		if err != nil {
+			return nil, err
+		}
+		ref = &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: poolTemplate}
+		errorList = append(errorList, poolErrs...)
+	}
+
+	// Validate template, unless the pool errors mean we can't tell which one it is
+	var template *v1alpha1.BuildkitTemplate
+	if len(errorList) == 0 {
+		if ref == nil || ref.Name == "" {
+			errorList = append(errorList, field.Required(field.NewPath("spec", "template"), "template, templateRef or pool must be specified, unless the namespace has a default BuildkitTemplate"))
+		} else {
+			var (
+				templateErrs field.ErrorList
+				err          error
+			)
+			template, templateErrs, err = v.validateTemplate(ctx, bk, ref)
+			if err != nil {
+				return nil, err
+			}
+			errorList = append(errorList, templateErrs...)
+
+			if template != nil && template.Spec.Lifecycle.RequireOwner && len(bk.GetOwnerReferences()) == 0 {
+				errorList = append(errorList, field.Required(
+					field.NewPath("metadata", "ownerReferences"),
+					fmt.Sprintf("%s '%s' requires owner references but none are present", ref.Kind, ref.Name),
+				))
+			}
+
+			resourceWarnings, resourceErrs := checkMaximums(bk, template)
+			warnings = append(warnings, resourceWarnings...)
+			errorList = append(errorList, resourceErrs...)
+			errorList = append(errorList, checkSchedulingOverrides(bk, template)...)
 		}
-		errorList = append(errorList, field.NotFound(field.NewPath("spec", "template"), bk.Spec.Template))
-	} else if template.Spec.Lifecycle.RequireOwner && len(bk.GetOwnerReferences()) == 0 {
-		errorList = append(errorList, field.Required(
-			field.NewPath("metadata", "ownerReferences"),
-			fmt.Sprintf("BuildkitTemplate '%s' requires owner references but none are present", bk.Spec.Template),
-		))
 	}
 
 	if len(errorList) > 0 {
-		return nil, apierrors.NewInvalid(
+		return warnings, apierrors.NewInvalid(
 			schema.GroupKind{
 				Group: v1alpha1.SchemeGroupVersion.Group,
 				Kind:  "Buildkit",
@@ -70,10 +112,110 @@ func (v *BuildkitValidator) ValidateCreate(ctx context.Context, obj runtime.Obje
 		)
 	}
 
-	return nil, nil
+	return warnings, v.checkQuotas(ctx, bk, template)
+}
+
+// validateTemplate checks that the template a Buildkit instance references exists and isn't being deleted and, for a
+// ClusterBuildkitTemplate, that the template's namespaceSelector lets the instance's namespace use it. It returns the
+// template as seen from the instance's namespace, or nil if it can't be used.
+func (v *BuildkitValidator) validateTemplate(ctx context.Context, bk *v1alpha1.Buildkit, ref *v1alpha1.TemplateReference) (*v1alpha1.BuildkitTemplate, field.ErrorList, error) {
+	if !ref.IsCluster() {
+		namePath := field.NewPath("spec", "template")
+		if bk.Spec.TemplateRef != nil {
+			namePath = field.NewPath("spec", "templateRef", "name")
+		}
+
+		var template v1alpha1.BuildkitTemplate
+		if err := v.c.Get(ctx, client.ObjectKey{Namespace: bk.Namespace, Name: ref.Name}, &template); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, nil, apierrors.NewInternalError(fmt.Errorf("failed to get BuildkitTemplate '%s' in namespace '%s': %w", ref.Name, bk.Namespace, err))
+			}
+			return nil, field.ErrorList{field.NotFound(namePath, ref.Name)}, nil
+		}
+
+		if template.DeletionTimestamp != nil {
+			return nil, field.ErrorList{field.Forbidden(namePath, fmt.Sprintf("BuildkitTemplate '%s' is being deleted", ref.Name))}, nil
+		}
+
+		return &template, nil, nil
+	}
+
+	var clusterTemplate v1alpha1.ClusterBuildkitTemplate
+	if err := v.c.Get(ctx, client.ObjectKey{Name: ref.Name}, &clusterTemplate); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, nil, apierrors.NewInternalError(fmt.Errorf("failed to get ClusterBuildkitTemplate '%s': %w", ref.Name, err))
+		}
+		return nil, field.ErrorList{field.NotFound(field.NewPath("spec", "templateRef", "name"), ref.Name)}, nil
+	}
+
+	if clusterTemplate.DeletionTimestamp != nil {
+		return nil, field.ErrorList{field.Forbidden(field.NewPath("spec", "templateRef", "name"), fmt.Sprintf("ClusterBuildkitTemplate '%s' is being deleted", ref.Name))}, nil
+	}
+
+	allowed, err := namespaceSelected(ctx, v.c, clusterTemplate.Spec.NamespaceSelector, bk.Namespace)
+	if err != nil {
+		return nil, nil, apierrors.NewInternalError(err)
+	}
+
+	if !allowed {
+		return nil, field.ErrorList{field.Forbidden(
+			field.NewPath("spec", "templateRef"),
+			fmt.Sprintf("ClusterBuildkitTemplate '%s' may not be used in namespace '%s'", ref.Name, bk.Namespace),
+		)}, nil
+	}
+
+	return clusterTemplate.InNamespace(bk.Namespace), nil, nil
+}
+
+// namespaceSelected reports whether a ClusterBuildkitTemplate's namespaceSelector matches the given namespace.
+// A nil selector matches no namespaces.
+func namespaceSelected(ctx context.Context, c client.Reader, selector *metav1.LabelSelector, namespace string) (bool, error) {
+	if selector == nil {
+		return false, nil
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+
+	var ns corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return false, fmt.Errorf("failed to get namespace '%s': %w", namespace, err)
+	}
+
+	return s.Matches(labels.Set(ns.Labels)), nil
+}
+
+// validatePool checks that a Buildkit instance's pool exists and that the instance can be served by one of its warm pods.
+// It returns the name of the pool's template.
+func (v *BuildkitValidator) validatePool(ctx context.Context, bk *v1alpha1.Buildkit) (string, field.ErrorList, error) {
+	var errorList field.ErrorList
+
+	if bk.Spec.Template != "" || bk.Spec.TemplateRef != nil {
+		errorList = append(errorList, field.Forbidden(field.NewPath("spec", "pool"), "pool may not be set together with template or templateRef"))
+	}
+
+	if len(bk.Spec.Resources.Requests) > 0 || len(bk.Spec.Resources.Limits) > 0 {
+		errorList = append(errorList, field.Forbidden(field.NewPath("spec", "resources"), "warm pods are started before they're claimed, so resources can't be set when using a pool"))
+	}
+
+	if !equality.Semantic.DeepEqual(bk.Spec.Scheduling, v1alpha1.BuildkitSchedulingOverrides{}) {
+		errorList = append(errorList, field.Forbidden(field.NewPath("spec", "scheduling"), "warm pods are scheduled before they're claimed, so scheduling can't be set when using a pool"))
+	}
+
+	var pool v1alpha1.BuildkitPool
+	if err := v.c.Get(ctx, client.ObjectKey{Namespace: bk.Namespace, Name: bk.Spec.Pool}, &pool); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", nil, apierrors.NewInternalError(fmt.Errorf("failed to get BuildkitPool '%s' in namespace '%s': %w", bk.Spec.Pool, bk.Namespace, err))
+		}
+		errorList = append(errorList, field.NotFound(field.NewPath("spec", "pool"), bk.Spec.Pool))
+	}
+
+	return pool.Spec.Template, errorList, nil
 }
 
-func (v *BuildkitValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *BuildkitValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldBk, ok := oldObj.(*v1alpha1.Buildkit)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected Buildkit object but got %T", oldObj))
@@ -84,14 +226,134 @@ func (v *BuildkitValidator) ValidateUpdate(_ context.Context, oldObj, newObj run
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected Buildkit object but got %T", newObj))
 	}
 
-	if !reflect.DeepEqual(oldBk.Spec, newBk.Spec) {
-		return nil, apierrors.NewBadRequest("spec changes are not allowed for existing Buildkit objects")
+	// Labels, annotations, resources and suspension can be changed on a running instance, but not what it runs from
+	// or where
+	specPath := field.NewPath("spec")
+	errorList := slices.Concat(
+		apivalidation.ValidateImmutableField(newBk.Spec.Template, oldBk.Spec.Template, specPath.Child("template")),
+		apivalidation.ValidateImmutableField(newBk.Spec.TemplateRef, oldBk.Spec.TemplateRef, specPath.Child("templateRef")),
+		apivalidation.ValidateImmutableField(newBk.Spec.Pool, oldBk.Spec.Pool, specPath.Child("pool")),
+		apivalidation.ValidateImmutableField(newBk.Spec.CacheKey, oldBk.Spec.CacheKey, specPath.Child("cacheKey")),
+		apivalidation.ValidateImmutableField(newBk.Spec.Scheduling, oldBk.Spec.Scheduling, specPath.Child("scheduling")),
+	)
+
+	if newBk.Spec.Pool != "" && (len(newBk.Spec.Resources.Requests) > 0 || len(newBk.Spec.Resources.Limits) > 0) {
+		errorList = append(errorList, field.Forbidden(specPath.Child("resources"), "warm pods are started before they're claimed, so resources can't be set when using a pool"))
 	}
 
-	return nil, nil
+	// Changed resources are held to the template's maximums and the namespace's quotas, like those of new instances
+	var (
+		warnings admission.Warnings
+		template *v1alpha1.BuildkitTemplate
+	)
+	resized := !equality.Semantic.DeepEqual(newBk.Spec.Resources, oldBk.Spec.Resources)
+	if len(errorList) == 0 && resized {
+		var err error
+		template, err = v.currentTemplate(ctx, newBk)
+		if err != nil {
+			return nil, err
+		}
+
+		var resourceErrs field.ErrorList
+		warnings, resourceErrs = checkMaximums(newBk, template)
+		errorList = append(errorList, resourceErrs...)
+	}
+
+	if len(errorList) > 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: v1alpha1.SchemeGroupVersion.Group,
+				Kind:  "Buildkit",
+			},
+			newBk.Name,
+			errorList,
+		)
+	}
+
+	if resized {
+		return warnings, v.checkQuotasOnResize(ctx, oldBk, newBk, template)
+	}
+
+	return warnings, nil
 }
 
 func (v *BuildkitValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	// No validation needed on delete
 	return nil, nil
 }
+
+// +kubebuilder:webhook:path=/mutate-buildkit-seatgeek-io-v1alpha1-buildkit,mutating=true,failurePolicy=fail,sideEffects=None,groups=buildkit.seatgeek.io,resources=buildkits,verbs=create,versions=v1alpha1,name=mbuildkit.kb.io,admissionReviewVersions=v1
+
+type BuildkitDefaulter struct {
+	c client.Reader
+}
+
+var _ webhook.CustomDefaulter = (*BuildkitDefaulter)(nil)
+
+func NewBuildkitDefaulter(c client.Reader) *BuildkitDefaulter {
+	return &BuildkitDefaulter{
+		c: c,
+	}
+}
+
+// Default fills in the namespace's default BuildkitTemplate for Buildkits that reference neither a template nor a pool.
+func (d *BuildkitDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	bk, ok := obj.(*v1alpha1.Buildkit)
+	if !ok {
+		return fmt.Errorf("expected Buildkit object, got %T", obj)
+	}
+
+	if bk.Spec.Template != "" || bk.Spec.TemplateRef != nil || bk.Spec.Pool != "" {
+		return nil
+	}
+
+	template, err := defaultTemplate(ctx, d.c, bk.Namespace)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	bk.Spec.Template = template
+	return nil
+}
+
+// defaultTemplate returns the name of the namespace's default BuildkitTemplate, or an empty string if it has none.
+// A template named by the namespace's label wins over one marked as the default by its annotation.
+func defaultTemplate(ctx context.Context, c client.Reader, namespace string) (string, error) {
+	var ns corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return "", fmt.Errorf("failed to get namespace '%s': %w", namespace, err)
+	}
+
+	if template := ns.Labels[v1alpha1.DefaultTemplateLabel]; template != "" {
+		return template, nil
+	}
+
+	defaults, err := defaultTemplates(ctx, c, namespace)
+	if err != nil || len(defaults) == 0 {
+		return "", err
+	}
+
+	return defaults[0], nil
+}
+
+// defaultTemplates returns the sorted names of the BuildkitTemplates in the namespace that are marked as its default.
+func defaultTemplates(ctx context.Context, c client.Reader, namespace string) ([]string, error) {
+	var templates v1alpha1.BuildkitTemplateList
+	if err := c.List(ctx, &templates, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list BuildkitTemplates in namespace '%s': %w", namespace, err)
+	}
+
+	var names []string
+	for _, template := range templates.Items {
+		if isDefaultTemplate(&template) {
+			names = append(names, template.Name)
+		}
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+func isDefaultTemplate(template *v1alpha1.BuildkitTemplate) bool {
+	return template.Annotations[v1alpha1.DefaultTemplateAnnotation] == "true"
+}
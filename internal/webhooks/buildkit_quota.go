@@ -0,0 +1,75 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit_quota"
+)
+
+// checkQuotas rejects a new Buildkit that would take its namespace over one of its BuildkitQuotas, counting the
+// Buildkit with the resources it gets from its template.
+func (v *BuildkitValidator) checkQuotas(ctx context.Context, bk *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) error {
+	return v.enforceQuotas(ctx, bk, buildkit_quota.EffectiveResources(templateSpec(template), bk), buildkit_quota.Check)
+}
+
+// checkQuotasOnResize rejects raising an existing Buildkit's resources when that would take its namespace over one
+// of its BuildkitQuotas. The Buildkit already counts as an instance, and lowering its resources is always allowed,
+// even in a namespace that's over its caps.
+func (v *BuildkitValidator) checkQuotasOnResize(ctx context.Context, oldBk, newBk *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) error {
+	requirements := buildkit_quota.EffectiveResources(templateSpec(template), newBk)
+	if !buildkit_quota.Grows(buildkit_quota.EffectiveResources(templateSpec(template), oldBk), requirements) {
+		return nil
+	}
+
+	return v.enforceQuotas(ctx, newBk, requirements, buildkit_quota.CheckResources)
+}
+
+// enforceQuotas checks the Buildkit, with the given effective resources, against each of its namespace's
+// BuildkitQuotas. Any earlier version of the Buildkit is left out of the namespace's usage, so it isn't counted twice.
+func (v *BuildkitValidator) enforceQuotas(ctx context.Context, bk *v1alpha1.Buildkit, requirements corev1.ResourceRequirements, check func(*v1alpha1.BuildkitQuota, v1alpha1.BuildkitQuotaUsage, corev1.ResourceRequirements) []string) error {
+	var quotas v1alpha1.BuildkitQuotaList
+	if err := v.c.List(ctx, &quotas, client.InNamespace(bk.Namespace)); err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("failed to list BuildkitQuotas in namespace '%s': %w", bk.Namespace, err))
+	}
+
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+
+	usage, err := buildkit_quota.UsageWithout(ctx, v.c, bk.Namespace, bk.Name)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	for _, quota := range quotas.Items {
+		if exceeded := check(&quota, usage, requirements); len(exceeded) > 0 {
+			return apierrors.NewForbidden(
+				schema.GroupResource{Group: v1alpha1.SchemeGroupVersion.Group, Resource: "buildkits"},
+				bk.Name,
+				fmt.Errorf("exceeded BuildkitQuota '%s': %s", quota.Name, strings.Join(exceeded, "; ")),
+			)
+		}
+	}
+
+	return nil
+}
+
+func templateSpec(template *v1alpha1.BuildkitTemplate) *v1alpha1.BuildkitTemplateSpec {
+	if template == nil {
+		return nil
+	}
+
+	return &template.Spec
+}
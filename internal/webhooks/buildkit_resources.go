@@ -0,0 +1,73 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/controllers/buildkit/resources"
+)
+
+// checkMaximums compares the resources a Buildkit asks for with its template's maximums. Requests and limits over them
+// are reduced to them when the pod is built, which the returned warnings point out, unless the template is strict
+// about its maximums, in which case they're errors instead.
+func checkMaximums(bk *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) (admission.Warnings, field.ErrorList) {
+	if template == nil {
+		return nil, nil
+	}
+
+	var (
+		warnings  admission.Warnings
+		errorList field.ErrorList
+	)
+
+	for _, excess := range resources.Exceeding(template.Spec.Resources.Maximum, bk.Spec.Resources) {
+		path := field.NewPath("spec", "resources", excess.Kind, string(excess.Name))
+		if template.Spec.Resources.Strict {
+			errorList = append(errorList, field.Invalid(path, excess.Desired.String(), fmt.Sprintf("over the maximum of %s set by template '%s'", excess.Maximum.String(), template.Name)))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: %s is over the maximum of %s set by template '%s', and will be reduced to it", path, excess.Desired.String(), excess.Maximum.String(), template.Name))
+		}
+	}
+
+	return warnings, errorList
+}
+
+// currentTemplate loads the template an existing Buildkit references directly, as seen from its namespace, or returns
+// nil if it can't be found. Buildkits in a pool can't set resources, so their template isn't needed.
+func (v *BuildkitValidator) currentTemplate(ctx context.Context, bk *v1alpha1.Buildkit) (*v1alpha1.BuildkitTemplate, error) {
+	ref := bk.Spec.DirectTemplateRef()
+	if ref == nil {
+		return nil, nil
+	}
+
+	if ref.IsCluster() {
+		var clusterTemplate v1alpha1.ClusterBuildkitTemplate
+		if err := v.c.Get(ctx, client.ObjectKey{Name: ref.Name}, &clusterTemplate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, apierrors.NewInternalError(fmt.Errorf("failed to get ClusterBuildkitTemplate '%s': %w", ref.Name, err))
+		}
+		return clusterTemplate.InNamespace(bk.Namespace), nil
+	}
+
+	var template v1alpha1.BuildkitTemplate
+	if err := v.c.Get(ctx, client.ObjectKey{Namespace: bk.Namespace, Name: ref.Name}, &template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, apierrors.NewInternalError(fmt.Errorf("failed to get BuildkitTemplate '%s' in namespace '%s': %w", ref.Name, bk.Namespace, err))
+	}
+
+	return &template, nil
+}
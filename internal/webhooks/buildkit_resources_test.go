@@ -0,0 +1,97 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestCheckMaximums(t *testing.T) {
+	t.Parallel()
+
+	template := func(strict bool) *v1alpha1.BuildkitTemplate {
+		return &v1alpha1.BuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "capped"},
+			Spec: v1alpha1.BuildkitTemplateSpec{
+				Resources: v1alpha1.BuildkitTemplateResources{
+					Default: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+					},
+					Maximum: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("16Gi"),
+					},
+					Strict: strict,
+				},
+			},
+		}
+	}
+	overMaximums := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi")},
+	}
+
+	tests := []struct {
+		name         string
+		template     *v1alpha1.BuildkitTemplate
+		resources    corev1.ResourceRequirements
+		wantWarnings admission.Warnings
+		wantErrors   []string
+	}{
+		{
+			name:      "no template",
+			resources: overMaximums,
+		},
+		{
+			name:     "within maximums, ignoring the template's own defaults",
+			template: template(false),
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+		},
+		{
+			name:      "over maximums",
+			template:  template(false),
+			resources: overMaximums,
+			wantWarnings: admission.Warnings{
+				"spec.resources.limits.memory: 64Gi is over the maximum of 16Gi set by template 'capped', and will be reduced to it",
+				"spec.resources.requests.memory: 32Gi is over the maximum of 16Gi set by template 'capped', and will be reduced to it",
+			},
+		},
+		{
+			name:      "over maximums of a strict template",
+			template:  template(true),
+			resources: overMaximums,
+			wantErrors: []string{
+				`spec.resources.limits.memory: Invalid value: "64Gi": over the maximum of 16Gi set by template 'capped'`,
+				`spec.resources.requests.memory: Invalid value: "32Gi": over the maximum of 16Gi set by template 'capped'`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bk := &v1alpha1.Buildkit{Spec: v1alpha1.BuildkitSpec{Resources: tt.resources}}
+			warnings, errs := checkMaximums(bk, tt.template)
+			assert.Equal(t, tt.wantWarnings, warnings)
+
+			var errStrings []string
+			for _, err := range errs {
+				errStrings = append(errStrings, err.Error())
+			}
+			assert.Equal(t, tt.wantErrors, errStrings)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// checkSchedulingOverrides checks a Buildkit's scheduling overrides against what its template's allowedOverrides
+// permit, which is nothing when the template doesn't set them.
+func checkSchedulingOverrides(bk *v1alpha1.Buildkit, template *v1alpha1.BuildkitTemplate) field.ErrorList {
+	if template == nil {
+		return nil
+	}
+
+	var allowed v1alpha1.BuildkitTemplateAllowedOverrides
+	if template.Spec.AllowedOverrides != nil {
+		allowed = *template.Spec.AllowedOverrides
+	}
+
+	var errorList field.ErrorList
+	path := field.NewPath("spec", "scheduling")
+	overrides := bk.Spec.Scheduling
+
+	for _, key := range slices.Sorted(maps.Keys(overrides.NodeSelector)) {
+		if !slices.Contains(allowed.NodeSelectorKeys, key) {
+			errorList = append(errorList, field.Forbidden(path.Child("nodeSelector").Key(key), notAllowedMessage(template, "nodeSelector key", allowed.NodeSelectorKeys)))
+		}
+	}
+
+	for i, toleration := range overrides.Tolerations {
+		if !slices.Contains(allowed.TolerationKeys, toleration.Key) {
+			errorList = append(errorList, field.Forbidden(path.Child("tolerations").Index(i).Child("key"), notAllowedMessage(template, "toleration key", allowed.TolerationKeys)))
+		}
+	}
+
+	if overrides.PriorityClassName != "" && !slices.Contains(allowed.PriorityClassNames, overrides.PriorityClassName) {
+		errorList = append(errorList, field.Forbidden(path.Child("priorityClassName"), notAllowedMessage(template, "priority class", allowed.PriorityClassNames)))
+	}
+
+	return errorList
+}
+
+func notAllowedMessage(template *v1alpha1.BuildkitTemplate, what string, allowed []string) string {
+	if len(allowed) == 0 {
+		return fmt.Sprintf("template '%s' doesn't allow overriding any %s", template.Name, what)
+	}
+
+	return fmt.Sprintf("template '%s' doesn't allow overriding this %s; allowed: %s", template.Name, what, strings.Join(allowed, ", "))
+}
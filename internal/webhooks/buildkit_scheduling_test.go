@@ -0,0 +1,93 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+func TestCheckSchedulingOverrides(t *testing.T) {
+	t.Parallel()
+
+	allowing := &v1alpha1.BuildkitTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "zoned"},
+		Spec: v1alpha1.BuildkitTemplateSpec{
+			AllowedOverrides: &v1alpha1.BuildkitTemplateAllowedOverrides{
+				NodeSelectorKeys:   []string{"topology.kubernetes.io/zone"},
+				TolerationKeys:     []string{"gpu"},
+				PriorityClassNames: []string{"builds-high", "builds-low"},
+			},
+		},
+	}
+	strict := &v1alpha1.BuildkitTemplate{ObjectMeta: metav1.ObjectMeta{Name: "fixed"}}
+
+	tests := []struct {
+		name       string
+		template   *v1alpha1.BuildkitTemplate
+		overrides  v1alpha1.BuildkitSchedulingOverrides
+		wantErrors []string
+	}{
+		{
+			name:     "no overrides",
+			template: strict,
+		},
+		{
+			name:     "allowed overrides",
+			template: allowing,
+			overrides: v1alpha1.BuildkitSchedulingOverrides{
+				NodeSelector:      map[string]string{"topology.kubernetes.io/zone": "us-east-1b"},
+				Tolerations:       []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}},
+				PriorityClassName: "builds-low",
+			},
+		},
+		{
+			name:     "overrides outside the allowlist",
+			template: allowing,
+			overrides: v1alpha1.BuildkitSchedulingOverrides{
+				NodeSelector: map[string]string{
+					"topology.kubernetes.io/zone": "us-east-1b",
+					"kubernetes.io/arch":          "arm64",
+				},
+				Tolerations:       []corev1.Toleration{{Key: "gpu"}, {Operator: corev1.TolerationOpExists}},
+				PriorityClassName: "system-cluster-critical",
+			},
+			wantErrors: []string{
+				"spec.scheduling.nodeSelector[kubernetes.io/arch]: Forbidden: template 'zoned' doesn't allow overriding this nodeSelector key; allowed: topology.kubernetes.io/zone",
+				"spec.scheduling.tolerations[1].key: Forbidden: template 'zoned' doesn't allow overriding this toleration key; allowed: gpu",
+				"spec.scheduling.priorityClassName: Forbidden: template 'zoned' doesn't allow overriding this priority class; allowed: builds-high, builds-low",
+			},
+		},
+		{
+			name:     "template without allowed overrides",
+			template: strict,
+			overrides: v1alpha1.BuildkitSchedulingOverrides{
+				PriorityClassName: "builds-high",
+			},
+			wantErrors: []string{
+				"spec.scheduling.priorityClassName: Forbidden: template 'fixed' doesn't allow overriding any priority class",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bk := &v1alpha1.Buildkit{Spec: v1alpha1.BuildkitSpec{Scheduling: tt.overrides}}
+
+			var errStrings []string
+			for _, err := range checkSchedulingOverrides(bk, tt.template) {
+				errStrings = append(errStrings, err.Error())
+			}
+			assert.Equal(t, tt.wantErrors, errStrings)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/BurntSushi/toml"
 	corev1 "k8s.io/api/core/v1"
@@ -15,19 +16,29 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+	"github.com/seatgeek/buildkit-operator/internal/buildkitd"
 )
 
 // +kubebuilder:webhook:path=/validate-buildkit-seatgeek-io-v1alpha1-buildkittemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=buildkit.seatgeek.io,resources=buildkittemplates,verbs=create;update,versions=v1alpha1,name=mbuildkittemplate.kb.io,admissionReviewVersions=v1
 
-type BuildkitTemplateValidator struct{}
+type BuildkitTemplateValidator struct {
+	c client.Reader
+}
 
 var _ webhook.CustomValidator = (*BuildkitTemplateValidator)(nil)
 
-func (v *BuildkitTemplateValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+func NewBuildkitTemplateValidator(c client.Reader) *BuildkitTemplateValidator {
+	return &BuildkitTemplateValidator{
+		c: c,
+	}
+}
+
+func (v *BuildkitTemplateValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	bkt, ok := obj.(*v1alpha1.BuildkitTemplate)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected BuildkitTemplate object but got %T", obj))
@@ -35,27 +46,73 @@ func (v *BuildkitTemplateValidator) validate(obj runtime.Object) (admission.Warn
 
 	var errorList field.ErrorList
 
-	// Validate the BuildkitTemplate name
-	if len(bkt.Name) > v1alpha1.BuildkitTemplateNameMaxLength {
-		errorList = append(errorList, field.TooLong(
-			field.NewPath("metadata", "name"),
+	// Validate the BuildkitTemplate name and spec
+	errorList = append(errorList, validateTemplateName(bkt.Name)...)
+	warnings, specErrors := validateTemplateSpec(bkt.Spec)
+	errorList = append(errorList, specErrors...)
+
+	// Validate that the namespace has at most one default template
+	if isDefaultTemplate(bkt) {
+		defaults, err := defaultTemplates(ctx, v.c, bkt.Namespace)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+
+		if others := slices.DeleteFunc(defaults, func(name string) bool { return name == bkt.Name }); len(others) > 0 {
+			errorList = append(errorList, field.Forbidden(
+				field.NewPath("metadata", "annotations").Key(v1alpha1.DefaultTemplateAnnotation),
+				fmt.Sprintf("BuildkitTemplate '%s' is already the default for namespace '%s'", others[0], bkt.Namespace),
+			))
+		}
+	}
+
+	// Warn about referenced Secrets and ConfigMaps that don't exist (yet), since pods started from the template would lack them
+	objectWarnings, err := referencedObjectWarnings(ctx, v.c, bkt.Namespace, bkt.Spec)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	warnings = append(warnings, objectWarnings...)
+
+	if len(errorList) > 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: v1alpha1.SchemeGroupVersion.Group,
+				Kind:  "BuildkitTemplate",
+			},
 			bkt.Name,
-			v1alpha1.BuildkitTemplateNameMaxLength,
-		))
+			errorList,
+		)
+	}
+
+	return warnings, nil
+}
+
+// validateTemplateName checks that a template's name leaves room for the suffixes of the resources named after it.
+func validateTemplateName(name string) field.ErrorList {
+	if len(name) > v1alpha1.BuildkitTemplateNameMaxLength {
+		return field.ErrorList{field.TooLong(field.NewPath("metadata", "name"), name, v1alpha1.BuildkitTemplateNameMaxLength)}
 	}
 
+	return nil
+}
+
+// validateTemplateSpec checks the spec shared by BuildkitTemplates and ClusterBuildkitTemplates.
+func validateTemplateSpec(spec v1alpha1.BuildkitTemplateSpec) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var errorList field.ErrorList
+
 	// Validate the port number
-	if bkt.Spec.Port < 1 || bkt.Spec.Port > 65535 {
+	if spec.Port < 1 || spec.Port > 65535 {
 		errorList = append(errorList, field.Invalid(
 			field.NewPath("spec", "port"),
-			bkt.Spec.Port,
+			spec.Port,
 			"spec.port must be between 1 and 65535",
 		))
 	}
 
-	// Validate the toml syntax
-	var tmp any
-	if _, err := toml.Decode(bkt.Spec.BuildkitdToml, &tmp); err != nil {
+	// Validate the toml syntax, and then the config against buildkitd's schema
+	var doc map[string]any
+	if _, err := toml.Decode(spec.BuildkitdToml, &doc); err != nil {
 		reason := "invalid TOML syntax"
 
 		var perr toml.ParseError
@@ -65,31 +122,180 @@ func (v *BuildkitTemplateValidator) validate(obj runtime.Object) (admission.Warn
 
 		errorList = append(errorList, field.Invalid(
 			field.NewPath("spec", "buildkitdToml"),
-			bkt.Spec.BuildkitdToml,
+			spec.BuildkitdToml,
 			reason,
 		))
+	} else {
+		tomlWarnings, tomlErrors := validateBuildkitdToml(doc, field.NewPath("spec", "buildkitdToml"))
+		warnings = append(warnings, tomlWarnings...)
+		errorList = append(errorList, tomlErrors...)
+
+		// Settings made in both places take their value from spec.config, which is easy to miss
+		for _, key := range overriddenKeys(doc, buildkitd.Document(&spec), field.NewPath("spec", "buildkitdToml")) {
+			warnings = append(warnings, fmt.Sprintf("%s: overridden by spec.config", key))
+		}
 	}
 
-	if len(errorList) > 0 {
-		return nil, apierrors.NewInvalid(
-			schema.GroupKind{
-				Group: v1alpha1.SchemeGroupVersion.Group,
-				Kind:  "BuildkitTemplate",
-			},
-			bkt.Name,
-			errorList,
-		)
+	// Validate the typed buildkitd config
+	if spec.Config != nil {
+		errorList = append(errorList, validateBuildkitdConfig(*spec.Config, field.NewPath("spec", "config"))...)
 	}
 
-	return nil, nil
+	// Validate the TLS certificate lifetimes
+	if spec.TLS != nil && spec.TLS.RenewBefore.Duration >= spec.TLS.CertificateDuration.Duration {
+		errorList = append(errorList, field.Invalid(
+			field.NewPath("spec", "tls", "renewBefore"),
+			spec.TLS.RenewBefore.Duration.String(),
+			"spec.tls.renewBefore must be shorter than spec.tls.certificateDuration",
+		))
+	}
+
+	// Validate the idle timeout
+	if timeout := spec.Lifecycle.IdleTimeout; timeout != nil && timeout.Duration <= 0 {
+		errorList = append(errorList, field.Invalid(
+			field.NewPath("spec", "lifecycle", "idleTimeout"),
+			timeout.Duration.String(),
+			"spec.lifecycle.idleTimeout must be positive",
+		))
+	}
+
+	// Validate the recovery backoff
+	if recovery := spec.Lifecycle.RecoveryPolicy; recovery != nil {
+		errorList = append(errorList, validateRecoveryPolicy(*recovery, field.NewPath("spec", "lifecycle", "recoveryPolicy"))...)
+	}
+
+	// Validate that the storage settings match the storage type
+	errorList = append(errorList, validateStorage(spec.Storage, field.NewPath("spec", "storage"))...)
+
+	return warnings, errorList
 }
 
-func (v *BuildkitTemplateValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return v.validate(obj)
+func validateBuildkitdConfig(config v1alpha1.BuildkitdConfig, path *field.Path) field.ErrorList {
+	var errorList field.ErrorList
+
+	for i, registry := range config.Registries {
+		caPath := path.Child("registries").Index(i).Child("ca")
+		switch ca := registry.CA; {
+		case ca == nil:
+		case ca.ConfigMapKeyRef == nil && ca.SecretKeyRef == nil:
+			errorList = append(errorList, field.Required(caPath, "one of configMapKeyRef and secretKeyRef must be set"))
+		case ca.ConfigMapKeyRef != nil && ca.SecretKeyRef != nil:
+			errorList = append(errorList, field.Forbidden(caPath.Child("secretKeyRef"), "may not be set along with configMapKeyRef"))
+		}
+	}
+
+	return errorList
 }
 
-func (v *BuildkitTemplateValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
-	return v.validate(newObj)
+func validateRecoveryPolicy(recovery v1alpha1.BuildkitTemplateRecoveryPolicy, path *field.Path) field.ErrorList {
+	var errorList field.ErrorList
+
+	if recovery.InitialBackoff.Duration < 0 {
+		errorList = append(errorList, field.Invalid(path.Child("initialBackoff"), recovery.InitialBackoff.Duration.String(), "must not be negative"))
+	}
+
+	if recovery.MaxBackoff.Duration != 0 && recovery.MaxBackoff.Duration < recovery.InitialBackoff.Duration {
+		errorList = append(errorList, field.Invalid(path.Child("maxBackoff"), recovery.MaxBackoff.Duration.String(), "must not be shorter than initialBackoff"))
+	}
+
+	return errorList
+}
+
+func validateStorage(storage v1alpha1.BuildkitTemplateStorage, path *field.Path) field.ErrorList {
+	var errorList field.ErrorList
+
+	usesClaim := storage.Type == v1alpha1.StorageTypePersistentVolumeClaim || storage.Type == v1alpha1.StorageTypeEphemeral
+	if usesClaim && storage.VolumeClaimTemplate == nil {
+		errorList = append(errorList, field.Required(path.Child("volumeClaimTemplate"), fmt.Sprintf("required when type is %s", storage.Type)))
+	} else if !usesClaim && storage.VolumeClaimTemplate != nil {
+		errorList = append(errorList, field.Forbidden(path.Child("volumeClaimTemplate"), fmt.Sprintf("may not be set when type is %s", storage.Type)))
+	}
+
+	if storage.EmptyDir != nil && storage.Type != v1alpha1.StorageTypeEmptyDir {
+		errorList = append(errorList, field.Forbidden(path.Child("emptyDir"), fmt.Sprintf("may not be set when type is %s", storage.Type)))
+	}
+
+	if storage.RetentionPolicy == v1alpha1.StorageRetentionPolicyRetain && storage.Type != v1alpha1.StorageTypePersistentVolumeClaim {
+		errorList = append(errorList, field.Forbidden(path.Child("retentionPolicy"), fmt.Sprintf("Retain is only supported when type is %s", v1alpha1.StorageTypePersistentVolumeClaim)))
+	}
+
+	return errorList
+}
+
+// referencedObjectWarnings returns a warning for each Secret or ConfigMap in the namespace that the spec references, for
+// registry credentials, image pulls or registry certificates, but that doesn't exist or is of the wrong type.
+func referencedObjectWarnings(ctx context.Context, c client.Reader, namespace string, spec v1alpha1.BuildkitTemplateSpec) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	lookupSecret := func(path *field.Path, name, consequence string, wantType corev1.SecretType) error {
+		var secret corev1.Secret
+		err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &secret)
+		switch {
+		case apierrors.IsNotFound(err):
+			warnings = append(warnings, fmt.Sprintf("%s: Secret %q not found in namespace %q, %s", path, name, namespace, consequence))
+		case err != nil:
+			return fmt.Errorf("failed to get Secret '%s/%s': %w", namespace, name, err)
+		case wantType != "" && secret.Type != wantType:
+			warnings = append(warnings, fmt.Sprintf("%s: Secret %q is of type %s rather than %s, %s", path, name, secret.Type, wantType, consequence))
+		}
+		return nil
+	}
+
+	for i, ref := range spec.RegistryAuth {
+		path := field.NewPath("spec", "registryAuth").Index(i).Child("name")
+		if err := lookupSecret(path, ref.Name, "so its registry credentials are left out", corev1.SecretTypeDockerConfigJson); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, ref := range spec.ImagePullSecrets {
+		path := field.NewPath("spec", "imagePullSecrets").Index(i).Child("name")
+		if err := lookupSecret(path, ref.Name, "so pulling the Buildkit image may fail", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.Config == nil {
+		return warnings, nil
+	}
+
+	// Buildkit pods can't start until the registry certificates they mount exist
+	const podsWait = "so Buildkit pods will wait for it to be created"
+	for i, registry := range spec.Config.Registries {
+		path := field.NewPath("spec", "config", "registries").Index(i)
+
+		if registry.CA != nil && registry.CA.SecretKeyRef != nil {
+			if err := lookupSecret(path.Child("ca", "secretKeyRef", "name"), registry.CA.SecretKeyRef.Name, podsWait, ""); err != nil {
+				return nil, err
+			}
+		}
+
+		if registry.CA != nil && registry.CA.ConfigMapKeyRef != nil {
+			name := registry.CA.ConfigMapKeyRef.Name
+			err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &corev1.ConfigMap{})
+			if apierrors.IsNotFound(err) {
+				warnings = append(warnings, fmt.Sprintf("%s: ConfigMap %q not found in namespace %q, %s", path.Child("ca", "configMapKeyRef", "name"), name, namespace, podsWait))
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to get ConfigMap '%s/%s': %w", namespace, name, err)
+			}
+		}
+
+		if registry.ClientCertificate != nil {
+			if err := lookupSecret(path.Child("clientCertificate", "name"), registry.ClientCertificate.Name, podsWait, corev1.SecretTypeTLS); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+func (v *BuildkitTemplateValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+func (v *BuildkitTemplateValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
 }
 
 func (v *BuildkitTemplateValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
@@ -107,27 +313,83 @@ func (b BuildkitTemplateDefaulter) Default(_ context.Context, obj runtime.Object
 		return fmt.Errorf("expected BuildkitTemplate object, got %T", obj)
 	}
 
-	if bkt.Spec.Port == 0 {
-		bkt.Spec.Port = 1234
+	defaultTemplateSpec(&bkt.Spec)
+	return nil
+}
+
+// defaultTemplateSpec fills in the defaults of the spec shared by BuildkitTemplates and ClusterBuildkitTemplates.
+func defaultTemplateSpec(spec *v1alpha1.BuildkitTemplateSpec) {
+	if spec.Port == 0 {
+		spec.Port = 1234
+	}
+
+	if spec.Storage.Type == "" {
+		spec.Storage.Type = v1alpha1.StorageTypeEmptyDir
+	}
+
+	if spec.Storage.RetentionPolicy == "" {
+		spec.Storage.RetentionPolicy = v1alpha1.StorageRetentionPolicyDelete
+	}
+
+	if spec.EndpointType == "" {
+		spec.EndpointType = v1alpha1.EndpointTypePodIP
+	}
+
+	if spec.UpdateStrategy == "" {
+		spec.UpdateStrategy = v1alpha1.UpdateStrategyOnDelete
+	}
+
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = v1alpha1.DeletionPolicyBlock
 	}
 
-	if bkt.Spec.Lifecycle.TerminationGracePeriodSeconds == nil {
-		bkt.Spec.Lifecycle.TerminationGracePeriodSeconds = new(int64(900)) // 15 minutes
+	if spec.Lifecycle.TerminationGracePeriodSeconds == nil {
+		spec.Lifecycle.TerminationGracePeriodSeconds = new(int64(900)) // 15 minutes
 	}
 
-	if bkt.Spec.Image == "" {
-		if bkt.Spec.Rootless {
-			bkt.Spec.Image = "moby/buildkit:rootless"
+	if spec.Lifecycle.IdleAction == "" {
+		spec.Lifecycle.IdleAction = v1alpha1.IdleActionDelete
+	}
+
+	if recovery := spec.Lifecycle.RecoveryPolicy; recovery != nil {
+		if recovery.Type == "" {
+			recovery.Type = v1alpha1.RecoveryPolicyNever
+		}
+
+		if recovery.MaxRestarts == 0 {
+			recovery.MaxRestarts = v1alpha1.DefaultRecoveryMaxRestarts
+		}
+
+		if recovery.InitialBackoff.Duration == 0 {
+			recovery.InitialBackoff.Duration = v1alpha1.DefaultRecoveryInitialBackoff
+		}
+
+		if recovery.MaxBackoff.Duration == 0 {
+			recovery.MaxBackoff.Duration = max(v1alpha1.DefaultRecoveryMaxBackoff, recovery.InitialBackoff.Duration)
+		}
+	}
+
+	if spec.Image == "" {
+		if spec.Rootless {
+			spec.Image = "moby/buildkit:rootless"
 		} else {
-			bkt.Spec.Image = "moby/buildkit:latest"
+			spec.Image = "moby/buildkit:latest"
 		}
 	}
 
-	if bkt.Spec.ImagePullPolicy == "" {
-		bkt.Spec.ImagePullPolicy = corev1.PullIfNotPresent
+	if spec.ImagePullPolicy == "" {
+		spec.ImagePullPolicy = corev1.PullIfNotPresent
 	}
 
-	return nil
+	if spec.TLS != nil {
+		if spec.TLS.CertificateDuration.Duration == 0 {
+			spec.TLS.CertificateDuration.Duration = v1alpha1.DefaultTLSCertificateDuration
+		}
+
+		if spec.TLS.RenewBefore.Duration == 0 {
+			spec.TLS.RenewBefore.Duration = v1alpha1.DefaultTLSRenewBefore
+		}
+	}
 }
 
 var _ webhook.CustomDefaulter = (*BuildkitTemplateDefaulter)(nil)
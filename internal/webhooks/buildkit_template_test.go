@@ -6,6 +6,7 @@ package webhooks
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -66,6 +67,22 @@ missing closing bracket`,
 			Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
 		})
 
+		It("should reject buildkitd.toml values of the wrong type", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					BuildkitdToml: `
+[worker.oci]
+  max-parallelism = "3"`,
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.buildkitdToml.worker.oci.max-parallelism")))
+		})
+
 		It("should accept empty TOML", func() {
 			buildkitTemplate := &v1alpha1.BuildkitTemplate{
 				ObjectMeta: metav1.ObjectMeta{
@@ -79,6 +96,262 @@ missing closing bracket`,
 
 			Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
 		})
+
+		It("should reject a TLS renewal window longer than the certificate duration", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					TLS: &v1alpha1.BuildkitTemplateTLS{
+						CertificateDuration: metav1.Duration{Duration: 24 * time.Hour},
+						RenewBefore:         metav1.Duration{Duration: 48 * time.Hour},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.tls.renewBefore")))
+		})
+	})
+
+	Context("When configuring storage", func() {
+		It("should require a volume claim template for PersistentVolumeClaim storage", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type: v1alpha1.StorageTypePersistentVolumeClaim,
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.storage.volumeClaimTemplate")))
+		})
+
+		It("should reject a retention policy for ephemeral storage", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type:                v1alpha1.StorageTypeEphemeral,
+						RetentionPolicy:     v1alpha1.StorageRetentionPolicyRetain,
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.storage.retentionPolicy")))
+		})
+
+		It("should reject emptyDir settings for other storage types", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type:                v1alpha1.StorageTypePersistentVolumeClaim,
+						EmptyDir:            &corev1.EmptyDirVolumeSource{},
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.storage.emptyDir")))
+		})
+
+		It("should accept retained PersistentVolumeClaim storage", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Storage: v1alpha1.BuildkitTemplateStorage{
+						Type:            v1alpha1.StorageTypePersistentVolumeClaim,
+						RetentionPolicy: v1alpha1.StorageRetentionPolicyRetain,
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							},
+						},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+		})
+
+		It("should reject a non-positive idle timeout", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Lifecycle: v1alpha1.BuildkitTemplatePodLifecycle{
+						IdleTimeout: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.lifecycle.idleTimeout")))
+		})
+
+		It("should reject a recovery maxBackoff shorter than its initialBackoff", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Lifecycle: v1alpha1.BuildkitTemplatePodLifecycle{
+						RecoveryPolicy: &v1alpha1.BuildkitTemplateRecoveryPolicy{
+							Type:           v1alpha1.RecoveryPolicyOnFailure,
+							InitialBackoff: metav1.Duration{Duration: time.Minute},
+							MaxBackoff:     metav1.Duration{Duration: time.Second},
+						},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.lifecycle.recoveryPolicy.maxBackoff")))
+		})
+	})
+
+	Context("When marking a BuildkitTemplate as the namespace default", func() {
+		newDefaultTemplate := func(name string) *v1alpha1.BuildkitTemplate {
+			return &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Annotations: map[string]string{v1alpha1.DefaultTemplateAnnotation: "true"},
+				},
+			}
+		}
+
+		It("should allow a single default", func() {
+			template := newDefaultTemplate("first-default")
+			Expect(c.Create(ctx, template)).To(Succeed())
+
+			// Updating the default itself is fine
+			template.Spec.Port = 4321
+			Expect(c.Update(ctx, template)).To(Succeed())
+		})
+
+		It("should reject a second default", func() {
+			Expect(c.Create(ctx, newDefaultTemplate("first-default"))).To(Succeed())
+			Expect(c.Create(ctx, newDefaultTemplate("second-default"))).To(MatchError(And(
+				ContainSubstring("metadata.annotations[buildkit.seatgeek.io/default-template]"),
+				ContainSubstring("BuildkitTemplate 'first-default' is already the default"),
+			)))
+		})
+	})
+
+	Context("When referencing Secrets", func() {
+		It("should warn about Secrets that are missing or hold no registry credentials", func() {
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "dockerhub", Namespace: namespace},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+			})).To(Succeed())
+			Expect(c.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: namespace},
+				Type:       corev1.SecretTypeOpaque,
+			})).To(Succeed())
+
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					RegistryAuth: []corev1.LocalObjectReference{
+						{Name: "dockerhub"},
+						{Name: "missing"},
+						{Name: "opaque"},
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "missing-pull-secret"}},
+				},
+			}
+
+			warnings, err := NewBuildkitTemplateValidator(c).ValidateCreate(ctx, buildkitTemplate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring(`spec.registryAuth[1].name: Secret "missing" not found`),
+				ContainSubstring(`spec.registryAuth[2].name: Secret "opaque" is of type Opaque`),
+				ContainSubstring(`spec.imagePullSecrets[0].name: Secret "missing-pull-secret" not found`),
+			))
+
+			// Secrets may well be created after the template, so it's admitted regardless
+			Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+		})
+
+		It("should warn about missing registry certificates", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Config: &v1alpha1.BuildkitdConfig{
+						Registries: []v1alpha1.BuildkitdRegistry{{
+							Host: "registry.internal",
+							CA: &v1alpha1.BuildkitdRegistryCA{
+								ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "internal-ca"},
+									Key:                  "ca.crt",
+								},
+							},
+							ClientCertificate: &corev1.LocalObjectReference{Name: "registry-client"},
+						}},
+					},
+				},
+			}
+
+			warnings, err := NewBuildkitTemplateValidator(c).ValidateCreate(ctx, buildkitTemplate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring(`spec.config.registries[0].ca.configMapKeyRef.name: ConfigMap "internal-ca" not found`),
+				ContainSubstring(`spec.config.registries[0].clientCertificate.name: Secret "registry-client" not found`),
+			))
+		})
+
+		It("should reject a registry CA referencing both a ConfigMap and a Secret", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Config: &v1alpha1.BuildkitdConfig{
+						Registries: []v1alpha1.BuildkitdRegistry{{
+							Host: "registry.internal",
+							CA: &v1alpha1.BuildkitdRegistryCA{
+								ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "internal-ca"},
+									Key:                  "ca.crt",
+								},
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "internal-ca"},
+									Key:                  "ca.crt",
+								},
+							},
+						}},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(MatchError(ContainSubstring("spec.config.registries[0].ca.secretKeyRef")))
+		})
 	})
 
 	Context("When updating a BuildkitTemplate resource", func() {
@@ -146,9 +419,60 @@ var _ = Describe("BuildkitTemplateDefaulter", func() {
 			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &created)).To(Succeed())
 
 			Expect(created.Spec.Port).To(Equal(int32(1234)))
+			Expect(created.Spec.EndpointType).To(Equal(v1alpha1.EndpointTypePodIP))
+			Expect(created.Spec.UpdateStrategy).To(Equal(v1alpha1.UpdateStrategyOnDelete))
+			Expect(created.Spec.DeletionPolicy).To(Equal(v1alpha1.DeletionPolicyBlock))
+			Expect(created.Spec.Storage.Type).To(Equal(v1alpha1.StorageTypeEmptyDir))
+			Expect(created.Spec.Storage.RetentionPolicy).To(Equal(v1alpha1.StorageRetentionPolicyDelete))
 			Expect(created.Spec.Image).To(Equal("moby/buildkit:latest"))
 			Expect(created.Spec.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
 			Expect(*created.Spec.Lifecycle.TerminationGracePeriodSeconds).To(Equal(int64(900)))
+			Expect(created.Spec.Lifecycle.IdleAction).To(Equal(v1alpha1.IdleActionDelete))
+			Expect(created.Spec.Lifecycle.RecoveryPolicy).To(BeNil())
+			Expect(created.Spec.TLS).To(BeNil())
+		})
+
+		It("should default the recovery limits when failed pods are replaced", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Lifecycle: v1alpha1.BuildkitTemplatePodLifecycle{
+						RecoveryPolicy: &v1alpha1.BuildkitTemplateRecoveryPolicy{Type: v1alpha1.RecoveryPolicyOnFailure},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+
+			var created v1alpha1.BuildkitTemplate
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &created)).To(Succeed())
+			recovery := created.Spec.Lifecycle.RecoveryPolicy
+			Expect(recovery.MaxRestarts).To(Equal(int32(v1alpha1.DefaultRecoveryMaxRestarts)))
+			Expect(recovery.InitialBackoff.Duration).To(Equal(v1alpha1.DefaultRecoveryInitialBackoff))
+			Expect(recovery.MaxBackoff.Duration).To(Equal(v1alpha1.DefaultRecoveryMaxBackoff))
+		})
+
+		It("should default TLS certificate lifetimes when TLS is enabled", func() {
+			buildkitTemplate := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit-template",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					TLS: &v1alpha1.BuildkitTemplateTLS{},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkitTemplate)).To(Succeed())
+
+			var created v1alpha1.BuildkitTemplate
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkitTemplate), &created)).To(Succeed())
+			Expect(created.Spec.TLS).NotTo(BeNil())
+			Expect(created.Spec.TLS.CertificateDuration.Duration).To(Equal(v1alpha1.DefaultTLSCertificateDuration))
+			Expect(created.Spec.TLS.RenewBefore.Duration).To(Equal(v1alpha1.DefaultTLSRenewBefore))
 		})
 
 		It("should not override explicitly set values", func() {
@@ -6,6 +6,7 @@ package webhooks
 
 import (
 	"fmt"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -38,6 +39,8 @@ var _ = Describe("BuildkitValidator", func() {
 
 		DeferCleanup(func() {
 			Expect(c.DeleteAllOf(ctx, &v1alpha1.Buildkit{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitPool{}, client.InNamespace(namespace))).To(Succeed())
+			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitQuota{}, client.InNamespace(namespace))).To(Succeed())
 			Expect(c.DeleteAllOf(ctx, &v1alpha1.BuildkitTemplate{}, client.InNamespace(namespace))).To(Succeed())
 			Expect(c.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
 		})
@@ -85,6 +88,379 @@ var _ = Describe("BuildkitValidator", func() {
 
 			Expect(c.Create(ctx, buildkit)).To(Succeed())
 		})
+
+		It("should reject names that can't be used for the instance's Service", func() {
+			for _, name := range []string{"1-buildkit", "buildkit.amd64", "buildkit-" + strings.Repeat("x", 60)} {
+				buildkit := &v1alpha1.Buildkit{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+					Spec: v1alpha1.BuildkitSpec{
+						Template: someExistingTemplateName,
+					},
+				}
+
+				Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("metadata.name")), name)
+			}
+		})
+
+		It("should reject templates that are being deleted", func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "deleted-template",
+					Namespace:  namespace,
+					Finalizers: []string{"test.buildkit.seatgeek.io/keep"},
+				},
+			})).To(Succeed())
+			deleted := &v1alpha1.BuildkitTemplate{ObjectMeta: metav1.ObjectMeta{Name: "deleted-template", Namespace: namespace}}
+			Expect(c.Delete(ctx, deleted)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(c.Get(ctx, client.ObjectKeyFromObject(deleted), deleted)).To(Succeed())
+				deleted.Finalizers = nil
+				Expect(c.Update(ctx, deleted)).To(Succeed())
+			})
+
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: "deleted-template",
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("BuildkitTemplate 'deleted-template' is being deleted")))
+		})
+	})
+
+	Context("When the namespace has a default BuildkitTemplate", func() {
+		const defaultTemplateName = "default-template"
+
+		BeforeEach(func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        defaultTemplateName,
+					Namespace:   namespace,
+					Annotations: map[string]string{v1alpha1.DefaultTemplateAnnotation: "true"},
+				},
+			})).To(Succeed())
+		})
+
+		It("should fill in the template marked as the default", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+			Expect(buildkit.Spec.Template).To(Equal(defaultTemplateName))
+		})
+
+		It("should prefer the template named by the namespace label", func() {
+			var ns corev1.Namespace
+			Expect(c.Get(ctx, client.ObjectKey{Name: namespace}, &ns)).To(Succeed())
+			ns.Labels = map[string]string{v1alpha1.DefaultTemplateLabel: someExistingTemplateName}
+			Expect(c.Update(ctx, &ns)).To(Succeed())
+
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+			Expect(buildkit.Spec.Template).To(Equal(someExistingTemplateName))
+		})
+
+		It("should leave an explicitly set template alone", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: someExistingTemplateName,
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+			Expect(buildkit.Spec.Template).To(Equal(someExistingTemplateName))
+		})
+	})
+
+	Context("When referencing a ClusterBuildkitTemplate", func() {
+		var clusterTemplateName string
+
+		BeforeEach(func() {
+			var ns corev1.Namespace
+			Expect(c.Get(ctx, client.ObjectKey{Name: namespace}, &ns)).To(Succeed())
+			ns.Labels = map[string]string{"team": "platform"}
+			Expect(c.Update(ctx, &ns)).To(Succeed())
+
+			clusterTemplate := &v1alpha1.ClusterBuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-" + namespace,
+				},
+				Spec: v1alpha1.ClusterBuildkitTemplateSpec{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+				},
+			}
+			Expect(c.Create(ctx, clusterTemplate)).To(Succeed())
+			clusterTemplateName = clusterTemplate.Name
+
+			DeferCleanup(func() {
+				Expect(c.Delete(ctx, clusterTemplate)).To(Succeed())
+			})
+		})
+
+		It("should allow namespaces matched by the namespace selector", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: clusterTemplateName},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+			Expect(buildkit.Spec.Template).To(BeEmpty(), "the default template must not be filled in")
+		})
+
+		It("should reject namespaces that the namespace selector doesn't match", func() {
+			var ns corev1.Namespace
+			Expect(c.Get(ctx, client.ObjectKey{Name: namespace}, &ns)).To(Succeed())
+			ns.Labels = map[string]string{"team": "someone-else"}
+			Expect(c.Update(ctx, &ns)).To(Succeed())
+
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: clusterTemplateName},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring(
+				fmt.Sprintf("spec.templateRef: Forbidden: ClusterBuildkitTemplate '%s' may not be used in namespace '%s'", clusterTemplateName, namespace),
+			)))
+		})
+
+		It("should require the referenced ClusterBuildkitTemplate to exist", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: "non-existent-template"},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("spec.templateRef.name: Not found: \"non-existent-template\"")))
+		})
+
+		It("should look up BuildkitTemplate references in the Buildkit's namespace", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					TemplateRef: &v1alpha1.TemplateReference{Name: someExistingTemplateName},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+			Expect(buildkit.Spec.TemplateRef.Kind).To(Equal(v1alpha1.TemplateKindBuildkitTemplate))
+		})
+
+		It("should reject setting both template and templateRef", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template:    someExistingTemplateName,
+					TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: clusterTemplateName},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("spec.templateRef: Forbidden: templateRef may not be set together with template")))
+		})
+
+		It("should not allow the templateRef to change", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					TemplateRef: &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindClusterBuildkitTemplate, Name: clusterTemplateName},
+				},
+			}
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+			buildkit.Spec.TemplateRef = &v1alpha1.TemplateReference{Kind: v1alpha1.TemplateKindBuildkitTemplate, Name: someExistingTemplateName}
+			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec.templateRef: Invalid value")))
+		})
+	})
+
+	Context("When using a BuildkitPool", func() {
+		const someExistingPoolName = "existing-pool"
+
+		BeforeEach(func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      someExistingPoolName,
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitPoolSpec{
+					Template: someExistingTemplateName,
+					Size:     1,
+				},
+			})).To(Succeed())
+		})
+
+		It("should allow creation with a valid pool reference", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Pool: someExistingPoolName,
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+		})
+
+		It("should require the spec.pool field to reference an existing BuildkitPool", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Pool: "non-existent-pool",
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("Not found: \"non-existent-pool\"")))
+		})
+
+		It("should reject setting both template and pool", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: someExistingTemplateName,
+					Pool:     someExistingPoolName,
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("spec.pool")))
+		})
+
+		It("should reject resources, which warm pods can't honour", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Pool: someExistingPoolName,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1"),
+						},
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("spec.resources")))
+
+			// Nor can they be added later
+			buildkit.Spec.Resources = corev1.ResourceRequirements{}
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+			buildkit.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec.resources")))
+		})
+
+		It("should reject scheduling overrides, which warm pods can't honour", func() {
+			buildkit := &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Pool: someExistingPoolName,
+					Scheduling: v1alpha1.BuildkitSchedulingOverrides{
+						PriorityClassName: "builds-high",
+					},
+				},
+			}
+
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("spec.scheduling")))
+		})
+	})
+
+	Context("When the template allows scheduling overrides", func() {
+		const zonedTemplateName = "zoned-template"
+
+		BeforeEach(func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: zonedTemplateName, Namespace: namespace},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					AllowedOverrides: &v1alpha1.BuildkitTemplateAllowedOverrides{
+						NodeSelectorKeys: []string{"topology.kubernetes.io/zone"},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		newBuildkit := func(nodeSelector map[string]string) *v1alpha1.Buildkit {
+			return &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template:   zonedTemplateName,
+					Scheduling: v1alpha1.BuildkitSchedulingOverrides{NodeSelector: nodeSelector},
+				},
+			}
+		}
+
+		It("should allow the overrides it lists", func() {
+			Expect(c.Create(ctx, newBuildkit(map[string]string{"topology.kubernetes.io/zone": "us-east-1b"}))).To(Succeed())
+		})
+
+		It("should reject the overrides it doesn't list", func() {
+			Expect(c.Create(ctx, newBuildkit(map[string]string{"kubernetes.io/arch": "arm64"}))).To(MatchError(
+				ContainSubstring("template 'zoned-template' doesn't allow overriding this nodeSelector key"),
+			))
+		})
+
+		It("should not allow the overrides to change", func() {
+			buildkit := newBuildkit(map[string]string{"topology.kubernetes.io/zone": "us-east-1b"})
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+			buildkit.Spec.Scheduling.NodeSelector["topology.kubernetes.io/zone"] = "us-east-1c"
+			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec.scheduling: Invalid value")))
+		})
 	})
 
 	Context("When RequireOwner validation is involved", func() {
@@ -170,6 +546,196 @@ var _ = Describe("BuildkitValidator", func() {
 		})
 	})
 
+	Context("When the template caps resources", func() {
+		newBuildkit := func(template, memory string) *v1alpha1.Buildkit {
+			return &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-buildkit",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: template,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+					},
+				},
+			}
+		}
+
+		newTemplate := func(name string, strict bool) *v1alpha1.BuildkitTemplate {
+			return &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Resources: v1alpha1.BuildkitTemplateResources{
+						Maximum: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+						Strict:  strict,
+					},
+				},
+			}
+		}
+
+		It("should warn about resources over the maximums", func() {
+			Expect(c.Create(ctx, newTemplate("capped-template", false))).To(Succeed())
+
+			warnings, err := NewBuildkitValidator(c).ValidateCreate(ctx, newBuildkit("capped-template", "64Gi"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"spec.resources.limits.memory: 64Gi is over the maximum of 16Gi set by template 'capped-template', and will be reduced to it",
+			))
+
+			warnings, err = NewBuildkitValidator(c).ValidateCreate(ctx, newBuildkit("capped-template", "8Gi"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject resources over the maximums of a strict template", func() {
+			Expect(c.Create(ctx, newTemplate("strict-template", true))).To(Succeed())
+
+			Expect(c.Create(ctx, newBuildkit("strict-template", "64Gi"))).To(MatchError(
+				ContainSubstring("spec.resources.limits.memory: Invalid value: \"64Gi\": over the maximum of 16Gi set by template 'strict-template'"),
+			))
+
+			buildkit := newBuildkit("strict-template", "8Gi")
+			Expect(c.Create(ctx, buildkit)).To(Succeed())
+
+			By("raising its resources over the maximums")
+			buildkit.Spec.Resources.Limits[corev1.ResourceMemory] = resource.MustParse("32Gi")
+			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("over the maximum of 16Gi")))
+		})
+	})
+
+	Context("When the namespace has a BuildkitQuota", func() {
+		newBuildkit := func(name string, cpu string) *v1alpha1.Buildkit {
+			return &v1alpha1.Buildkit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.BuildkitSpec{
+					Template: someExistingTemplateName,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					},
+				},
+			}
+		}
+
+		It("should reject Buildkits beyond the quota's instance count", func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota", Namespace: namespace},
+				Spec:       v1alpha1.BuildkitQuotaSpec{Instances: new(int32(1))},
+			})).To(Succeed())
+
+			Expect(c.Create(ctx, newBuildkit("first", "1"))).To(Succeed())
+			Expect(c.Create(ctx, newBuildkit("second", "1"))).To(MatchError(And(
+				ContainSubstring("exceeded BuildkitQuota 'test-quota'"),
+				ContainSubstring("instances: 1 of 1 used"),
+			)))
+		})
+
+		It("should reject Buildkits that would take the namespace over its total requests", func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota", Namespace: namespace},
+				Spec: v1alpha1.BuildkitQuotaSpec{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+				},
+			})).To(Succeed())
+
+			Expect(c.Create(ctx, newBuildkit("first", "2"))).To(Succeed())
+			Expect(c.Create(ctx, newBuildkit("second", "2"))).To(MatchError(ContainSubstring("requests.cpu: 2 requested, 2 of 3 used")))
+			Expect(c.Create(ctx, newBuildkit("third", "1"))).To(Succeed())
+		})
+
+		It("should count Buildkits with the maximums of their template applied", func() {
+			template := &v1alpha1.BuildkitTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "capped-template", Namespace: namespace},
+				Spec: v1alpha1.BuildkitTemplateSpec{
+					Resources: v1alpha1.BuildkitTemplateResources{
+						Maximum: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+			}
+			Expect(c.Create(ctx, template)).To(Succeed())
+			Expect(c.Create(ctx, &v1alpha1.BuildkitQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota", Namespace: namespace},
+				Spec: v1alpha1.BuildkitQuotaSpec{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			})).To(Succeed())
+
+			for _, name := range []string{"first", "second"} {
+				buildkit := newBuildkit(name, "4")
+				buildkit.Spec.Template = template.Name
+				Expect(c.Create(ctx, buildkit)).To(Succeed())
+			}
+
+			buildkit := newBuildkit("third", "4")
+			buildkit.Spec.Template = template.Name
+			Expect(c.Create(ctx, buildkit)).To(MatchError(ContainSubstring("limits.cpu: 1 requested, 2 of 2 used")))
+		})
+
+		It("should count the limits of Buildkits that don't set requests as their requests", func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota", Namespace: namespace},
+				Spec: v1alpha1.BuildkitQuotaSpec{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+				},
+			})).To(Succeed())
+
+			limitsOnly := func(name, cpu string) *v1alpha1.Buildkit {
+				buildkit := newBuildkit(name, cpu)
+				buildkit.Spec.Resources = corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				}
+				return buildkit
+			}
+
+			Expect(c.Create(ctx, limitsOnly("first", "2"))).To(Succeed())
+			Expect(c.Create(ctx, limitsOnly("second", "2"))).To(MatchError(ContainSubstring("requests.cpu: 2 requested, 2 of 3 used")))
+		})
+
+		It("should require capped resources to be set", func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota", Namespace: namespace},
+				Spec: v1alpha1.BuildkitQuotaSpec{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+			})).To(Succeed())
+
+			Expect(c.Create(ctx, newBuildkit("test-buildkit", "1"))).To(MatchError(ContainSubstring("requests.memory: must be set")))
+		})
+
+		It("should reject raising resources beyond the quota, but not lowering them", func() {
+			Expect(c.Create(ctx, &v1alpha1.BuildkitQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-quota", Namespace: namespace},
+				Spec: v1alpha1.BuildkitQuotaSpec{
+					Instances: new(int32(2)),
+					Requests:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+				},
+			})).To(Succeed())
+
+			first := newBuildkit("first", "1")
+			Expect(c.Create(ctx, first)).To(Succeed())
+			Expect(c.Create(ctx, newBuildkit("second", "1"))).To(Succeed())
+
+			By("raising the first Buildkit's requests within the quota, which doesn't count it twice")
+			first.Spec.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+			Expect(c.Update(ctx, first)).To(Succeed())
+
+			By("raising them beyond the quota")
+			first.Spec.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("4")
+			Expect(c.Update(ctx, first)).To(MatchError(And(
+				ContainSubstring("exceeded BuildkitQuota 'test-quota'"),
+				ContainSubstring("requests.cpu: 4 requested, 1 of 3 used"),
+			)))
+
+			By("lowering them")
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(first), first)).To(Succeed())
+			first.Spec.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("500m")
+			Expect(c.Update(ctx, first)).To(Succeed())
+		})
+	})
+
 	Context("When updating an existing Buildkit resource", func() {
 		It("should allow updates to the metadata", func() {
 			buildkit := &v1alpha1.Buildkit{
@@ -192,7 +758,7 @@ var _ = Describe("BuildkitValidator", func() {
 			Expect(c.Update(ctx, buildkit)).To(Succeed())
 		})
 
-		It("should disallow updates to the spec", func() {
+		It("should only allow updates to the mutable spec fields", func() {
 			buildkit := &v1alpha1.Buildkit{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-buildkit",
@@ -223,31 +789,41 @@ var _ = Describe("BuildkitValidator", func() {
 
 			// Try changing the template
 			buildkit.Spec.Template = "some-other-template"
-			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec changes are not allowed")))
+			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec.template: Invalid value: \"some-other-template\": field is immutable")))
 			// Reload to make sure the change didn't persist
 			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), buildkit)).To(Succeed())
 			Expect(buildkit.Spec.Template).To(Equal(someExistingTemplateName))
 
-			// Try changing the resources
-			buildkit.Spec.Resources.Requests[corev1.ResourceMemory] = resource.MustParse("512Mi")
-			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec changes are not allowed")))
+			// Try changing the cache key
+			buildkit.Spec.CacheKey = "some-other-cache"
+			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec.cacheKey: Invalid value: \"some-other-cache\": field is immutable")))
 			// Reload to make sure the change didn't persist
 			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), buildkit)).To(Succeed())
-			Expect(*buildkit.Spec.Resources.Requests.Memory()).To(Equal(resource.MustParse("256Mi")))
+			Expect(buildkit.Spec.CacheKey).To(BeEmpty())
+
+			// Change the resources
+			buildkit.Spec.Resources.Requests[corev1.ResourceMemory] = resource.MustParse("512Mi")
+			Expect(c.Update(ctx, buildkit)).To(Succeed())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), buildkit)).To(Succeed())
+			Expect(*buildkit.Spec.Resources.Requests.Memory()).To(Equal(resource.MustParse("512Mi")))
 
-			// Try changing the annotations
+			// Change the annotations
 			buildkit.Spec.Annotations["new-annotation"] = "new-value"
-			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec changes are not allowed")))
-			// Reload to make sure the change didn't persist
+			Expect(c.Update(ctx, buildkit)).To(Succeed())
 			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), buildkit)).To(Succeed())
-			Expect(buildkit.Spec.Annotations).To(Not(HaveKey("new-annotation")))
+			Expect(buildkit.Spec.Annotations).To(HaveKeyWithValue("new-annotation", "new-value"))
 
-			// Try changing the labels
+			// Change the labels
 			buildkit.Spec.Labels["bar"] = "baz"
-			Expect(c.Update(ctx, buildkit)).To(MatchError(ContainSubstring("spec changes are not allowed")))
-			// Reload to make sure the change didn't persist
+			Expect(c.Update(ctx, buildkit)).To(Succeed())
 			Expect(c.Get(ctx, client.ObjectKeyFromObject(buildkit), buildkit)).To(Succeed())
-			Expect(buildkit.Spec.Labels["bar"]).To(Equal("bar"))
+			Expect(buildkit.Spec.Labels["bar"]).To(Equal("baz"))
+
+			// Suspending and resuming is allowed
+			buildkit.Spec.Suspended = true
+			Expect(c.Update(ctx, buildkit)).To(Succeed())
+			buildkit.Spec.Suspended = false
+			Expect(c.Update(ctx, buildkit)).To(Succeed())
 		})
 	})
 })
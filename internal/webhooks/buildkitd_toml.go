@@ -0,0 +1,440 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// tomlType is the type of a value in buildkitd.toml, as buildkitd decodes it.
+type tomlType int
+
+const (
+	tomlString tomlType = iota
+	tomlBool
+	tomlInteger
+	// tomlDuration is a duration like "48h", or a number of seconds
+	tomlDuration
+	// tomlDiskSpace is a number of bytes, a size like "512MB", or a percentage of the disk like "10%"
+	tomlDiskSpace
+	tomlArray
+	tomlTable
+	tomlTableArray
+	// tomlMap is a table whose keys are chosen by the user, such as registry hostnames
+	tomlMap
+	// tomlAny is passed on to something else by buildkitd, such as the stargz snapshotter, so it isn't checked
+	tomlAny
+)
+
+// tomlSchema models a value in buildkitd.toml.
+type tomlSchema struct {
+	typ    tomlType
+	fields map[string]*tomlSchema // the keys of a table or the tables in an array
+	elem   *tomlSchema            // the elements of an array or the values of a map
+	enum   []string               // the values a string may take, if limited
+}
+
+var (
+	tomlStringSchema    = &tomlSchema{typ: tomlString}
+	tomlBoolSchema      = &tomlSchema{typ: tomlBool}
+	tomlIntegerSchema   = &tomlSchema{typ: tomlInteger}
+	tomlDurationSchema  = &tomlSchema{typ: tomlDuration}
+	tomlDiskSpaceSchema = &tomlSchema{typ: tomlDiskSpace}
+	tomlAnySchema       = &tomlSchema{typ: tomlAny}
+	tomlStringsSchema   = &tomlSchema{typ: tomlArray, elem: tomlStringSchema}
+)
+
+func tomlEnum(values ...string) *tomlSchema {
+	return &tomlSchema{typ: tomlString, enum: values}
+}
+
+func tomlTableOf(fields ...map[string]*tomlSchema) *tomlSchema {
+	all := map[string]*tomlSchema{}
+	for _, f := range fields {
+		maps.Copy(all, f)
+	}
+
+	return &tomlSchema{typ: tomlTable, fields: all}
+}
+
+// buildkitdSchema models the buildkitd.toml understood by the buildkitd versions the operator supports.
+// See https://docs.docker.com/build/buildkit/toml-configuration/
+var buildkitdSchema = func() *tomlSchema {
+	gc := map[string]*tomlSchema{
+		"gc":            tomlBoolSchema,
+		"gckeepstorage": tomlDiskSpaceSchema,
+		"reservedSpace": tomlDiskSpaceSchema,
+		"maxUsedSpace":  tomlDiskSpaceSchema,
+		"minFreeSpace":  tomlDiskSpaceSchema,
+		"gcpolicy": {typ: tomlTableArray, fields: map[string]*tomlSchema{
+			"all":           tomlBoolSchema,
+			"filters":       tomlStringsSchema,
+			"keepDuration":  tomlDurationSchema,
+			"keepBytes":     tomlDiskSpaceSchema,
+			"reservedSpace": tomlDiskSpaceSchema,
+			"maxUsedSpace":  tomlDiskSpaceSchema,
+			"minFreeSpace":  tomlDiskSpaceSchema,
+		}},
+	}
+
+	network := map[string]*tomlSchema{
+		"networkMode":   tomlEnum("auto", "cni", "host", "bridge"),
+		"cniConfigPath": tomlStringSchema,
+		"cniBinaryPath": tomlStringSchema,
+		"cniPoolSize":   tomlIntegerSchema,
+		"bridgeName":    tomlStringSchema,
+		"bridgeSubnet":  tomlStringSchema,
+	}
+
+	worker := map[string]*tomlSchema{
+		"enabled":             tomlBoolSchema,
+		"labels":              {typ: tomlMap, elem: tomlStringSchema},
+		"platforms":           tomlStringsSchema,
+		"snapshotter":         tomlStringSchema,
+		"rootless":            tomlBoolSchema,
+		"apparmor-profile":    tomlStringSchema,
+		"selinux":             tomlBoolSchema,
+		"max-parallelism":     tomlIntegerSchema,
+		"defaultCgroupParent": tomlStringSchema,
+	}
+
+	return tomlTableOf(map[string]*tomlSchema{
+		"debug":                 tomlBoolSchema,
+		"trace":                 tomlBoolSchema,
+		"root":                  tomlStringSchema,
+		"insecure-entitlements": tomlStringsSchema,
+		"log": tomlTableOf(map[string]*tomlSchema{
+			"format": tomlEnum("json", "text"),
+		}),
+		"grpc": tomlTableOf(map[string]*tomlSchema{
+			"address":            tomlStringsSchema,
+			"debugAddress":       tomlStringSchema,
+			"uid":                tomlIntegerSchema,
+			"gid":                tomlIntegerSchema,
+			"securityDescriptor": tomlStringSchema,
+			"tls": tomlTableOf(map[string]*tomlSchema{
+				"cert": tomlStringSchema,
+				"key":  tomlStringSchema,
+				"ca":   tomlStringSchema,
+			}),
+		}),
+		"otel": tomlTableOf(map[string]*tomlSchema{
+			"socketPath": tomlStringSchema,
+		}),
+		"cdi": tomlTableOf(map[string]*tomlSchema{
+			"disabled":    tomlBoolSchema,
+			"specDirs":    tomlStringsSchema,
+			"autoAllowed": tomlStringsSchema,
+		}),
+		"worker": tomlTableOf(map[string]*tomlSchema{
+			"oci": tomlTableOf(worker, gc, network, map[string]*tomlSchema{
+				"noProcessSandbox":     tomlBoolSchema,
+				"userRemapUnsupported": tomlStringSchema,
+				"binary":               tomlStringSchema,
+				"proxySnapshotterPath": tomlStringSchema,
+				"stargzSnapshotter":    tomlAnySchema,
+			}),
+			"containerd": tomlTableOf(worker, gc, network, map[string]*tomlSchema{
+				"address":   tomlStringSchema,
+				"namespace": tomlStringSchema,
+				"runtime": tomlTableOf(map[string]*tomlSchema{
+					"name":    tomlStringSchema,
+					"path":    tomlStringSchema,
+					"options": tomlAnySchema,
+				}),
+			}),
+		}),
+		"registry": {typ: tomlMap, elem: tomlTableOf(map[string]*tomlSchema{
+			"mirrors":      tomlStringsSchema,
+			"http":         tomlBoolSchema,
+			"insecure":     tomlBoolSchema,
+			"ca":           tomlStringsSchema,
+			"tlsconfigdir": tomlStringsSchema,
+			"keypair": {typ: tomlTableArray, fields: map[string]*tomlSchema{
+				"key":  tomlStringSchema,
+				"cert": tomlStringSchema,
+			}},
+		})},
+		"dns": tomlTableOf(map[string]*tomlSchema{
+			"nameservers":   tomlStringsSchema,
+			"options":       tomlStringsSchema,
+			"searchDomains": tomlStringsSchema,
+		}),
+		"history": tomlTableOf(map[string]*tomlSchema{
+			"maxAge":     tomlDurationSchema,
+			"maxEntries": tomlIntegerSchema,
+		}),
+		"frontend": tomlTableOf(map[string]*tomlSchema{
+			"dockerfile.v0": tomlTableOf(map[string]*tomlSchema{
+				"enabled": tomlBoolSchema,
+			}),
+			"gateway.v0": tomlTableOf(map[string]*tomlSchema{
+				"enabled":             tomlBoolSchema,
+				"allowedRepositories": tomlStringsSchema,
+			}),
+		}),
+		"system": tomlTableOf(map[string]*tomlSchema{
+			"platformsCacheMaxAge": tomlDurationSchema,
+		}),
+	})
+}()
+
+// diskSizePattern matches the sizes buildkitd accepts for disk space, like "512MB" or "10GiB".
+var diskSizePattern = regexp.MustCompile(`^\d+(\.\d+)? ?[kKmMgGtTpP]?[iI]?[bB]?$`)
+
+// validateBuildkitdToml checks a decoded buildkitd.toml against buildkitd's config schema. Keys buildkitd doesn't know
+// are only warned about, since it ignores them, while values it can't decode are errors, since it won't start.
+func validateBuildkitdToml(doc map[string]any, path *field.Path) ([]string, field.ErrorList) {
+	return buildkitdSchema.validate(doc, path)
+}
+
+func (s *tomlSchema) validate(value any, path *field.Path) ([]string, field.ErrorList) {
+	switch s.typ {
+	case tomlTable:
+		table, ok := value.(map[string]any)
+		if !ok {
+			return nil, field.ErrorList{field.TypeInvalid(path, value, "must be a table")}
+		}
+		return s.validateTable(table, path)
+
+	case tomlTableArray:
+		tables, ok := tomlTables(value)
+		if !ok {
+			return nil, field.ErrorList{field.TypeInvalid(path, value, "must be an array of tables")}
+		}
+
+		var warnings []string
+		var errs field.ErrorList
+		for i, table := range tables {
+			w, e := s.validateTable(table, path.Index(i))
+			warnings, errs = append(warnings, w...), append(errs, e...)
+		}
+		return warnings, errs
+
+	case tomlArray, tomlMap:
+		return s.validateElements(value, path)
+
+	default:
+		return nil, s.validateScalar(value, path)
+	}
+}
+
+// validateTable checks each key of a table, warning about the ones buildkitd doesn't know.
+func (s *tomlSchema) validateTable(table map[string]any, path *field.Path) ([]string, field.ErrorList) {
+	var warnings []string
+	var errs field.ErrorList
+
+	for _, key := range slices.Sorted(maps.Keys(table)) {
+		keyPath := tomlKeyPath(path, key)
+
+		schema, known := s.fields[key]
+		if !known {
+			warning := fmt.Sprintf("%s: unknown buildkitd.toml key, which buildkitd ignores", keyPath)
+			if suggestion := closestKey(key, s.fields); suggestion != "" {
+				warning += fmt.Sprintf("; did you mean %q?", suggestion)
+			}
+			warnings = append(warnings, warning)
+			continue
+		}
+
+		w, e := schema.validate(table[key], keyPath)
+		warnings, errs = append(warnings, w...), append(errs, e...)
+	}
+
+	return warnings, errs
+}
+
+// validateElements checks the elements of an array or the values of a map.
+func (s *tomlSchema) validateElements(value any, path *field.Path) ([]string, field.ErrorList) {
+	var warnings []string
+	var errs field.ErrorList
+
+	switch v := value.(type) {
+	case []any:
+		if s.typ != tomlArray {
+			return nil, field.ErrorList{field.TypeInvalid(path, value, "must be a table")}
+		}
+		for i, elem := range v {
+			w, e := s.elem.validate(elem, path.Index(i))
+			warnings, errs = append(warnings, w...), append(errs, e...)
+		}
+
+	case map[string]any:
+		if s.typ != tomlMap {
+			return nil, field.ErrorList{field.TypeInvalid(path, value, "must be an array")}
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			w, e := s.elem.validate(v[key], path.Key(key))
+			warnings, errs = append(warnings, w...), append(errs, e...)
+		}
+
+	default:
+		if s.typ == tomlMap {
+			return nil, field.ErrorList{field.TypeInvalid(path, value, "must be a table")}
+		}
+		return nil, field.ErrorList{field.TypeInvalid(path, value, "must be an array")}
+	}
+
+	return warnings, errs
+}
+
+func (s *tomlSchema) validateScalar(value any, path *field.Path) field.ErrorList {
+	switch s.typ {
+	case tomlString:
+		str, ok := value.(string)
+		if !ok {
+			return field.ErrorList{field.TypeInvalid(path, value, "must be a string")}
+		}
+		if len(s.enum) > 0 && !slices.Contains(s.enum, str) {
+			return field.ErrorList{field.NotSupported(path, str, s.enum)}
+		}
+
+	case tomlBool:
+		if _, ok := value.(bool); !ok {
+			return field.ErrorList{field.TypeInvalid(path, value, "must be a boolean")}
+		}
+
+	case tomlInteger:
+		if _, ok := value.(int64); !ok {
+			return field.ErrorList{field.TypeInvalid(path, value, "must be an integer")}
+		}
+
+	case tomlDuration:
+		if !isTomlDuration(value) {
+			return field.ErrorList{field.Invalid(path, value, `must be a duration like "48h" or a number of seconds`)}
+		}
+
+	case tomlDiskSpace:
+		if !isTomlDiskSpace(value) {
+			return field.ErrorList{field.Invalid(path, value, `must be a number of bytes, a size like "512MB" or a percentage like "10%"`)}
+		}
+	}
+
+	return nil
+}
+
+func isTomlDuration(value any) bool {
+	switch v := value.(type) {
+	case int64:
+		return true
+	case string:
+		if _, err := time.ParseDuration(v); err == nil {
+			return true
+		}
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func isTomlDiskSpace(value any) bool {
+	switch v := value.(type) {
+	case int64:
+		return true
+	case string:
+		if percentage, ok := strings.CutSuffix(v, "%"); ok {
+			_, err := strconv.ParseInt(percentage, 10, 64)
+			return err == nil
+		}
+		return diskSizePattern.MatchString(v)
+	default:
+		return false
+	}
+}
+
+// tomlTables returns the tables of an array of tables, whether written as [[table]] sections or inline.
+func tomlTables(value any) ([]map[string]any, bool) {
+	switch v := value.(type) {
+	case []map[string]any:
+		return v, true
+	case []any:
+		tables := make([]map[string]any, 0, len(v))
+		for _, elem := range v {
+			table, ok := elem.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			tables = append(tables, table)
+		}
+		return tables, true
+	default:
+		return nil, false
+	}
+}
+
+// overriddenKeys returns the paths of the keys set in both documents whose value in base is replaced by the one in
+// overlay when they're merged, which is every key but those of tables present in both.
+func overriddenKeys(base, overlay map[string]any, path *field.Path) []string {
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(overlay)) {
+		existing, ok := base[key]
+		if !ok {
+			continue
+		}
+
+		baseTable, baseIsTable := existing.(map[string]any)
+		overlayTable, overlayIsTable := overlay[key].(map[string]any)
+		if baseIsTable && overlayIsTable {
+			keys = append(keys, overriddenKeys(baseTable, overlayTable, tomlKeyPath(path, key))...)
+		} else {
+			keys = append(keys, tomlKeyPath(path, key).String())
+		}
+	}
+
+	return keys
+}
+
+// tomlKeyPath returns the path of a key within a table, quoting keys that contain dots like TOML does.
+func tomlKeyPath(path *field.Path, key string) *field.Path {
+	if strings.Contains(key, ".") {
+		return path.Key(key)
+	}
+
+	return path.Child(key)
+}
+
+// closestKey returns the known key that an unknown one is most likely a typo of, if any is close enough.
+func closestKey(key string, fields map[string]*tomlSchema) string {
+	best, bestDistance := "", 3
+	for _, candidate := range slices.Sorted(maps.Keys(fields)) {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(candidate)); d < bestDistance && d < len(key) {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateBuildkitdToml(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		toml         string
+		wantWarnings []string
+		wantErrors   []string
+	}{
+		{
+			name: "valid config",
+			toml: `
+debug = true
+insecure-entitlements = ["network.host"]
+
+[log]
+  format = "json"
+
+[grpc]
+  address = ["tcp://0.0.0.0:1234"]
+  uid = 1000
+
+[worker.oci]
+  enabled = true
+  max-parallelism = 3
+  cniPoolSize = 16
+  networkMode = "bridge"
+  reservedSpace = "10%"
+  maxUsedSpace = "512MB"
+  labels = { team = "platform" }
+
+  [worker.oci.stargzSnapshotter.blob]
+    check_always = true
+
+  [[worker.oci.gcpolicy]]
+    keepBytes = 512000000
+    keepDuration = "48h"
+    filters = ["type==source.local"]
+
+  [[worker.oci.gcpolicy]]
+    all = true
+    keepDuration = 172800
+
+[worker.containerd]
+  enabled = false
+
+[registry."docker.io"]
+  mirrors = ["mirror.gcr.io"]
+  keypair = [{ key = "/etc/certs/key.pem", cert = "/etc/certs/cert.pem" }]
+
+[frontend."dockerfile.v0"]
+  enabled = true
+
+[history]
+  maxAge = "24h"
+  maxEntries = 100`,
+		},
+		{
+			name: "unknown keys",
+			toml: `
+[worker.oic]
+  enabled = true
+
+[worker.oci]
+  max-paralelism = 3
+  somethingElse = true`,
+			wantWarnings: []string{
+				`spec.buildkitdToml.worker.oci.max-paralelism: unknown buildkitd.toml key, which buildkitd ignores; did you mean "max-parallelism"?`,
+				"spec.buildkitdToml.worker.oci.somethingElse: unknown buildkitd.toml key, which buildkitd ignores",
+				`spec.buildkitdToml.worker.oic: unknown buildkitd.toml key, which buildkitd ignores; did you mean "oci"?`,
+			},
+		},
+		{
+			name: "values of the wrong type",
+			toml: `
+debug = "yes"
+
+[worker.oci]
+  max-parallelism = "3"
+  platforms = "linux/amd64"
+
+[registry."docker.io"]
+  http = 1`,
+			wantErrors: []string{
+				`spec.buildkitdToml.debug: Invalid value: "yes": must be a boolean`,
+				`spec.buildkitdToml.registry[docker.io].http: Invalid value: 1: must be a boolean`,
+				`spec.buildkitdToml.worker.oci.max-parallelism: Invalid value: "3": must be an integer`,
+				`spec.buildkitdToml.worker.oci.platforms: Invalid value: "linux/amd64": must be an array`,
+			},
+		},
+		{
+			name: "unsupported enum values",
+			toml: `
+[log]
+  format = "yaml"
+
+[worker.containerd]
+  networkMode = "none"`,
+			wantErrors: []string{
+				`spec.buildkitdToml.log.format: Unsupported value: "yaml": supported values: "json", "text"`,
+				`spec.buildkitdToml.worker.containerd.networkMode: Unsupported value: "none": supported values: "auto", "cni", "host", "bridge"`,
+			},
+		},
+		{
+			name: "invalid durations and disk space",
+			toml: `
+[history]
+  maxAge = "two days"
+
+[[worker.oci.gcpolicy]]
+  keepBytes = "lots"
+
+[[worker.oci.gcpolicy]]
+  keepBytes = "1.2.3GB"`,
+			wantErrors: []string{
+				`spec.buildkitdToml.history.maxAge: Invalid value: "two days": must be a duration like "48h" or a number of seconds`,
+				`spec.buildkitdToml.worker.oci.gcpolicy[0].keepBytes: Invalid value: "lots": must be a number of bytes, a size like "512MB" or a percentage like "10%"`,
+				`spec.buildkitdToml.worker.oci.gcpolicy[1].keepBytes: Invalid value: "1.2.3GB": must be a number of bytes, a size like "512MB" or a percentage like "10%"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var doc map[string]any
+			_, err := toml.Decode(tt.toml, &doc)
+			require.NoError(t, err)
+
+			warnings, errs := validateBuildkitdToml(doc, field.NewPath("spec", "buildkitdToml"))
+			assert.Equal(t, tt.wantWarnings, warnings)
+
+			var gotErrors []string
+			for _, e := range errs {
+				gotErrors = append(gotErrors, e.Error())
+			}
+			assert.Equal(t, tt.wantErrors, gotErrors)
+		})
+	}
+}
+
+func TestOverriddenKeys(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]any{
+		"debug": true,
+		"worker": map[string]any{
+			"oci": map[string]any{"gc": true, "max-parallelism": int64(2)},
+		},
+		"registry": map[string]any{
+			"docker.io": map[string]any{"mirrors": []any{"mirror.example.com"}},
+		},
+		"history": int64(3),
+	}
+	overlay := map[string]any{
+		"worker": map[string]any{
+			"oci": map[string]any{"max-parallelism": int32(4)},
+		},
+		"registry": map[string]any{
+			"docker.io": map[string]any{"mirrors": []string{"mirror.gcr.io"}},
+			"quay.io":   map[string]any{"insecure": true},
+		},
+		"history": map[string]any{"maxEntries": int64(10)},
+	}
+
+	got := overriddenKeys(base, overlay, field.NewPath("spec", "buildkitdToml"))
+
+	assert.Equal(t, []string{
+		"spec.buildkitdToml.history",
+		`spec.buildkitdToml.registry[docker.io].mirrors`,
+		"spec.buildkitdToml.worker.oci.max-parallelism",
+	}, got)
+}
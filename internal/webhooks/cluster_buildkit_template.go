@@ -0,0 +1,94 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-buildkit-seatgeek-io-v1alpha1-clusterbuildkittemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=buildkit.seatgeek.io,resources=clusterbuildkittemplates,verbs=create;update,versions=v1alpha1,name=mclusterbuildkittemplate.kb.io,admissionReviewVersions=v1
+
+type ClusterBuildkitTemplateValidator struct{}
+
+var _ webhook.CustomValidator = (*ClusterBuildkitTemplateValidator)(nil)
+
+func (v *ClusterBuildkitTemplateValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	cbt, ok := obj.(*v1alpha1.ClusterBuildkitTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterBuildkitTemplate object but got %T", obj))
+	}
+
+	// Validate the name and the spec shared with BuildkitTemplates
+	errorList := validateTemplateName(cbt.Name)
+	warnings, specErrors := validateTemplateSpec(cbt.Spec.BuildkitTemplateSpec)
+	errorList = append(errorList, specErrors...)
+
+	// Validate the namespace selector
+	if cbt.Spec.NamespaceSelector != nil {
+		errorList = append(errorList, metav1validation.ValidateLabelSelector(
+			cbt.Spec.NamespaceSelector,
+			metav1validation.LabelSelectorValidationOptions{},
+			field.NewPath("spec", "namespaceSelector"),
+		)...)
+	}
+
+	if len(errorList) > 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: v1alpha1.SchemeGroupVersion.Group,
+				Kind:  "ClusterBuildkitTemplate",
+			},
+			cbt.Name,
+			errorList,
+		)
+	}
+
+	// Buildkits are only checked against the selector when they're created, so narrowing it doesn't affect existing ones
+	if cbt.Spec.NamespaceSelector == nil {
+		warnings = append(warnings, "spec.namespaceSelector is unset, so no namespace may use this ClusterBuildkitTemplate")
+	}
+
+	return warnings, nil
+}
+
+func (v *ClusterBuildkitTemplateValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+func (v *ClusterBuildkitTemplateValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(newObj)
+}
+
+func (v *ClusterBuildkitTemplateValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	// No validation needed on delete
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/mutate-buildkit-seatgeek-io-v1alpha1-clusterbuildkittemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=buildkit.seatgeek.io,resources=clusterbuildkittemplates,verbs=create;update,versions=v1alpha1,name=mclusterbuildkittemplate.kb.io,admissionReviewVersions=v1
+
+type ClusterBuildkitTemplateDefaulter struct{}
+
+func (d ClusterBuildkitTemplateDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cbt, ok := obj.(*v1alpha1.ClusterBuildkitTemplate)
+	if !ok {
+		return fmt.Errorf("expected ClusterBuildkitTemplate object, got %T", obj)
+	}
+
+	defaultTemplateSpec(&cbt.Spec.BuildkitTemplateSpec)
+	return nil
+}
+
+var _ webhook.CustomDefaulter = (*ClusterBuildkitTemplateDefaulter)(nil)
@@ -0,0 +1,74 @@
+// Copyright 2026 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhooks
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktest "github.com/reddit/achilles-sdk/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/seatgeek/buildkit-operator/api/v1alpha1"
+)
+
+var _ = Describe("ClusterBuildkitTemplate webhooks", func() {
+	var name string
+
+	BeforeEach(func() {
+		name = fmt.Sprintf("webhook-test-%s", sdktest.GenerateRandomString(8))
+
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(c.Delete(ctx, &v1alpha1.ClusterBuildkitTemplate{ObjectMeta: metav1.ObjectMeta{Name: name}}))).To(Succeed())
+		})
+	})
+
+	It("should default the same fields as a BuildkitTemplate", func() {
+		clusterTemplate := &v1alpha1.ClusterBuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.ClusterBuildkitTemplateSpec{
+				NamespaceSelector: &metav1.LabelSelector{},
+			},
+		}
+
+		Expect(c.Create(ctx, clusterTemplate)).To(Succeed())
+		Expect(clusterTemplate.Spec.Port).To(Equal(int32(1234)))
+		Expect(clusterTemplate.Spec.Image).To(Equal("moby/buildkit:latest"))
+		Expect(clusterTemplate.Spec.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+		Expect(clusterTemplate.Spec.Storage.Type).To(Equal(v1alpha1.StorageTypeEmptyDir))
+	})
+
+	It("should validate the spec like a BuildkitTemplate", func() {
+		clusterTemplate := &v1alpha1.ClusterBuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.ClusterBuildkitTemplateSpec{
+				BuildkitTemplateSpec: v1alpha1.BuildkitTemplateSpec{
+					BuildkitdToml: "invalid toml [",
+				},
+				NamespaceSelector: &metav1.LabelSelector{},
+			},
+		}
+
+		Expect(c.Create(ctx, clusterTemplate)).To(MatchError(ContainSubstring("spec.buildkitdToml")))
+	})
+
+	It("should reject an invalid namespace selector", func() {
+		clusterTemplate := &v1alpha1.ClusterBuildkitTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.ClusterBuildkitTemplateSpec{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: metav1.LabelSelectorOpIn},
+					},
+				},
+			},
+		}
+
+		Expect(c.Create(ctx, clusterTemplate)).To(MatchError(ContainSubstring("spec.namespaceSelector.matchExpressions[0].values")))
+	})
+})
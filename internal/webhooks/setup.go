@@ -15,11 +15,16 @@ import (
 func SetupWebhooks(mgr ctrl.Manager) error {
 	return errors.Join(
 		ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Buildkit{}).
-			WithValidator(NewBuildkitValidator(mgr.GetClient())).
+			WithDefaulter(NewBuildkitDefaulter(mgr.GetAPIReader())).
+			WithValidator(NewBuildkitValidator(mgr.GetAPIReader())).
 			Complete(),
 		ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.BuildkitTemplate{}).
 			WithDefaulter(&BuildkitTemplateDefaulter{}).
-			WithValidator(&BuildkitTemplateValidator{}).
+			WithValidator(NewBuildkitTemplateValidator(mgr.GetAPIReader())).
+			Complete(),
+		ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.ClusterBuildkitTemplate{}).
+			WithDefaulter(&ClusterBuildkitTemplateDefaulter{}).
+			WithValidator(&ClusterBuildkitTemplateValidator{}).
 			Complete(),
 	)
 }